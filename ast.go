@@ -48,25 +48,33 @@ func Doc(node ast.Node) string {
 	return ""
 }
 
+// Name returns an ast.Node's Name string.
+func Name(node ast.Node) string {
+	if v := reflect.ValueOf(node); v.Kind() == reflect.Ptr {
+		if f := v.Elem().FieldByName("Name"); f.IsValid() && f.Kind() == reflect.String {
+			return f.String()
+		}
+	}
+	return ""
+}
+
 // Resolve resolves a named reference to its target node.
 //
 // The target can either be in the current program's scope or it can refer to
-// an included file using dot notation. Included files must exist in one of the
+// an included file using dot notation, qualified by the include's name if it
+// has one. Included files must exist in one of the
 // given search directories.
 func Resolve(name string, program *ast.Program, dirs []string) (ast.Node, error) {
 	defs := program.Definitions
 
 	if strings.Contains(name, ".") {
 		parts := strings.SplitN(name, ".", 2)
-		fname := parts[0] + ".thrift"
 
 		var ipath string
 		for _, header := range program.Headers {
-			if include, ok := header.(*ast.Include); ok {
-				if _, file := filepath.Split(include.Path); file == fname {
-					ipath = include.Path
-					break
-				}
+			if include, ok := header.(*ast.Include); ok && includeName(include) == parts[0] {
+				ipath = include.Path
+				break
 			}
 		}
 		if ipath == "" {
@@ -91,6 +99,16 @@ func Resolve(name string, program *ast.Program, dirs []string) (ast.Node, error)
 	return nil, fmt.Errorf("%q could not be resolved", name)
 }
 
+// includeName returns the name that qualifies references to an included
+// file's definitions: the include's name, if it has one (include t
+// "types.thrift"), or else the file's base name without its extension.
+func includeName(include *ast.Include) string {
+	if include.Name != "" {
+		return include.Name
+	}
+	return strings.TrimSuffix(filepath.Base(include.Path), ".thrift")
+}
+
 // ResolveConstant resolves an [ast.ConstantReference] to its target node.
 //
 // The following name formats are supported:
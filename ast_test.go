@@ -65,6 +65,22 @@ func TestResolveConstant(t *testing.T) {
 			}}, reflect.TypeOf((*ast.EnumItem)(nil)),
 			false,
 		},
+		{
+			ast.ConstantReference{Name: "c.Status.ACTIVE"},
+			&ast.Program{Headers: []ast.Header{
+				&ast.Include{Name: "c", Path: "common.thrift"},
+			}},
+			reflect.TypeOf((*ast.EnumItem)(nil)),
+			false,
+		},
+		{
+			ast.ConstantReference{Name: "common.Status.ACTIVE"},
+			&ast.Program{Headers: []ast.Header{
+				&ast.Include{Name: "c", Path: "common.thrift"},
+			}},
+			nil,
+			true,
+		},
 		{
 			ast.ConstantReference{Name: "Unknown"},
 			&ast.Program{},
@@ -74,7 +90,7 @@ func TestResolveConstant(t *testing.T) {
 	}
 
 	for _, tt := range tests {
-		n, err := ResolveConstant(tt.ref, tt.prog, nil)
+		n, err := ResolveConstant(tt.ref, tt.prog, []string{"testdata"})
 		if tt.err {
 			if err == nil {
 				t.Errorf("expected an error, got %s", n)
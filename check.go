@@ -15,8 +15,10 @@
 package thriftcheck
 
 import (
+	"bytes"
 	"fmt"
 	"log"
+	"path/filepath"
 	"reflect"
 	"sort"
 	"strings"
@@ -27,8 +29,26 @@ import (
 
 // Check is a named check function.
 type Check struct {
-	Name string
-	fn   any
+	Name     string
+	Info     CheckInfo
+	fn       any
+	finalize func(*C)
+	calls    *int
+	kinds    []NodeKind
+}
+
+// CheckInfo describes a check for documentation purposes.
+type CheckInfo struct {
+	// Description is a short summary of what the check reports.
+	Description string
+	// Severity is the severity the check typically reports.
+	Severity Severity
+	// Rationale explains why the check exists.
+	Rationale string
+	// Bad is an example of Thrift source that violates the check.
+	Bad string
+	// Good is an example of Thrift source that satisfies the check.
+	Good string
 }
 
 // Checks is a list of checks.
@@ -56,7 +76,36 @@ func NewCheck(name string, fn any) Check {
 		}
 	}
 
-	return Check{Name: name, fn: fn}
+	return Check{Name: name, fn: fn, kinds: kindsMatching(f.In(f.NumIn() - 1))}
+}
+
+// NodeKinds returns the kinds of nodes that the check inspects. The linter
+// skips any part of the tree that can't contain one of these kinds.
+func (c *Check) NodeKinds() []NodeKind {
+	return c.kinds
+}
+
+// NewMultiFileCheck creates a new Check that accumulates state across all of
+// the files processed by a linter run. fn is called like a NewCheck function
+// for each file's nodes, and finalize is called once after all of the files
+// have been linted. finalize reports its messages using the C's "At" methods
+// and is responsible for resetting any accumulated state. It can use
+// C.Received to tell whether fn was called for any nodes since the check was
+// last finalized.
+func NewMultiFileCheck(name string, fn any, finalize func(*C)) Check {
+	if finalize == nil {
+		panic("finalize function must be a Func; got nil")
+	}
+
+	check := NewCheck(name, fn)
+	check.finalize = finalize
+	check.calls = new(int)
+	return check
+}
+
+// IsMultiFile reports whether the check is a multi-file check.
+func (c *Check) IsMultiFile() bool {
+	return c.finalize != nil
 }
 
 // Call the check function if its arguments end with the current node in the
@@ -117,6 +166,25 @@ func (c *Check) Call(ctx *C, nodes ...ast.Node) bool {
 
 	ctx.Check = c.Name
 	reflect.ValueOf(c.fn).Call(args)
+	if c.calls != nil {
+		*c.calls++
+	}
+	return true
+}
+
+// Finalize calls a multi-file check's finalize function. It returns false
+// for single-file checks.
+func (c *Check) Finalize(ctx *C) bool {
+	if c.finalize == nil {
+		return false
+	}
+
+	ctx.Check = c.Name
+	ctx.received = c.calls != nil && *c.calls > 0
+	c.finalize(ctx)
+	if c.calls != nil {
+		*c.calls = 0
+	}
 	return true
 }
 
@@ -172,6 +240,11 @@ type C struct {
 	Messages  Messages
 	logger    *log.Logger
 	parseInfo *idl.Info
+	src       []byte
+	received  bool
+	includes  []string
+	programs  *programCache
+	symbols   *Symbols
 }
 
 func (c *C) pos(n ast.Node) ast.Position {
@@ -189,18 +262,79 @@ func (c *C) Logf(message string, args ...any) {
 	}
 }
 
+// Received reports whether a multi-file check's function was called for any
+// nodes before its finalize function. It is only meaningful during finalize.
+func (c *C) Received() bool {
+	return c.received
+}
+
+// Location identifies a node within a linted file. Multi-file checks record
+// locations while visiting nodes so that they can report messages against
+// them from their finalize functions.
+type Location struct {
+	Filename string
+	Pos      ast.Position
+	Node     ast.Node
+}
+
+// Locate returns the Location of a node in the current file.
+func (c *C) Locate(node ast.Node) Location {
+	return Location{Filename: c.Filename, Pos: c.pos(node), Node: node}
+}
+
 // Warningf records a new message for the given node with Warning severity.
 func (c *C) Warningf(node ast.Node, message string, args ...any) {
-	m := Message{Filename: c.Filename, Pos: c.pos(node), Node: node, Check: c.Check, Severity: Warning, Message: fmt.Sprintf(message, args...)}
-	c.Messages = append(c.Messages, m)
+	c.WarningfAt(c.Locate(node), message, args...)
 }
 
 // Errorf records a new message for the given node with Error severity.
 func (c *C) Errorf(node ast.Node, message string, args ...any) {
-	m := Message{Filename: c.Filename, Pos: c.pos(node), Node: node, Check: c.Check, Severity: Error, Message: fmt.Sprintf(message, args...)}
+	c.ErrorfAt(c.Locate(node), message, args...)
+}
+
+// WarningfAt records a new message at the given location with Warning severity.
+func (c *C) WarningfAt(loc Location, message string, args ...any) {
+	m := Message{Filename: loc.Filename, Pos: loc.Pos, Node: loc.Node, Check: c.Check, Severity: Warning, Message: fmt.Sprintf(message, args...)}
+	c.Messages = append(c.Messages, m)
+}
+
+// ErrorfAt records a new message at the given location with Error severity.
+func (c *C) ErrorfAt(loc Location, message string, args ...any) {
+	m := Message{Filename: loc.Filename, Pos: loc.Pos, Node: loc.Node, Check: c.Check, Severity: Error, Message: fmt.Sprintf(message, args...)}
 	c.Messages = append(c.Messages, m)
 }
 
+// Offset returns the byte offset of a node's position in the current file's
+// source. It returns false if the source or the node's position isn't known.
+func (c *C) Offset(node ast.Node) (int, bool) {
+	pos := c.pos(node)
+	if c.src == nil || pos.Line < 1 {
+		return 0, false
+	}
+	offset := 0
+	for line := 1; line < pos.Line; line++ {
+		i := bytes.IndexByte(c.src[offset:], '\n')
+		if i < 0 {
+			return 0, false
+		}
+		offset += i + 1
+	}
+	if pos.Column > 1 {
+		offset += pos.Column - 1
+	}
+	if offset > len(c.src) {
+		return 0, false
+	}
+	return offset, true
+}
+
+// SuggestFix attaches a suggested edit to the most recently recorded message.
+func (c *C) SuggestFix(edit Edit) {
+	if len(c.Messages) > 0 {
+		c.Messages[len(c.Messages)-1].Fix = &edit
+	}
+}
+
 // Resolve resolves a name.
 func (c *C) Resolve(name string) ast.Node {
 	if n, err := Resolve(name, c.Program, c.Dirs); err == nil {
@@ -217,6 +351,36 @@ func (c *C) ResolveConstant(ref ast.ConstantReference) ast.Node {
 	return nil
 }
 
+// Symbols returns the symbol table of the current file, building it the first
+// time it's needed. It returns nil when there is no current file, such as
+// in a multi-file check's finalize function; use SymbolsFor there instead.
+func (c *C) Symbols() *Symbols {
+	if c.symbols == nil && c.Program != nil {
+		c.symbols = c.programs.symbolsFor(c.Filename, c.Program, c.Dirs)
+	}
+	return c.symbols
+}
+
+// SymbolsFor returns the symbol table of a linted file or of a file that it
+// includes. The table is built once per run and shared between checks, so it
+// is also available in a multi-file check's finalize function. It returns nil
+// if the file can't be parsed.
+func (c *C) SymbolsFor(filename string) *Symbols {
+	if c.Program != nil && filepath.Clean(filename) == filepath.Clean(c.Filename) {
+		return c.Symbols()
+	}
+	return c.programs.symbolsFor(filename, nil, append([]string{filepath.Dir(filename)}, c.includes...))
+}
+
+// Included returns the program parsed from a file, such as one found through
+// an include. Files are parsed once per run, using the linter's ParseOptions,
+// and shared between checks. It returns an error if the file can't be read or
+// parsed; the linter also reports files that can't be read as
+// include.unreadable warnings, so checks needn't report those themselves.
+func (c *C) Included(path string) (*ast.Program, error) {
+	return c.programs.load(path)
+}
+
 // ResolveType resolves a type reference to its target type.
 func (c *C) ResolveType(ref ast.TypeReference) ast.Node {
 	if n, err := ResolveType(ref, c.Program, c.Dirs); err == nil {
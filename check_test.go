@@ -48,6 +48,43 @@ func TestNewCheck(t *testing.T) {
 	}
 }
 
+func TestNewMultiFileCheck(t *testing.T) {
+	defer func() { _ = recover() }()
+	NewMultiFileCheck("", func(c *C, n ast.Node) {}, nil)
+	t.Errorf("should have panicked")
+}
+
+func TestFinalize(t *testing.T) {
+	finalized := false
+	received := false
+	check := NewMultiFileCheck("multi", func(c *C, n ast.Node) {}, func(c *C) {
+		finalized = true
+		received = c.Received()
+		if c.Check != "multi" {
+			t.Errorf("expected check name %q, got %q", "multi", c.Check)
+		}
+	})
+	if !check.Finalize(&C{}) || !finalized {
+		t.Errorf("expected multi-file check to be finalized")
+	}
+	if received {
+		t.Errorf("expected multi-file check not to have received any nodes")
+	}
+
+	check.Call(&C{}, &ast.Struct{})
+	if check.Finalize(&C{}); !received {
+		t.Errorf("expected multi-file check to have received a node")
+	}
+	if check.Finalize(&C{}); received {
+		t.Errorf("expected finalize to reset the received state")
+	}
+
+	check = NewCheck("single", func(c *C, n ast.Node) {})
+	if check.Finalize(&C{}) {
+		t.Errorf("expected single-file check not to be finalized")
+	}
+}
+
 func TestCall(t *testing.T) {
 	nodes := []ast.Node{
 		&ast.Field{},
@@ -130,6 +167,24 @@ func TestC(t *testing.T) {
 	}
 }
 
+func TestCAt(t *testing.T) {
+	c := &C{Filename: "test.thrift", Check: "check"}
+	node := &ast.Struct{Line: 3}
+	loc := c.Locate(node)
+	c.Filename = "other.thrift"
+	c.WarningfAt(loc, "Warning")
+	c.ErrorfAt(loc, "Error")
+
+	expected := Messages{
+		Message{Filename: "test.thrift", Pos: ast.Position{Line: 3}, Node: node, Check: c.Check, Severity: Warning, Message: "Warning"},
+		Message{Filename: "test.thrift", Pos: ast.Position{Line: 3}, Node: node, Check: c.Check, Severity: Error, Message: "Error"},
+	}
+
+	if !reflect.DeepEqual(expected, c.Messages) {
+		t.Errorf("expected %s, got %s", expected, c.Messages)
+	}
+}
+
 func TestIsTypeAllowed(t *testing.T) {
 	var stringType ThriftType
 	if err := stringType.UnmarshalString("string"); err != nil {
@@ -0,0 +1,45 @@
+// Copyright 2026 Pinterest
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checks
+
+import (
+	"regexp"
+
+	"github.com/pinterest/thriftcheck"
+	"go.uber.org/thriftrw/ast"
+)
+
+var docTagRegexp = regexp.MustCompile(`@([\w.-]+)(?:\(([^)]*)\))?`)
+
+// annotation looks up a named annotation on a node. Like `nolint`
+// directives, annotations can be written as Thrift annotations (`(name =
+// "value")`) or as `@name(value)` tags in documentation blocks.
+func annotation(n ast.Node, name string) (string, bool) {
+	for _, a := range ast.Annotations(n) {
+		if a.Name == name {
+			return a.Value, true
+		}
+	}
+
+	if doc := thriftcheck.Doc(n); doc != "" {
+		for _, m := range docTagRegexp.FindAllStringSubmatch(doc, -1) {
+			if m[1] == name {
+				return m[2], true
+			}
+		}
+	}
+
+	return "", false
+}
@@ -0,0 +1,48 @@
+// Copyright 2026 Pinterest
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checks
+
+import (
+	"testing"
+
+	"go.uber.org/thriftrw/ast"
+)
+
+func TestAnnotation(t *testing.T) {
+	tests := []struct {
+		desc  string
+		node  ast.Node
+		value string
+		ok    bool
+	}{
+		{"none", &ast.Struct{}, "", false},
+		{"annotation", &ast.Struct{Annotations: []*ast.Annotation{{Name: "alias"}}}, "", true},
+		{"annotation value", &ast.Struct{Annotations: []*ast.Annotation{{Name: "alias", Value: "A"}}}, "A", true},
+		{"other annotation", &ast.Struct{Annotations: []*ast.Annotation{{Name: "aliases"}}}, "", false},
+		{"doc", &ast.Struct{Doc: "An alias.\n@alias"}, "", true},
+		{"doc value", &ast.Struct{Doc: "@alias(A)"}, "A", true},
+		{"doc other", &ast.Struct{Doc: "@aliases"}, "", false},
+		{"no doc or annotations", ast.BaseType{ID: ast.I32TypeID}, "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			value, ok := annotation(tt.node, "alias")
+			if ok != tt.ok || value != tt.value {
+				t.Errorf("expected (%q, %v), got (%q, %v)", tt.value, tt.ok, value, ok)
+			}
+		})
+	}
+}
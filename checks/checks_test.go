@@ -15,7 +15,12 @@
 package checks_test
 
 import (
+	"maps"
+	"os"
+	"path/filepath"
 	"reflect"
+	"slices"
+	"strings"
 	"testing"
 
 	"github.com/pinterest/thriftcheck"
@@ -56,6 +61,63 @@ func RunTests(t *testing.T, check *thriftcheck.Check, tests []Test) {
 	}
 }
 
+// MultiFileTest describes a set of Thrift sources (keyed by filename) that
+// are linted together.
+type MultiFileTest struct {
+	files map[string]string
+	// unlinted files are written alongside files but aren't linted, so they
+	// can only be reached through includes.
+	unlinted map[string]string
+	want     []string
+}
+
+// RunMultiFileTests writes each test's files to a temporary directory and
+// lints them together (in filename order). Filenames in the resulting
+// messages are relative to that directory.
+func RunMultiFileTests(t *testing.T, check *thriftcheck.Check, tests []MultiFileTest) {
+	t.Helper()
+
+	linter := thriftcheck.NewLinter(thriftcheck.Checks{*check})
+	for _, tt := range tests {
+		dir := t.TempDir()
+		filenames := make([]string, 0, len(tt.files))
+		for _, name := range slices.Sorted(maps.Keys(tt.files)) {
+			filename := filepath.Join(dir, name)
+			if err := os.MkdirAll(filepath.Dir(filename), 0o755); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(filename, []byte(tt.files[name]), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			filenames = append(filenames, filename)
+		}
+		for name, src := range tt.unlinted {
+			filename := filepath.Join(dir, name)
+			if err := os.MkdirAll(filepath.Dir(filename), 0o755); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(filename, []byte(src), 0o644); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		msgs, err := linter.LintFiles(filenames)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(tt.want) > 0 || len(msgs) > 0 {
+			lines := make([]string, len(msgs))
+			for i, m := range msgs {
+				lines[i] = strings.ReplaceAll(m.String(), dir+string(filepath.Separator), "")
+			}
+			if !reflect.DeepEqual(lines, tt.want) {
+				t.Errorf("%v:\n- %v\n+ %v", tt.files, tt.want, lines)
+			}
+		}
+	}
+}
+
 func ParseType(t *testing.T, name string) (thriftType thriftcheck.ThriftType) {
 	t.Helper()
 
@@ -0,0 +1,37 @@
+// Copyright 2025 Pinterest
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checks
+
+import (
+	"github.com/pinterest/thriftcheck"
+	"go.uber.org/thriftrw/ast"
+)
+
+// CheckConflictingAnnotations returns a thriftcheck.Check that reports an
+// error when a node carries both annotations of any of the given conflict
+// pairs. Annotations may be written as Thrift annotations or as `@name` tags
+// in documentation blocks.
+func CheckConflictingAnnotations(conflicts [][2]string) thriftcheck.Check {
+	return newCheck("annotation.conflict", func(c *thriftcheck.C, n ast.Node) {
+		for _, pair := range conflicts {
+			if _, ok := annotation(n, pair[0]); !ok {
+				continue
+			}
+			if _, ok := annotation(n, pair[1]); ok {
+				c.Errorf(n, "%q and %q annotations conflict", pair[0], pair[1])
+			}
+		}
+	})
+}
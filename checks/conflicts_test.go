@@ -0,0 +1,55 @@
+// Copyright 2025 Pinterest
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checks_test
+
+import (
+	"testing"
+
+	"github.com/pinterest/thriftcheck/checks"
+	"go.uber.org/thriftrw/ast"
+)
+
+func TestCheckConflictingAnnotations(t *testing.T) {
+	tests := []Test{
+		{
+			node: &ast.Field{Name: "id", Doc: "@required\n@optional"},
+			want: []string{
+				`t.thrift:0:1: error: "required" and "optional" annotations conflict (annotation.conflict)`,
+			},
+		},
+		{
+			node: &ast.Struct{Name: "S", Annotations: []*ast.Annotation{
+				{Name: "mutable"}, {Name: "immutable"}, {Name: "deprecated"},
+			}},
+			want: []string{
+				`t.thrift:0:1: error: "mutable" and "immutable" annotations conflict (annotation.conflict)`,
+			},
+		},
+		{
+			node: &ast.Field{Name: "id", Doc: "@required", Annotations: []*ast.Annotation{{Name: "deprecated"}}},
+			want: []string{},
+		},
+		{
+			node: &ast.Struct{Name: "S"},
+			want: []string{},
+		},
+	}
+
+	check := checks.CheckConflictingAnnotations([][2]string{
+		{"required", "optional"},
+		{"mutable", "immutable"},
+	})
+	RunTests(t, &check, tests)
+}
@@ -15,6 +15,14 @@
 package checks
 
 import (
+	"cmp"
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
 	"github.com/pinterest/thriftcheck"
 	"go.uber.org/thriftrw/ast"
 )
@@ -22,9 +30,173 @@ import (
 // CheckConstantRef returns a thriftcheck.Check that ensures that a constant
 // reference's target can be resolved.
 func CheckConstantRef() thriftcheck.Check {
-	return thriftcheck.NewCheck("constant.ref", func(c *thriftcheck.C, ref ast.ConstantReference) {
+	return newCheck("constant.ref", func(c *thriftcheck.C, ref ast.ConstantReference) {
 		if c.ResolveConstant(ref) == nil {
 			c.Errorf(ref, "unable to find a constant or enum value named %q", ref.Name)
 		}
 	})
 }
+
+// CheckNoStructConst returns a thriftcheck.Check that warns when a constant's
+// type resolves to a struct or union. Types defined in included files are
+// resolved using the include paths.
+func CheckNoStructConst() thriftcheck.Check {
+	return newCheck("const.struct.type", func(c *thriftcheck.C, k *ast.Constant) {
+		s, ok := resolveType(c, k.Type).(*ast.Struct)
+		if !ok {
+			return
+		}
+		switch s.Type {
+		case ast.StructType:
+			c.Warningf(k, "constant %q has struct type %q", k.Name, s.Name)
+		case ast.UnionType:
+			c.Warningf(k, "constant %q has union type %q", k.Name, s.Name)
+		}
+	})
+}
+
+// CheckQualifiedEnumConst returns a thriftcheck.Check that warns when the value
+// of an enum-typed constant is an unqualified identifier (`FOO`) rather than a
+// reference to the enum's item (`MyEnum.FOO`). References to other constants
+// are fine.
+func CheckQualifiedEnumConst() thriftcheck.Check {
+	return newCheck("const.enum.qualified", func(c *thriftcheck.C, k *ast.Constant) {
+		e, ok := resolveType(c, k.Type).(*ast.Enum)
+		if !ok {
+			return
+		}
+		ref, ok := k.Value.(ast.ConstantReference)
+		if !ok || strings.Contains(ref.Name, ".") {
+			return
+		}
+		if _, ok := c.ResolveConstant(ref).(*ast.Constant); ok {
+			return
+		}
+
+		qualifier := e.Name
+		if t, ok := k.Type.(ast.TypeReference); ok && typeBaseName(t.Name) == e.Name {
+			qualifier = t.Name
+		}
+		c.Warningf(k, "constant %q uses unqualified enum value %q; use %s.%s instead", k.Name, ref.Name, qualifier, ref.Name)
+	})
+}
+
+var defaultUnitNameRegexp = regexp.MustCompile(`(?i)(^|_)(ms|millis|milliseconds|secs|seconds|minutes|hours|days|bytes|kb|mb|gb)$`)
+
+// CheckConstUnitTypedef returns a thriftcheck.Check that warns when a numeric
+// constant whose name implies a unit (matching nameRegexp) isn't declared
+// with one of the given unit typedefs, like `Milliseconds` for TIMEOUT_MS.
+// Typedefs can be qualified with an include prefix, which isn't considered
+// when matching. If nameRegexp is nil, a default pattern matching common time
+// and size suffixes is used. No typedefs disables the check.
+func CheckConstUnitTypedef(nameRegexp *regexp.Regexp, typedefs []string) thriftcheck.Check {
+	if nameRegexp == nil {
+		nameRegexp = defaultUnitNameRegexp
+	}
+	units := make([]string, len(typedefs))
+	for i, name := range typedefs {
+		units[i] = typeBaseName(name)
+	}
+
+	return newCheck("const.unit.typedef", func(c *thriftcheck.C, k *ast.Constant) {
+		if len(units) == 0 || !nameRegexp.MatchString(k.Name) {
+			return
+		}
+		if ref, ok := k.Type.(ast.TypeReference); ok && slices.Contains(units, typeBaseName(ref.Name)) {
+			return
+		}
+		b, ok := resolveType(c, k.Type).(ast.BaseType)
+		if !ok {
+			return
+		}
+		switch b.ID {
+		case ast.I8TypeID, ast.I16TypeID, ast.I32TypeID, ast.I64TypeID, ast.DoubleTypeID:
+			c.Warningf(k, "constant %q has type %s, which doesn't name its unit; use one of: %s", k.Name, k.Type, strings.Join(typedefs, ", "))
+		}
+	})
+}
+
+// CheckConstDivergence returns a multi-file thriftcheck.Check that warns when
+// constants with the same name, matching nameRegexp, are defined with
+// different values in different files. Set items and map entries are
+// compared regardless of their order. A nil nameRegexp disables the check.
+func CheckConstDivergence(nameRegexp *regexp.Regexp) thriftcheck.Check {
+	type constant struct {
+		loc   thriftcheck.Location
+		value string
+	}
+	constants := make(map[string][]constant)
+
+	return newMultiFileCheck("const.divergence", func(c *thriftcheck.C, k *ast.Constant) {
+		if nameRegexp == nil || !nameRegexp.MatchString(k.Name) {
+			return
+		}
+		constants[k.Name] = append(constants[k.Name], constant{
+			loc:   c.Locate(k),
+			value: constantValueString(c, k.Type, k.Value),
+		})
+	}, func(c *thriftcheck.C) {
+		for _, name := range slices.Sorted(maps.Keys(constants)) {
+			first := constants[name][0]
+			for _, k := range constants[name][1:] {
+				if k.loc.Filename != first.loc.Filename && k.value != first.value {
+					c.WarningfAt(k.loc, "constant %q has a different value than in %s (line %d)",
+						name, first.loc.Filename, first.loc.Pos.Line)
+				}
+			}
+		}
+		clear(constants)
+	})
+}
+
+// constantValueString returns a canonical representation of a constant value
+// of type t, which is the same for values that are equal. The items of sets
+// and the entries of maps are sorted because their order isn't significant.
+func constantValueString(c *thriftcheck.C, t ast.Type, v ast.ConstantValue) string {
+	var elem, key ast.Type
+	var unordered bool
+	switch t := resolveType(c, t).(type) {
+	case ast.ListType:
+		elem = t.ValueType
+	case ast.SetType:
+		elem, unordered = t.ValueType, true
+	case ast.MapType:
+		key, elem = t.KeyType, t.ValueType
+	}
+
+	switch v := v.(type) {
+	case ast.ConstantBoolean:
+		return strconv.FormatBool(bool(v))
+	case ast.ConstantInteger:
+		return strconv.FormatInt(int64(v), 10)
+	case ast.ConstantDouble:
+		return strconv.FormatFloat(float64(v), 'g', -1, 64)
+	case ast.ConstantString:
+		return strconv.Quote(string(v))
+	case ast.ConstantReference:
+		return v.Name
+	case ast.ConstantList:
+		items := make([]string, len(v.Items))
+		for i, item := range v.Items {
+			items[i] = constantValueString(c, elem, item)
+		}
+		if unordered {
+			slices.Sort(items)
+		}
+		return "[" + strings.Join(items, ", ") + "]"
+	case ast.ConstantMap:
+		type entry struct{ key, value string }
+		entries := make([]entry, len(v.Items))
+		for i, item := range v.Items {
+			entries[i] = entry{constantValueString(c, key, item.Key), constantValueString(c, elem, item.Value)}
+		}
+		slices.SortFunc(entries, func(a, b entry) int { return cmp.Compare(a.key, b.key) })
+		items := make([]string, len(entries))
+		for i, e := range entries {
+			items[i] = e.key + ": " + e.value
+		}
+		return "{" + strings.Join(items, ", ") + "}"
+	default:
+		return fmt.Sprint(v)
+	}
+}
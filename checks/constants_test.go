@@ -15,6 +15,7 @@
 package checks_test
 
 import (
+	"regexp"
 	"testing"
 
 	"github.com/pinterest/thriftcheck/checks"
@@ -54,3 +55,201 @@ func TestCheckConstantRef(t *testing.T) {
 	check := checks.CheckConstantRef()
 	RunTests(t, &check, tests)
 }
+
+func TestCheckConstantRefFieldDefaults(t *testing.T) {
+	tests := []MultiFileTest{
+		{
+			files: map[string]string{
+				"a.thrift": "include \"b.thrift\"\nconst i32 LIMIT = 10\nstruct S {\n  1: optional i32 x = LIMIT\n  2: optional i32 y = b.OTHER\n  3: optional i32 z = 5\n}",
+				"b.thrift": "const i32 OTHER = 1",
+			},
+			want: []string{},
+		},
+		{
+			files: map[string]string{
+				"a.thrift": "struct S {\n  1: optional i32 x = UNDEFINED_CONST\n}",
+			},
+			want: []string{
+				`a.thrift:2:21: error: unable to find a constant or enum value named "UNDEFINED_CONST" (constant.ref)`,
+			},
+		},
+	}
+
+	check := checks.CheckConstantRef()
+	RunMultiFileTests(t, &check, tests)
+}
+
+func TestCheckNoStructConst(t *testing.T) {
+	prog := &ast.Program{Definitions: []ast.Definition{
+		&ast.Struct{Name: "Point", Type: ast.StructType},
+		&ast.Struct{Name: "Value", Type: ast.UnionType},
+		&ast.Typedef{Name: "Origin", Type: ast.TypeReference{Name: "Point"}},
+	}}
+
+	tests := []Test{
+		{
+			prog: prog,
+			node: &ast.Constant{Name: "ORIGIN", Type: ast.TypeReference{Name: "Point"}},
+			want: []string{
+				`t.thrift:0:1: warning: constant "ORIGIN" has struct type "Point" (const.struct.type)`,
+			},
+		},
+		{
+			prog: prog,
+			node: &ast.Constant{Name: "ORIGIN", Type: ast.TypeReference{Name: "Origin"}},
+			want: []string{
+				`t.thrift:0:1: warning: constant "ORIGIN" has struct type "Point" (const.struct.type)`,
+			},
+		},
+		{
+			prog: prog,
+			node: &ast.Constant{Name: "DEFAULT", Type: ast.TypeReference{Name: "Value"}},
+			want: []string{
+				`t.thrift:0:1: warning: constant "DEFAULT" has union type "Value" (const.struct.type)`,
+			},
+		},
+		{
+			prog: prog,
+			node: &ast.Constant{Name: "LIMIT", Type: ast.BaseType{ID: ast.I32TypeID}},
+			want: []string{},
+		},
+	}
+
+	check := checks.CheckNoStructConst()
+	RunTests(t, &check, tests)
+}
+
+func TestCheckQualifiedEnumConst(t *testing.T) {
+	prog := &ast.Program{Definitions: []ast.Definition{
+		&ast.Enum{Name: "Status", Items: []*ast.EnumItem{{Name: "ACTIVE"}}},
+		&ast.Constant{Name: "DEFAULT_STATUS", Type: ast.TypeReference{Name: "Status"}, Value: ast.ConstantReference{Name: "Status.ACTIVE"}},
+	}}
+
+	tests := []Test{
+		{
+			prog: prog,
+			node: &ast.Constant{Name: "S", Type: ast.TypeReference{Name: "Status"}, Value: ast.ConstantReference{Name: "Status.ACTIVE"}},
+			want: []string{},
+		},
+		{
+			prog: prog,
+			node: &ast.Constant{Name: "S", Type: ast.TypeReference{Name: "Status"}, Value: ast.ConstantReference{Name: "DEFAULT_STATUS"}},
+			want: []string{},
+		},
+		{
+			prog: prog,
+			node: &ast.Constant{Name: "S", Line: 3, Type: ast.TypeReference{Name: "Status"}, Value: ast.ConstantReference{Name: "ACTIVE"}},
+			want: []string{
+				`t.thrift:3:1: warning: constant "S" uses unqualified enum value "ACTIVE"; use Status.ACTIVE instead (const.enum.qualified)`,
+			},
+		},
+		{
+			prog: prog,
+			node: &ast.Constant{Name: "N", Type: ast.BaseType{ID: ast.I32TypeID}, Value: ast.ConstantReference{Name: "ACTIVE"}},
+			want: []string{},
+		},
+	}
+
+	check := checks.CheckQualifiedEnumConst()
+	RunTests(t, &check, tests)
+}
+
+func TestCheckConstDivergence(t *testing.T) {
+	tests := []MultiFileTest{
+		{
+			files: map[string]string{
+				"a.thrift": `const set<string> ALLOWED_HOSTS = ["a", "b"]`,
+				"b.thrift": `const set<string> ALLOWED_HOSTS = ["b", "a"]`,
+				"c.thrift": `const map<string, i32> ALLOWED_LIMITS = {"a": 1, "b": 2}`,
+				"d.thrift": `const map<string, i32> ALLOWED_LIMITS = {"b": 2, "a": 1}`,
+			},
+			want: []string{},
+		},
+		{
+			files: map[string]string{
+				"a.thrift": `const set<string> ALLOWED_HOSTS = ["a", "b"]`,
+				"b.thrift": "\nconst set<string> ALLOWED_HOSTS = [\"a\"]",
+				"c.thrift": `const set<string> ALLOWED_HOSTS = ["a", "b"]`,
+			},
+			want: []string{
+				`b.thrift:2:1: warning: constant "ALLOWED_HOSTS" has a different value than in a.thrift (line 1) (const.divergence)`,
+			},
+		},
+		{
+			files: map[string]string{
+				"a.thrift": `const list<string> ALLOWED_ORDER = ["a", "b"]`,
+				"b.thrift": `const list<string> ALLOWED_ORDER = ["b", "a"]`,
+			},
+			want: []string{
+				`b.thrift:1:1: warning: constant "ALLOWED_ORDER" has a different value than in a.thrift (line 1) (const.divergence)`,
+			},
+		},
+		{
+			files: map[string]string{
+				"a.thrift": `const i32 TIMEOUT = 1`,
+				"b.thrift": `const i32 TIMEOUT = 2`,
+			},
+			want: []string{},
+		},
+	}
+
+	check := checks.CheckConstDivergence(regexp.MustCompile(`^ALLOWED_`))
+	RunMultiFileTests(t, &check, tests)
+}
+
+func TestCheckConstUnitTypedef(t *testing.T) {
+	i64 := ast.BaseType{ID: ast.I64TypeID}
+	prog := &ast.Program{Definitions: []ast.Definition{
+		&ast.Typedef{Name: "Milliseconds", Type: i64},
+		&ast.Typedef{Name: "Duration", Type: i64},
+	}}
+
+	tests := []Test{
+		{
+			prog: prog,
+			node: &ast.Constant{Name: "TIMEOUT_MS", Type: ast.TypeReference{Name: "Milliseconds"}, Value: ast.ConstantInteger(3600000)},
+			want: []string{},
+		},
+		{
+			node: &ast.Constant{Name: "TIMEOUT_MS", Type: ast.TypeReference{Name: "units.Milliseconds"}, Value: ast.ConstantInteger(3600000)},
+			want: []string{},
+		},
+		{
+			node: &ast.Constant{Name: "TIMEOUT_MS", Type: i64, Value: ast.ConstantInteger(3600000)},
+			want: []string{
+				`t.thrift:0:1: warning: constant "TIMEOUT_MS" has type i64, which doesn't name its unit; use one of: units.Milliseconds, Bytes (const.unit.typedef)`,
+			},
+		},
+		{
+			prog: prog,
+			node: &ast.Constant{Name: "retryDelayMillis", Type: ast.TypeReference{Name: "Duration"}, Value: ast.ConstantInteger(100)},
+			want: []string{},
+		},
+		{
+			prog: prog,
+			node: &ast.Constant{Name: "RETRY_DELAY_MILLIS", Type: ast.TypeReference{Name: "Duration"}, Value: ast.ConstantInteger(100)},
+			want: []string{
+				`t.thrift:0:1: warning: constant "RETRY_DELAY_MILLIS" has type Duration, which doesn't name its unit; use one of: units.Milliseconds, Bytes (const.unit.typedef)`,
+			},
+		},
+		{
+			node: &ast.Constant{Name: "MAX_RETRIES", Type: i64, Value: ast.ConstantInteger(3)},
+			want: []string{},
+		},
+		{
+			node: &ast.Constant{Name: "DEFAULT_UNITS", Type: ast.BaseType{ID: ast.StringTypeID}, Value: ast.ConstantString("ms")},
+			want: []string{},
+		},
+	}
+
+	check := checks.CheckConstUnitTypedef(nil, []string{"units.Milliseconds", "Bytes"})
+	RunTests(t, &check, tests)
+
+	check = checks.CheckConstUnitTypedef(nil, nil)
+	RunTests(t, &check, []Test{
+		{
+			node: &ast.Constant{Name: "TIMEOUT_MS", Type: i64, Value: ast.ConstantInteger(3600000)},
+			want: []string{},
+		},
+	})
+}
@@ -15,6 +15,13 @@
 package checks
 
 import (
+	"cmp"
+	"maps"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/danwakefield/fnmatch"
 	"github.com/pinterest/thriftcheck"
 	"go.uber.org/thriftrw/ast"
 )
@@ -22,7 +29,7 @@ import (
 // CheckEnumSize returns a thriftcheck.Check that warns or errors if an
 // enumeration's element size grows beyond a limit.
 func CheckEnumSize(warningLimit, errorLimit int) thriftcheck.Check {
-	return thriftcheck.NewCheck("enum.size", func(c *thriftcheck.C, e *ast.Enum) {
+	return newCheck("enum.size", func(c *thriftcheck.C, e *ast.Enum) {
 		size := len(e.Items)
 		if errorLimit > 0 && size > errorLimit {
 			c.Errorf(e, "enumeration %q has more than %d items", e.Name, errorLimit)
@@ -31,3 +38,265 @@ func CheckEnumSize(warningLimit, errorLimit int) thriftcheck.Check {
 		}
 	})
 }
+
+// CheckMaxEnumMembers returns a thriftcheck.Check that reports an error if an
+// enumeration has more than max members. It's the same limit as
+// CheckEnumSize's error limit, but its message includes the actual count. A
+// max of 0 disables the check.
+func CheckMaxEnumMembers(max int) thriftcheck.Check {
+	return newCheck("enum.members.max", func(c *thriftcheck.C, e *ast.Enum) {
+		if max > 0 && len(e.Items) > max {
+			c.Errorf(e, "enumeration %q has %d members, more than the maximum of %d", e.Name, len(e.Items), max)
+		}
+	})
+}
+
+// CheckEnumAliasAnnotation returns a thriftcheck.Check that reports an error
+// if an enumeration item reuses another item's value without being annotated
+// as an intentional alias (using an `@alias` documentation tag).
+func CheckEnumAliasAnnotation() thriftcheck.Check {
+	return newCheck("enum.alias", func(c *thriftcheck.C, e *ast.Enum) {
+		seen := make(map[int]*ast.EnumItem, len(e.Items))
+		next := 0
+		for _, ei := range e.Items {
+			value := next
+			if ei.Value != nil {
+				value = *ei.Value
+			}
+			next = value + 1
+
+			if first, ok := seen[value]; ok {
+				if _, ok := annotation(ei, "alias"); !ok {
+					c.Errorf(ei, "enumeration item %q reuses the value of %q (%d) but isn't annotated as an alias", ei.Name, first.Name, value)
+				}
+				continue
+			}
+			seen[value] = ei
+		}
+	})
+}
+
+// CheckFlagEnumPowersOfTwo returns a thriftcheck.Check that reports an error
+// when a nonzero item of an enum annotated with `flags` has a value that
+// isn't a power of two, which would make it overlap with other flags when
+// they're combined.
+func CheckFlagEnumPowersOfTwo() thriftcheck.Check {
+	return newCheck("enum.flags.power.of.two", func(c *thriftcheck.C, e *ast.Enum) {
+		if _, ok := annotation(e, "flags"); !ok {
+			return
+		}
+		next := 0
+		for _, ei := range e.Items {
+			value := next
+			if ei.Value != nil {
+				value = *ei.Value
+			}
+			next = value + 1
+
+			if value != 0 && (value < 0 || value&(value-1) != 0) {
+				c.Errorf(ei, "flags enumeration item %q has value %d, which isn't a power of two", ei.Name, value)
+			}
+		}
+	})
+}
+
+// CheckSharedEnumLocation returns a multi-file thriftcheck.Check that warns
+// when an enum crosses a service boundary, being referenced by the signature
+// of a service method in a file other than the one that defines it, and the
+// defining file's path doesn't match the sharedPattern glob. Such enums
+// belong in shared types files that clients can include without pulling in
+// the service itself. Every method that uses one is reported. An empty
+// pattern disables the check.
+func CheckSharedEnumLocation(sharedPattern string) thriftcheck.Check {
+	type enum struct{ path, name string }
+	type use struct {
+		loc     thriftcheck.Location
+		path    string
+		method  string
+		name    string
+		defined string
+	}
+	paths := make(pathCache)
+	uses := make(map[enum][]use)
+	var enums []enum
+
+	return newMultiFileCheck("enum.location", func(c *thriftcheck.C, f *ast.Function) {
+		if sharedPattern == "" {
+			return
+		}
+
+		types := []ast.Type{f.ReturnType}
+		for _, p := range f.Parameters {
+			types = append(types, p.Type)
+		}
+
+		for len(types) > 0 {
+			t := types[0]
+			types = types[1:]
+
+			switch t := t.(type) {
+			case ast.ListType:
+				types = append(types, t.ValueType)
+			case ast.SetType:
+				types = append(types, t.ValueType)
+			case ast.MapType:
+				types = append(types, t.KeyType, t.ValueType)
+			case ast.TypeReference:
+				sym, ok := c.Symbols().Lookup(t.Name)
+				if !ok {
+					continue
+				}
+				e, ok := sym.Definition.(*ast.Enum)
+				filename := filepath.Clean(sym.Filename)
+				if !ok || fnmatch.Match(sharedPattern, filename, fnmatch.FNM_NOESCAPE) {
+					continue
+				}
+				key := enum{paths.canonical(sym.Filename), e.Name}
+				if _, ok := uses[key]; !ok {
+					enums = append(enums, key)
+				}
+				uses[key] = append(uses[key], use{c.Locate(f), paths.canonical(c.Filename), f.Name, t.Name, filename})
+			}
+		}
+	}, func(c *thriftcheck.C) {
+		defer clear(paths)
+		defer clear(uses)
+		defer func() { enums = nil }()
+
+		for _, e := range enums {
+			crosses := slices.ContainsFunc(uses[e], func(u use) bool { return u.path != e.path })
+			if !crosses {
+				continue
+			}
+			for _, u := range uses[e] {
+				c.WarningfAt(u.loc, "method %q uses enum %q defined in %s, which isn't a shared types file", u.method, u.name, u.defined)
+			}
+		}
+	})
+}
+
+// definingFile returns the (cleaned) path of the file that defines the named
+// type, based on its include qualifier.
+func definingFile(c *thriftcheck.C, name string) (string, bool) {
+	i := strings.Index(name, ".")
+	if i < 0 {
+		return filepath.Clean(c.Filename), true
+	}
+
+	prefix := name[:i]
+	for _, header := range c.Program.Headers {
+		if include, ok := header.(*ast.Include); ok && includePrefix(include) == prefix {
+			if path, ok := thriftcheck.FindFile(include.Path, c.Dirs); ok {
+				return filepath.Clean(path), true
+			}
+		}
+	}
+	return "", false
+}
+
+// CheckEnumStability returns a thriftcheck.Check that reports an error if an
+// enumeration that exists in the baseline version of its file no longer has
+// one of the baseline's values, or gives a value a different name. New items
+// are allowed. Baseline files are found by joining baselineDir with the
+// linted file's path. An empty baselineDir disables the check.
+func CheckEnumStability(baselineDir string) thriftcheck.Check {
+	return newCheck("enum.stability", func(c *thriftcheck.C, e *ast.Enum) {
+		if baselineDir == "" {
+			return
+		}
+		program, _ := c.Included(filepath.Join(baselineDir, c.Filename))
+		if program == nil {
+			return
+		}
+
+		var baseline *ast.Enum
+		for _, def := range program.Definitions {
+			if b, ok := def.(*ast.Enum); ok && b.Name == e.Name {
+				baseline = b
+				break
+			}
+		}
+		if baseline == nil {
+			return
+		}
+
+		items := enumValues(e)
+		for _, b := range enumItems(baseline) {
+			current, ok := items[b.value]
+			if !ok {
+				c.Errorf(e, "enumeration %q no longer has value %d (%q)", e.Name, b.value, b.item.Name)
+			} else if !slices.ContainsFunc(current, func(ei *ast.EnumItem) bool { return ei.Name == b.item.Name }) {
+				c.Errorf(current[0], "enumeration item %q has value %d, which was named %q", current[0].Name, b.value, b.item.Name)
+			}
+		}
+	})
+}
+
+// CheckUnusedEnumMember returns a multi-file thriftcheck.Check that warns
+// about enumeration items that aren't referenced by any constant or default
+// value in the linted files. It can't see how generated code uses an item at
+// runtime, so it only points out candidates for cleanup.
+func CheckUnusedEnumMember() thriftcheck.Check {
+	type member struct{ path, name string }
+	defined := make(map[member]thriftcheck.Location)
+	referenced := make(map[member]bool)
+
+	return newMultiFileCheck("enum.member.unused", func(c *thriftcheck.C, n ast.Node) {
+		switch n := n.(type) {
+		case *ast.Enum:
+			path := canonicalPath(c.Filename)
+			for _, ei := range n.Items {
+				defined[member{path, n.Name + "." + ei.Name}] = c.Locate(ei)
+			}
+		case ast.ConstantReference:
+			if _, ok := c.ResolveConstant(n).(*ast.EnumItem); !ok {
+				return
+			}
+			i := strings.LastIndex(n.Name, ".")
+			if filename, ok := definingFile(c, n.Name[:i]); ok {
+				referenced[member{canonicalPath(filename), typeBaseName(n.Name[:i]) + n.Name[i:]}] = true
+			}
+		}
+	}, func(c *thriftcheck.C) {
+		members := slices.SortedFunc(maps.Keys(defined), func(a, b member) int {
+			return cmp.Or(cmp.Compare(a.path, b.path), cmp.Compare(a.name, b.name))
+		})
+		for _, m := range members {
+			if !referenced[m] {
+				c.WarningfAt(defined[m], "enumeration item %q isn't referenced by any constant or default value", m.name)
+			}
+		}
+		clear(defined)
+		clear(referenced)
+	})
+}
+
+type enumItem struct {
+	item  *ast.EnumItem
+	value int
+}
+
+// enumItems returns an enumeration's items along with their values, which
+// are implicitly one more than the previous item's value if they're omitted.
+func enumItems(e *ast.Enum) []enumItem {
+	items := make([]enumItem, len(e.Items))
+	next := 0
+	for i, ei := range e.Items {
+		value := next
+		if ei.Value != nil {
+			value = *ei.Value
+		}
+		next = value + 1
+		items[i] = enumItem{item: ei, value: value}
+	}
+	return items
+}
+
+// enumValues maps an enumeration's values to the items that use them.
+func enumValues(e *ast.Enum) map[int][]*ast.EnumItem {
+	values := make(map[int][]*ast.EnumItem, len(e.Items))
+	for _, ei := range enumItems(e) {
+		values[ei.value] = append(values[ei.value], ei.item)
+	}
+	return values
+}
@@ -15,6 +15,8 @@
 package checks_test
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/pinterest/thriftcheck/checks"
@@ -43,4 +45,304 @@ func TestCheckEnumSize(t *testing.T) {
 
 	check := checks.CheckEnumSize(1, 2)
 	RunTests(t, &check, tests)
+
+	// Test with only an error limit (a hard maximum).
+	items := func(n int) []*ast.EnumItem {
+		items := make([]*ast.EnumItem, n)
+		for i := range items {
+			items[i] = &ast.EnumItem{}
+		}
+		return items
+	}
+	tests = []Test{
+		{
+			node: &ast.Enum{Name: "enum", Items: items(3)},
+			want: []string{},
+		},
+		{
+			node: &ast.Enum{Name: "enum", Items: items(4)},
+			want: []string{
+				`t.thrift:0:1: error: enumeration "enum" has more than 3 items (enum.size)`,
+			},
+		},
+	}
+
+	check = checks.CheckEnumSize(0, 3)
+	RunTests(t, &check, tests)
+}
+
+func TestCheckFlagEnumPowersOfTwo(t *testing.T) {
+	zero, one, two, three, four := 0, 1, 2, 3, 4
+	flags := []*ast.Annotation{{Name: "flags"}}
+
+	tests := []Test{
+		{
+			node: &ast.Enum{Name: "Flags", Annotations: flags, Items: []*ast.EnumItem{
+				{Name: "NONE", Value: &zero},
+				{Name: "A", Value: &one},
+				{Name: "B", Value: &two},
+				{Name: "C", Value: &four},
+			}},
+			want: []string{},
+		},
+		{
+			node: &ast.Enum{Name: "Enum", Items: []*ast.EnumItem{
+				{Name: "A", Value: &one},
+				{Name: "B", Value: &three},
+			}},
+			want: []string{},
+		},
+		{
+			node: &ast.Enum{Name: "Flags", Annotations: flags, Items: []*ast.EnumItem{
+				{Name: "A", Value: &one},
+				{Name: "B", Value: &two},
+				{Name: "C", Value: &three},
+			}},
+			want: []string{
+				`t.thrift:0:1: error: flags enumeration item "C" has value 3, which isn't a power of two (enum.flags.power.of.two)`,
+			},
+		},
+		{
+			node: &ast.Enum{Name: "Flags", Doc: "@flags", Items: []*ast.EnumItem{
+				{Name: "A", Value: &two},
+				{Name: "B"},
+			}},
+			want: []string{
+				`t.thrift:0:1: error: flags enumeration item "B" has value 3, which isn't a power of two (enum.flags.power.of.two)`,
+			},
+		},
+	}
+
+	check := checks.CheckFlagEnumPowersOfTwo()
+	RunTests(t, &check, tests)
+}
+
+func TestCheckMaxEnumMembers(t *testing.T) {
+	items := func(n int) []*ast.EnumItem {
+		items := make([]*ast.EnumItem, n)
+		for i := range items {
+			items[i] = &ast.EnumItem{}
+		}
+		return items
+	}
+
+	tests := []Test{
+		{
+			node: &ast.Enum{Name: "enum"},
+			want: []string{},
+		},
+		{
+			node: &ast.Enum{Name: "enum", Items: items(3)},
+			want: []string{},
+		},
+		{
+			node: &ast.Enum{Name: "enum", Items: items(4)},
+			want: []string{
+				`t.thrift:0:1: error: enumeration "enum" has 4 members, more than the maximum of 3 (enum.members.max)`,
+			},
+		},
+	}
+
+	check := checks.CheckMaxEnumMembers(3)
+	RunTests(t, &check, tests)
+
+	// Test with a different maximum.
+	tests = []Test{
+		{
+			node: &ast.Enum{Name: "enum", Items: items(10)},
+			want: []string{},
+		},
+		{
+			node: &ast.Enum{Name: "enum", Items: items(11)},
+			want: []string{
+				`t.thrift:0:1: error: enumeration "enum" has 11 members, more than the maximum of 10 (enum.members.max)`,
+			},
+		},
+	}
+
+	check = checks.CheckMaxEnumMembers(10)
+	RunTests(t, &check, tests)
+
+	disabled := checks.CheckMaxEnumMembers(0)
+	RunTests(t, &disabled, []Test{
+		{node: &ast.Enum{Name: "enum", Items: items(1000)}, want: []string{}},
+	})
+}
+
+func TestCheckEnumAliasAnnotation(t *testing.T) {
+	one, two := 1, 2
+
+	tests := []Test{
+		{
+			node: &ast.Enum{Name: "Enum", Items: []*ast.EnumItem{
+				{Name: "A", Value: &one},
+				{Name: "B", Value: &two},
+			}},
+			want: []string{},
+		},
+		{
+			node: &ast.Enum{Name: "Enum", Items: []*ast.EnumItem{
+				{Name: "A", Value: &one},
+				{Name: "B", Value: &one, Annotations: []*ast.Annotation{{Name: "alias"}}},
+			}},
+			want: []string{},
+		},
+		{
+			node: &ast.Enum{Name: "Enum", Items: []*ast.EnumItem{
+				{Name: "A", Value: &one},
+				{Name: "B", Value: &one},
+			}},
+			want: []string{
+				`t.thrift:0:1: error: enumeration item "B" reuses the value of "A" (1) but isn't annotated as an alias (enum.alias)`,
+			},
+		},
+		{
+			node: &ast.Enum{Name: "Enum", Items: []*ast.EnumItem{
+				{Name: "A"},
+				{Name: "B", Value: &two},
+				{Name: "C", Value: &one},
+				{Name: "D"},
+			}},
+			want: []string{
+				`t.thrift:0:1: error: enumeration item "D" reuses the value of "B" (2) but isn't annotated as an alias (enum.alias)`,
+			},
+		},
+	}
+
+	check := checks.CheckEnumAliasAnnotation()
+	RunTests(t, &check, tests)
+}
+
+func TestCheckSharedEnumLocation(t *testing.T) {
+	tests := []MultiFileTest{
+		{
+			files: map[string]string{
+				"shared/types.thrift": "enum Status { ACTIVE, INACTIVE }",
+				"users.thrift":        "include \"shared/types.thrift\"\nservice Users {\n  types.Status getStatus(1: i64 id)\n}",
+			},
+			want: []string{},
+		},
+		{
+			files: map[string]string{
+				"users.thrift":  "enum Status { ACTIVE, INACTIVE }\nservice Users {\n  Status getStatus(1: i64 id)\n}",
+				"admin.thrift":  "include \"users.thrift\"\nservice Admin {\n  void setStatuses(1: map<i64, users.Status> statuses)\n}",
+				"unused.thrift": "enum Unused { A }\nstruct S {\n  1: optional Unused u\n}",
+				"local.thrift":  "enum Mode { FAST, SLOW }\nservice Local {\n  void setMode(1: Mode mode)\n}",
+			},
+			want: []string{
+				`admin.thrift:3:3: warning: method "setStatuses" uses enum "users.Status" defined in users.thrift, which isn't a shared types file (enum.location)`,
+				`users.thrift:3:3: warning: method "getStatus" uses enum "Status" defined in users.thrift, which isn't a shared types file (enum.location)`,
+			},
+		},
+		{
+			// The defining file isn't linted, but its enum is still used
+			// across the boundary.
+			files: map[string]string{
+				"admin.thrift": "include \"users.thrift\"\nservice Admin {\n  list<users.Status> statuses()\n}",
+			},
+			unlinted: map[string]string{
+				"users.thrift": "enum Status { ACTIVE, INACTIVE }",
+			},
+			want: []string{
+				`admin.thrift:3:3: warning: method "statuses" uses enum "users.Status" defined in users.thrift, which isn't a shared types file (enum.location)`,
+			},
+		},
+	}
+
+	check := checks.CheckSharedEnumLocation("*/shared/*")
+	RunMultiFileTests(t, &check, tests)
+}
+
+func TestCheckEnumStability(t *testing.T) {
+	dir := t.TempDir()
+	baseline := "enum Color {\n  RED = 1\n  GREEN\n  BLUE = 5\n}"
+	if err := os.WriteFile(filepath.Join(dir, "t.thrift"), []byte(baseline), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	value := func(v int) *int { return &v }
+	tests := []Test{
+		{
+			node: &ast.Enum{Name: "Color", Items: []*ast.EnumItem{
+				{Name: "RED", Value: value(1)},
+				{Name: "GREEN"},
+				{Name: "BLUE", Value: value(5)},
+				{Name: "PURPLE"},
+			}},
+			want: []string{},
+		},
+		{
+			node: &ast.Enum{Name: "Color", Items: []*ast.EnumItem{
+				{Name: "RED", Value: value(1)},
+				{Name: "BLUE", Value: value(5)},
+			}},
+			want: []string{
+				`t.thrift:0:1: error: enumeration "Color" no longer has value 2 ("GREEN") (enum.stability)`,
+			},
+		},
+		{
+			node: &ast.Enum{Name: "Color", Items: []*ast.EnumItem{
+				{Name: "RED", Value: value(1)},
+				{Name: "LIME"},
+				{Name: "BLUE", Value: value(5)},
+			}},
+			want: []string{
+				`t.thrift:0:1: error: enumeration item "LIME" has value 2, which was named "GREEN" (enum.stability)`,
+			},
+		},
+		{
+			node: &ast.Enum{Name: "Other", Items: []*ast.EnumItem{
+				{Name: "A"},
+			}},
+			want: []string{},
+		},
+		{
+			name: "missing.thrift",
+			node: &ast.Enum{Name: "Color"},
+			want: []string{},
+		},
+	}
+
+	check := checks.CheckEnumStability(dir)
+	RunTests(t, &check, tests)
+}
+
+func TestCheckUnusedEnumMember(t *testing.T) {
+	tests := []MultiFileTest{
+		{
+			files: map[string]string{
+				"a.thrift": "enum Status {\n  ACTIVE\n}\nconst Status DEFAULT_STATUS = Status.ACTIVE",
+			},
+			want: []string{},
+		},
+		{
+			files: map[string]string{
+				"a.thrift": "enum Status {\n  ACTIVE\n  DELETED\n}\nconst Status DEFAULT_STATUS = Status.ACTIVE",
+			},
+			want: []string{
+				`a.thrift:3:3: warning: enumeration item "Status.DELETED" isn't referenced by any constant or default value (enum.member.unused)`,
+			},
+		},
+		{
+			files: map[string]string{
+				"a.thrift": "include \"b.thrift\"\nstruct User {\n  1: optional b.Status status = b.Status.DELETED\n}",
+				"b.thrift": "enum Status {\n  ACTIVE\n  DELETED\n}",
+			},
+			want: []string{
+				`b.thrift:2:3: warning: enumeration item "Status.ACTIVE" isn't referenced by any constant or default value (enum.member.unused)`,
+			},
+		},
+		{
+			files: map[string]string{
+				"a.thrift": "include t \"b.thrift\"\nstruct User {\n  1: optional t.Status status = t.Status.DELETED\n}",
+				"b.thrift": "enum Status {\n  ACTIVE\n  DELETED\n}",
+			},
+			want: []string{
+				`b.thrift:2:3: warning: enumeration item "Status.ACTIVE" isn't referenced by any constant or default value (enum.member.unused)`,
+			},
+		},
+	}
+
+	check := checks.CheckUnusedEnumMember()
+	RunMultiFileTests(t, &check, tests)
 }
@@ -15,22 +15,230 @@
 package checks
 
 import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
 	"github.com/pinterest/thriftcheck"
 	"go.uber.org/thriftrw/ast"
 )
 
-// CheckFieldIDMissing reports an error if a field's ID is missing.
+// CheckFieldIDMissing reports an error if a field's ID is missing. It
+// suggests numbering the fields without IDs after the highest explicit ID.
 func CheckFieldIDMissing() thriftcheck.Check {
-	return thriftcheck.NewCheck("field.id.missing", func(c *thriftcheck.C, f *ast.Field) {
-		if f.IDUnset {
-			c.Errorf(f, "field ID for %q is missing", f.Name)
+	return newCheck("field.id.missing", func(c *thriftcheck.C, f *ast.Field) {
+		if !f.IDUnset {
+			return
+		}
+		c.Errorf(f, "field ID for %q is missing", f.Name)
+		if id, ok := nextFieldID(c.Program, f); ok {
+			if offset, ok := c.Offset(f); ok {
+				c.SuggestFix(thriftcheck.Edit{Start: offset, End: offset, Text: fmt.Sprintf("%d: ", id)})
+			}
+		}
+	})
+}
+
+// nextFieldID returns the ID for a field without one, numbering the fields
+// without IDs in its struct (or function parameter or exception list) in
+// order after the list's highest explicit ID.
+func nextFieldID(p *ast.Program, f *ast.Field) (int, bool) {
+	if p == nil {
+		return 0, false
+	}
+	var lists [][]*ast.Field
+	for _, d := range p.Definitions {
+		switch d := d.(type) {
+		case *ast.Struct:
+			lists = append(lists, d.Fields)
+		case *ast.Service:
+			for _, fn := range d.Functions {
+				lists = append(lists, fn.Parameters, fn.Exceptions)
+			}
+		}
+	}
+	for _, fields := range lists {
+		if !slices.Contains(fields, f) {
+			continue
+		}
+		highest, unset := 0, 0
+		for _, field := range fields {
+			if !field.IDUnset {
+				highest = max(highest, field.ID)
+			}
+		}
+		for _, field := range fields {
+			if field == f {
+				return highest + unset + 1, true
+			}
+			if field.IDUnset {
+				unset++
+			}
+		}
+	}
+	return 0, false
+}
+
+// CheckFieldIDDuplicate reports an error if two of a struct's fields use the
+// same explicit field ID.
+func CheckFieldIDDuplicate() thriftcheck.Check {
+	return newCheck("field.id.duplicate", func(c *thriftcheck.C, s *ast.Struct) {
+		seen := make(map[int]*ast.Field, len(s.Fields))
+		for _, f := range s.Fields {
+			if f.IDUnset {
+				continue
+			}
+			if first, ok := seen[f.ID]; ok {
+				c.Errorf(f, "field %q reuses field ID %d of %q", f.Name, f.ID, first.Name)
+				continue
+			}
+			seen[f.ID] = f
+		}
+	})
+}
+
+// CheckFirstFieldIDIsOne warns if a struct's lowest explicit field ID isn't 1.
+// If contiguous is true, it also warns about gaps between consecutive field IDs.
+func CheckFirstFieldIDIsOne(contiguous bool) thriftcheck.Check {
+	return newCheck("field.id.first", func(c *thriftcheck.C, s *ast.Struct) {
+		ids := make([]int, 0, len(s.Fields))
+		for _, f := range s.Fields {
+			if !f.IDUnset {
+				ids = append(ids, f.ID)
+			}
+		}
+		if len(ids) == 0 {
+			return
+		}
+		slices.Sort(ids)
+
+		if ids[0] != 1 {
+			c.Warningf(s, "%q should start with field ID 1 (lowest is %d)", s.Name, ids[0])
+		}
+		if contiguous {
+			for i := 1; i < len(ids); i++ {
+				if ids[i] > ids[i-1]+1 {
+					c.Warningf(s, "%q has a gap in field IDs between %d and %d", s.Name, ids[i-1], ids[i])
+				}
+			}
+		}
+	})
+}
+
+// CheckFieldIDGap warns if the difference between a struct's consecutive
+// explicit field IDs (in ID order) is less than step, which leaves no room to
+// insert new fields between them. A step of 0 disables the check.
+func CheckFieldIDGap(step int) thriftcheck.Check {
+	return newCheck("field.id.gap", func(c *thriftcheck.C, s *ast.Struct) {
+		if step <= 0 {
+			return
+		}
+		fields := slices.DeleteFunc(slices.Clone(s.Fields), func(f *ast.Field) bool { return f.IDUnset })
+		slices.SortStableFunc(fields, func(a, b *ast.Field) int { return a.ID - b.ID })
+		for i := 1; i < len(fields); i++ {
+			prev, f := fields[i-1], fields[i]
+			if f.ID != prev.ID && f.ID-prev.ID < step {
+				c.Warningf(f, "field %q (%d) should be at least %d IDs after %q (%d)", f.Name, f.ID, step, prev.Name, prev.ID)
+			}
+		}
+	})
+}
+
+// CheckCaseInsensitiveFieldCollision reports an error if two of a struct's
+// fields have names that differ only in case.
+func CheckCaseInsensitiveFieldCollision() thriftcheck.Check {
+	return newCheck("field.name.case.collision", func(c *thriftcheck.C, s *ast.Struct) {
+		seen := make(map[string]*ast.Field, len(s.Fields))
+		for _, f := range s.Fields {
+			key := strings.ToLower(f.Name)
+			if prev, ok := seen[key]; ok {
+				c.Errorf(f, "field %q differs only in case from %q (line %d)", f.Name, prev.Name, prev.Line)
+				continue
+			}
+			seen[key] = f
+		}
+	})
+}
+
+var defaultPIIRegexp = regexp.MustCompile(`(?i)(email|ssn|phone|dob|address)`)
+
+// CheckPIIAnnotation returns a thriftcheck.Check that reports an error if a
+// field whose name matches nameRegexp isn't annotated with `pii`. If
+// nameRegexp is nil, a default pattern matching common PII field names is
+// used.
+func CheckPIIAnnotation(nameRegexp *regexp.Regexp) thriftcheck.Check {
+	if nameRegexp == nil {
+		nameRegexp = defaultPIIRegexp
+	}
+
+	return newCheck("field.pii.annotation", func(c *thriftcheck.C, f *ast.Field) {
+		if _, ok := annotation(f, "pii"); !ok && nameRegexp.MatchString(f.Name) {
+			c.Errorf(f, "field %q appears to contain PII but isn't annotated with @pii", f.Name)
+		}
+	})
+}
+
+var defaultIDNameRegexp = regexp.MustCompile(`(^|_)(?i:id)$|[a-z]I[Dd]$`)
+
+// CheckConsistentIDWidth returns a thriftcheck.Check that warns when the
+// integer-typed fields of a struct whose names match idRegexp don't all use
+// the same integer width. If idRegexp is nil, a default pattern matching
+// names that end in "id" (e.g. "parent_id" or "childId") is used.
+func CheckConsistentIDWidth(idRegexp *regexp.Regexp) thriftcheck.Check {
+	if idRegexp == nil {
+		idRegexp = defaultIDNameRegexp
+	}
+
+	return newCheck("field.id.width.consistent", func(c *thriftcheck.C, s *ast.Struct) {
+		var first *ast.Field
+		var width ast.BaseTypeID
+		for _, f := range s.Fields {
+			if !idRegexp.MatchString(f.Name) {
+				continue
+			}
+			t, ok := resolveType(c, f.Type).(ast.BaseType)
+			if !ok || (t.ID != ast.I8TypeID && t.ID != ast.I16TypeID && t.ID != ast.I32TypeID && t.ID != ast.I64TypeID) {
+				continue
+			}
+			if first == nil {
+				first, width = f, t.ID
+			} else if t.ID != width {
+				c.Warningf(f, "field %q is %s but %q is %s", f.Name, ast.BaseType{ID: t.ID}, first.Name, ast.BaseType{ID: width})
+			}
+		}
+	})
+}
+
+// CheckIDFieldLengthBound warns if a string or binary field (including
+// typedefs of them) whose name matches idRegexp doesn't have a maxKey
+// annotation bounding its length. If idRegexp is nil, the default ID name
+// pattern is used, and maxKey defaults to `maxlen`.
+func CheckIDFieldLengthBound(idRegexp *regexp.Regexp, maxKey string) thriftcheck.Check {
+	if idRegexp == nil {
+		idRegexp = defaultIDNameRegexp
+	}
+	if maxKey == "" {
+		maxKey = "maxlen"
+	}
+
+	return newCheck("field.id.length.bound", func(c *thriftcheck.C, f *ast.Field) {
+		if !idRegexp.MatchString(f.Name) {
+			return
+		}
+		if t, ok := resolveType(c, f.Type).(ast.BaseType); !ok || (t.ID != ast.StringTypeID && t.ID != ast.BinaryTypeID) {
+			return
+		}
+		if _, ok := annotation(f, maxKey); !ok {
+			c.Warningf(f, "ID field %q (%d) should bound its length with a %s annotation", f.Name, f.ID, maxKey)
 		}
 	})
 }
 
 // CheckFieldIDNegative reports an error if a field's ID is explicitly negative.
 func CheckFieldIDNegative() thriftcheck.Check {
-	return thriftcheck.NewCheck("field.id.negative", func(c *thriftcheck.C, f *ast.Field) {
+	return newCheck("field.id.negative", func(c *thriftcheck.C, f *ast.Field) {
 		if !f.IDUnset && f.ID < 0 {
 			c.Errorf(f, "field ID for %q (%d) is negative", f.Name, f.ID)
 		}
@@ -39,7 +247,7 @@ func CheckFieldIDNegative() thriftcheck.Check {
 
 // CheckFieldIDZero reports an error if a field's ID is explicitly zero.
 func CheckFieldIDZero() thriftcheck.Check {
-	return thriftcheck.NewCheck("field.id.zero", func(c *thriftcheck.C, f *ast.Field) {
+	return newCheck("field.id.zero", func(c *thriftcheck.C, f *ast.Field) {
 		if !f.IDUnset && f.ID == 0 {
 			c.Errorf(f, "field ID for %q is zero", f.Name)
 		}
@@ -48,27 +256,319 @@ func CheckFieldIDZero() thriftcheck.Check {
 
 // CheckFieldOptional warns if a field isn't declared as "optional".
 func CheckFieldOptional() thriftcheck.Check {
-	return thriftcheck.NewCheck("field.optional", func(c *thriftcheck.C, f *ast.Field) {
+	return newCheck("field.optional", func(c *thriftcheck.C, f *ast.Field) {
 		if f.Requiredness != ast.Optional {
 			c.Warningf(f, `field %q (%d) should be "optional"`, f.Name, f.ID)
 		}
 	})
 }
 
+var defaultBoolPrefixes = []string{"is_", "has_", "can_", "should_"}
+
+// CheckBoolFieldNaming warns if a bool field (including typedefs of bool)
+// isn't named as a predicate, using one of the given name prefixes.
+func CheckBoolFieldNaming(prefixes []string) thriftcheck.Check {
+	if len(prefixes) == 0 {
+		prefixes = defaultBoolPrefixes
+	}
+
+	return newCheck("field.bool.naming", func(c *thriftcheck.C, f *ast.Field) {
+		if t, ok := resolveType(c, f.Type).(ast.BaseType); !ok || t.ID != ast.BoolTypeID {
+			return
+		}
+		for _, prefix := range prefixes {
+			if strings.HasPrefix(f.Name, prefix) {
+				return
+			}
+		}
+		c.Warningf(f, "bool field %q (%d) should start with one of: %s", f.Name, f.ID, strings.Join(prefixes, ", "))
+	})
+}
+
+var defaultReservedFieldPrefixes = []string{"thrift_", "__"}
+
+// CheckNoSmallInts returns a thriftcheck.Check that warns if a field's type is
+// i16 or byte (including typedefs of them), unless its struct carries the
+// given annotation (`low-level` by default).
+func CheckNoSmallInts(allowAnnotation string) thriftcheck.Check {
+	if allowAnnotation == "" {
+		allowAnnotation = "low-level"
+	}
+
+	return newCheck("field.small.int", func(c *thriftcheck.C, s *ast.Struct) {
+		if _, ok := annotation(s, allowAnnotation); ok {
+			return
+		}
+		for _, f := range s.Fields {
+			if b, ok := resolveType(c, f.Type).(ast.BaseType); ok && (b.ID == ast.I16TypeID || b.ID == ast.I8TypeID) {
+				c.Warningf(f, "field %q (%d) is %s; prefer i32 outside of %s structs", f.Name, f.ID, ast.BaseType{ID: b.ID}, allowAnnotation)
+			}
+		}
+	})
+}
+
+// typeWords are the words that name each base type in field names, like the
+// "int" in "count_int".
+var typeWords = map[ast.BaseTypeID][]string{
+	ast.BoolTypeID:   {"bool", "boolean"},
+	ast.I8TypeID:     {"byte", "i8", "int8"},
+	ast.I16TypeID:    {"i16", "int16", "short"},
+	ast.I32TypeID:    {"i32", "int", "int32", "integer"},
+	ast.I64TypeID:    {"i64", "int64", "long"},
+	ast.DoubleTypeID: {"double", "float"},
+	ast.StringTypeID: {"str", "string"},
+	ast.BinaryTypeID: {"binary", "bytes"},
+}
+
+var nameWordRegexp = regexp.MustCompile(`[A-Z]*[a-z0-9]+|[A-Z]+`)
+
+// CheckNoTypeInFieldName warns if a field's name starts or ends with a word
+// that names the kind of its (resolved) type, like "count_int" for an i32 or
+// "user_struct" for a struct. A name that is just the type's own name, like
+// "user" for a User, is fine.
+func CheckNoTypeInFieldName() thriftcheck.Check {
+	return newCheck("field.name.type.redundant", func(c *thriftcheck.C, f *ast.Field) {
+		var words []string
+		switch t := resolveType(c, f.Type).(type) {
+		case ast.BaseType:
+			words = typeWords[t.ID]
+		case *ast.Struct, *ast.Enum:
+			words = []string{namingCategory(t)}
+		}
+
+		parts := nameWordRegexp.FindAllString(f.Name, -1)
+		if len(parts) < 2 {
+			return
+		}
+		for _, part := range []string{parts[0], parts[len(parts)-1]} {
+			if slices.Contains(words, strings.ToLower(part)) {
+				c.Warningf(f, "field %q (%d) of type %s restates its type with %q", f.Name, f.ID, f.Type, part)
+				return
+			}
+		}
+	})
+}
+
+// CheckReservedFieldPrefix reports an error if a field's name starts with one
+// of the given prefixes, which are reserved for generated code.
+func CheckReservedFieldPrefix(prefixes []string) thriftcheck.Check {
+	if len(prefixes) == 0 {
+		prefixes = defaultReservedFieldPrefixes
+	}
+
+	return newCheck("field.reserved.prefix", func(c *thriftcheck.C, f *ast.Field) {
+		for _, prefix := range prefixes {
+			if strings.HasPrefix(f.Name, prefix) {
+				c.Errorf(f, "field %q (%d) uses reserved prefix %q", f.Name, f.ID, prefix)
+				return
+			}
+		}
+	})
+}
+
+// CheckContainerFieldOptional warns if a list, set, or map field (including
+// typedefs of them) is declared as "required".
+func CheckContainerFieldOptional() thriftcheck.Check {
+	return newCheck("field.container.optional", func(c *thriftcheck.C, f *ast.Field) {
+		if f.Requiredness != ast.Required {
+			return
+		}
+		switch resolveType(c, f.Type).(type) {
+		case ast.ListType, ast.SetType, ast.MapType:
+			c.Warningf(f, `container field %q (%d) should be "optional" rather than "required"`, f.Name, f.ID)
+		}
+	})
+}
+
+// CheckContainerDefaultEmpty warns if a list, set, or map field (including
+// typedefs of them) has a default value that isn't empty. Defaults that refer
+// to constants are resolved.
+func CheckContainerDefaultEmpty() thriftcheck.Check {
+	return newCheck("field.container.default", func(c *thriftcheck.C, f *ast.Field) {
+		if f.Default == nil {
+			return
+		}
+		switch resolveType(c, f.Type).(type) {
+		case ast.ListType, ast.SetType, ast.MapType:
+		default:
+			return
+		}
+
+		value := f.Default
+		if ref, ok := value.(ast.ConstantReference); ok {
+			if constant, ok := c.ResolveConstant(ref).(*ast.Constant); ok {
+				value = constant.Value
+			}
+		}
+		switch value := value.(type) {
+		case ast.ConstantList:
+			if len(value.Items) > 0 {
+				c.Warningf(f, "container field %q (%d) has a non-empty default value", f.Name, f.ID)
+			}
+		case ast.ConstantMap:
+			if len(value.Items) > 0 {
+				c.Warningf(f, "container field %q (%d) has a non-empty default value", f.Name, f.ID)
+			}
+		}
+	})
+}
+
+// CheckDefaultConstDefined returns a multi-file thriftcheck.Check that reports
+// an error if a field's default value refers to a constant (or an enum item)
+// that isn't defined in its file or in the files that it includes. References
+// are resolved through the symbol tables shared by the multi-file checks.
+func CheckDefaultConstDefined() thriftcheck.Check {
+	type undefined struct {
+		loc         thriftcheck.Location
+		field, name string
+	}
+	var found []undefined
+
+	defined := func(symbols *thriftcheck.Symbols, name string) bool {
+		if sym, ok := symbols.Lookup(name); ok {
+			_, ok := sym.Definition.(*ast.Constant)
+			return ok
+		}
+		if i := strings.LastIndex(name, "."); i > 0 {
+			if sym, ok := symbols.Lookup(name[:i]); ok {
+				if e, ok := sym.Definition.(*ast.Enum); ok {
+					return slices.ContainsFunc(e.Items, func(item *ast.EnumItem) bool { return item.Name == name[i+1:] })
+				}
+			}
+		}
+		return false
+	}
+
+	return newMultiFileCheck("field.default.const.undefined", func(c *thriftcheck.C, f *ast.Field) {
+		if ref, ok := f.Default.(ast.ConstantReference); ok && !defined(c.Symbols(), ref.Name) {
+			found = append(found, undefined{c.Locate(ref), f.Name, ref.Name})
+		}
+	}, func(c *thriftcheck.C) {
+		defer func() { found = nil }()
+		for _, u := range found {
+			c.ErrorfAt(u.loc, "default value of field %q refers to undefined constant %q", u.field, u.name)
+		}
+	})
+}
+
+var optionalDocRegexp = regexp.MustCompile(`(?i)\b(optional|absent|unset|null)\b`)
+
+// CheckOptionalDoc warns if an optional field's documentation doesn't
+// describe what it means for the field to be absent, which is assumed when
+// it mentions "optional", "absent", "unset", or "null".
+func CheckOptionalDoc() thriftcheck.Check {
+	return newCheck("field.optional.doc", func(c *thriftcheck.C, f *ast.Field) {
+		if f.Requiredness == ast.Optional && !optionalDocRegexp.MatchString(f.Doc) {
+			c.Warningf(f, "optional field %q (%d) should document what it means when it's absent", f.Name, f.ID)
+		}
+	})
+}
+
 // CheckFieldRequiredness warns if a field isn't explicitly declared as "required" or "optional".
 func CheckFieldRequiredness() thriftcheck.Check {
-	return thriftcheck.NewCheck("field.requiredness", func(c *thriftcheck.C, f *ast.Field) {
+	return newCheck("field.requiredness", func(c *thriftcheck.C, f *ast.Field) {
 		if f.Requiredness == ast.Unspecified {
 			c.Warningf(f, `field %q (%d) should be explicitly "required" or "optional"`, f.Name, f.ID)
 		}
 	})
 }
 
+// CheckNoNewRequiredField returns a thriftcheck.Check that reports an error if
+// a struct that exists in the baseline version of its file has a "required"
+// field with an ID that the baseline struct doesn't have. Existing writers
+// don't set the new field, so readers would reject their messages. Baseline
+// files are found by joining baselineDir with the linted file's path. An
+// empty baselineDir disables the check.
+func CheckNoNewRequiredField(baselineDir string) thriftcheck.Check {
+	return newCheck("field.required.added", func(c *thriftcheck.C, s *ast.Struct) {
+		if baselineDir == "" {
+			return
+		}
+		program, _ := c.Included(filepath.Join(baselineDir, c.Filename))
+		if program == nil {
+			return
+		}
+
+		var baseline *ast.Struct
+		for _, def := range program.Definitions {
+			if b, ok := def.(*ast.Struct); ok && b.Name == s.Name {
+				baseline = b
+				break
+			}
+		}
+		if baseline == nil {
+			return
+		}
+
+		ids := make(map[int]bool, len(baseline.Fields))
+		for _, f := range baseline.Fields {
+			ids[f.ID] = true
+		}
+		for _, f := range s.Fields {
+			if f.Requiredness == ast.Required && !f.IDUnset && !ids[f.ID] {
+				c.Errorf(f, `new field %q (%d) in %q must not be "required"`, f.Name, f.ID, s.Name)
+			}
+		}
+	})
+}
+
 // CheckFieldDocMissing warns if a field is missing a documentation comment.
 func CheckFieldDocMissing() thriftcheck.Check {
-	return thriftcheck.NewCheck("field.doc.missing", func(c *thriftcheck.C, f *ast.Field) {
+	return newCheck("field.doc.missing", func(c *thriftcheck.C, f *ast.Field) {
 		if f.Doc == "" {
 			c.Warningf(f, `field %q (%d) is missing a documentation comment`, f.Name, f.ID)
 		}
 	})
 }
+
+// DocTypeRule requires fields whose documentation mentions Keyword (as a
+// whole word, ignoring case) to have one of the given Types.
+type DocTypeRule struct {
+	Keyword string                   `fig:"keyword" validate:"required"`
+	Types   []thriftcheck.ThriftType `fig:"types" validate:"required"`
+}
+
+var defaultDocTypeRules = []DocTypeRule{
+	{Keyword: "timestamp", Types: thriftTypes("i64")},
+	{Keyword: "count", Types: thriftTypes("i8", "i16", "i32", "i64")},
+	{Keyword: "flag", Types: thriftTypes("bool")},
+}
+
+// thriftTypes returns the named types, panicking if any are unknown.
+func thriftTypes(names ...string) []thriftcheck.ThriftType {
+	types := make([]thriftcheck.ThriftType, len(names))
+	for i, name := range names {
+		if err := types[i].UnmarshalString(name); err != nil {
+			panic(err)
+		}
+	}
+	return types
+}
+
+// CheckDocTypeConsistency returns a thriftcheck.Check that warns when a
+// field's documentation mentions a rule's keyword but the field (resolving
+// typedefs) doesn't have one of the rule's types. Rules are tried in order,
+// and only the first rule whose keyword is mentioned applies. If no rules
+// are given, defaults for timestamps, counts, and flags are used.
+func CheckDocTypeConsistency(rules []DocTypeRule) thriftcheck.Check {
+	if len(rules) == 0 {
+		rules = defaultDocTypeRules
+	}
+	keywords := make([]*regexp.Regexp, len(rules))
+	for i, r := range rules {
+		keywords[i] = regexp.MustCompile(`(?i)\b` + regexp.QuoteMeta(r.Keyword) + `\b`)
+	}
+
+	return newCheck("field.doc.type.mismatch", func(c *thriftcheck.C, f *ast.Field) {
+		for i, r := range rules {
+			if !keywords[i].MatchString(f.Doc) {
+				continue
+			}
+			if ok, _ := c.IsTypeAllowed(resolveType(c, f.Type), r.Types, nil); !ok {
+				c.Warningf(f, "field %q (%d) is documented as a %s but has type %q rather than one of %v",
+					f.Name, f.ID, r.Keyword, f.Type, r.Types)
+			}
+			return
+		}
+	})
+}
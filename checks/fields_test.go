@@ -15,8 +15,14 @@
 package checks_test
 
 import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
 	"testing"
 
+	"github.com/pinterest/thriftcheck"
 	"github.com/pinterest/thriftcheck/checks"
 	"go.uber.org/thriftrw/ast"
 )
@@ -39,6 +45,544 @@ func TestCheckFieldIDMissing(t *testing.T) {
 	RunTests(t, &check, tests)
 }
 
+func TestCheckFieldIDMissingFix(t *testing.T) {
+	linter := thriftcheck.NewLinter(thriftcheck.Checks{checks.CheckFieldIDMissing()})
+
+	src := `struct S {
+  2: string a
+  string b
+  5: string c
+  string d
+}
+
+service Users {
+  void get(string id) throws (NotFound notFound)
+}`
+	msgs, err := linter.Lint(strings.NewReader(src), "t.thrift")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := `struct S {
+  2: string a
+  6: string b
+  5: string c
+  7: string d
+}
+
+service Users {
+  void get(1: string id) throws (1: NotFound notFound)
+}`
+	fixed, remaining := thriftcheck.ApplyFixes([]byte(src), msgs)
+	if string(fixed) != want {
+		t.Errorf("expected:\n%s\ngot:\n%s", want, fixed)
+	}
+	if len(remaining) != 0 {
+		t.Errorf("expected every message to be fixed, got %v", remaining)
+	}
+}
+
+func TestCheckFieldIDDuplicate(t *testing.T) {
+	tests := []Test{
+		{
+			node: &ast.Struct{Name: "S", Fields: []*ast.Field{
+				{ID: 1, Name: "a"},
+				{ID: 2, Name: "b"},
+			}},
+			want: []string{},
+		},
+		{
+			node: &ast.Struct{Name: "S", Fields: []*ast.Field{
+				{ID: 1, Name: "a"},
+				{ID: 2, Name: "b"},
+				{ID: 1, Name: "c"},
+			}},
+			want: []string{
+				`t.thrift:0:1: error: field "c" reuses field ID 1 of "a" (field.id.duplicate)`,
+			},
+		},
+		{
+			node: &ast.Struct{Name: "S", Fields: []*ast.Field{
+				{ID: -1, Name: "a", IDUnset: true},
+				{ID: -1, Name: "b", IDUnset: true},
+			}},
+			want: []string{},
+		},
+	}
+
+	check := checks.CheckFieldIDDuplicate()
+	RunTests(t, &check, tests)
+}
+
+func TestFieldIDChecks(t *testing.T) {
+	linter := thriftcheck.NewLinter(thriftcheck.Checks{
+		checks.CheckFieldIDDuplicate(),
+		checks.CheckFieldIDMissing(),
+		checks.CheckFieldIDNegative(),
+		checks.CheckFieldIDZero(),
+	})
+
+	src := `union U {
+  1: string a
+  string b
+  1: string c
+  -2: string d
+}`
+	msgs, err := linter.Lint(strings.NewReader(src), "t.thrift")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []string{
+		`t.thrift:4:3: error: field "c" reuses field ID 1 of "a" (field.id.duplicate)`,
+		`t.thrift:3:3: error: field ID for "b" is missing (field.id.missing)`,
+		`t.thrift:5:3: error: field ID for "d" (-2) is negative (field.id.negative)`,
+	}
+	var got []string
+	for _, m := range msgs {
+		got = append(got, m.String())
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+}
+
+func TestCheckCaseInsensitiveFieldCollision(t *testing.T) {
+	tests := []Test{
+		{
+			node: &ast.Struct{Name: "S", Fields: []*ast.Field{
+				{Name: "userId", Line: 2},
+				{Name: "user_id", Line: 3},
+				{Name: "name", Line: 4},
+			}},
+			want: []string{},
+		},
+		{
+			node: &ast.Struct{Name: "S", Fields: []*ast.Field{
+				{Name: "userId", Line: 2},
+				{Name: "name", Line: 3},
+				{Name: "userid", Line: 4},
+			}},
+			want: []string{
+				`t.thrift:4:1: error: field "userid" differs only in case from "userId" (line 2) (field.name.case.collision)`,
+			},
+		},
+		{
+			node: &ast.Struct{Name: "E", Type: ast.ExceptionType, Fields: []*ast.Field{
+				{Name: "Message", Line: 2},
+				{Name: "message", Line: 3},
+				{Name: "MESSAGE", Line: 4},
+			}},
+			want: []string{
+				`t.thrift:3:1: error: field "message" differs only in case from "Message" (line 2) (field.name.case.collision)`,
+				`t.thrift:4:1: error: field "MESSAGE" differs only in case from "Message" (line 2) (field.name.case.collision)`,
+			},
+		},
+	}
+
+	check := checks.CheckCaseInsensitiveFieldCollision()
+	RunTests(t, &check, tests)
+}
+
+func TestCheckPIIAnnotation(t *testing.T) {
+	tests := []Test{
+		{
+			node: &ast.Field{Name: "email", Doc: "@pii"},
+			want: []string{},
+		},
+		{
+			node: &ast.Field{Name: "homeAddress", Annotations: []*ast.Annotation{{Name: "pii", Value: "true"}}},
+			want: []string{},
+		},
+		{
+			node: &ast.Field{Name: "name"},
+			want: []string{},
+		},
+		{
+			node: &ast.Field{Name: "email"},
+			want: []string{
+				`t.thrift:0:1: error: field "email" appears to contain PII but isn't annotated with @pii (field.pii.annotation)`,
+			},
+		},
+		{
+			node: &ast.Field{Name: "PhoneNumber", Doc: "The user's phone number"},
+			want: []string{
+				`t.thrift:0:1: error: field "PhoneNumber" appears to contain PII but isn't annotated with @pii (field.pii.annotation)`,
+			},
+		},
+	}
+
+	check := checks.CheckPIIAnnotation(nil)
+	RunTests(t, &check, tests)
+
+	tests = []Test{
+		{
+			node: &ast.Field{Name: "email"},
+			want: []string{},
+		},
+		{
+			node: &ast.Field{Name: "full_name"},
+			want: []string{
+				`t.thrift:0:1: error: field "full_name" appears to contain PII but isn't annotated with @pii (field.pii.annotation)`,
+			},
+		},
+	}
+
+	check = checks.CheckPIIAnnotation(regexp.MustCompile(`name`))
+	RunTests(t, &check, tests)
+}
+
+func TestCheckBoolFieldNaming(t *testing.T) {
+	boolType := ast.BaseType{ID: ast.BoolTypeID}
+	prog := &ast.Program{Definitions: []ast.Definition{
+		&ast.Typedef{Name: "Flag", Type: boolType},
+	}}
+
+	tests := []Test{
+		{
+			node: &ast.Field{ID: 1, Name: "is_active", Type: boolType},
+			want: []string{},
+		},
+		{
+			node: &ast.Field{ID: 1, Name: "active", Type: ast.BaseType{ID: ast.StringTypeID}},
+			want: []string{},
+		},
+		{
+			node: &ast.Field{ID: 1, Name: "active", Type: boolType},
+			want: []string{
+				`t.thrift:0:1: warning: bool field "active" (1) should start with one of: is_, has_, can_, should_ (field.bool.naming)`,
+			},
+		},
+		{
+			prog: prog,
+			node: &ast.Field{ID: 2, Name: "enabled", Type: ast.TypeReference{Name: "Flag"}},
+			want: []string{
+				`t.thrift:0:1: warning: bool field "enabled" (2) should start with one of: is_, has_, can_, should_ (field.bool.naming)`,
+			},
+		},
+	}
+
+	check := checks.CheckBoolFieldNaming(nil)
+	RunTests(t, &check, tests)
+
+	check = checks.CheckBoolFieldNaming([]string{"was_"})
+	RunTests(t, &check, []Test{
+		{
+			node: &ast.Field{ID: 1, Name: "was_active", Type: boolType},
+			want: []string{},
+		},
+		{
+			node: &ast.Field{ID: 1, Name: "is_active", Type: boolType},
+			want: []string{
+				`t.thrift:0:1: warning: bool field "is_active" (1) should start with one of: was_ (field.bool.naming)`,
+			},
+		},
+	})
+}
+
+func TestCheckNoSmallInts(t *testing.T) {
+	fields := func() []*ast.Field {
+		return []*ast.Field{
+			{ID: 1, Name: "count", Line: 2, Type: ast.BaseType{ID: ast.I32TypeID}},
+			{ID: 2, Name: "port", Line: 3, Type: ast.BaseType{ID: ast.I16TypeID}},
+			{ID: 3, Name: "flags", Line: 4, Type: ast.BaseType{ID: ast.I8TypeID}},
+		}
+	}
+
+	tests := []Test{
+		{
+			node: &ast.Struct{Name: "Endpoint", Fields: fields()},
+			want: []string{
+				`t.thrift:3:1: warning: field "port" (2) is i16; prefer i32 outside of low-level structs (field.small.int)`,
+				`t.thrift:4:1: warning: field "flags" (3) is byte; prefer i32 outside of low-level structs (field.small.int)`,
+			},
+		},
+		{
+			node: &ast.Struct{Name: "Endpoint", Doc: "@low-level", Fields: fields()},
+			want: []string{},
+		},
+		{
+			node: &ast.Struct{Name: "Endpoint", Fields: []*ast.Field{
+				{ID: 1, Name: "count", Type: ast.BaseType{ID: ast.I32TypeID}},
+			}},
+			want: []string{},
+		},
+	}
+
+	check := checks.CheckNoSmallInts("")
+	RunTests(t, &check, tests)
+
+	tests = []Test{
+		{
+			node: &ast.Struct{Name: "Endpoint", Annotations: []*ast.Annotation{{Name: "packed"}}, Fields: fields()},
+			want: []string{},
+		},
+	}
+
+	check = checks.CheckNoSmallInts("packed")
+	RunTests(t, &check, tests)
+}
+
+func TestCheckReservedFieldPrefix(t *testing.T) {
+	stringType := ast.BaseType{ID: ast.StringTypeID}
+
+	tests := []Test{
+		{
+			node: &ast.Field{ID: 1, Name: "internal_note", Type: stringType},
+			want: []string{},
+		},
+		{
+			node: &ast.Field{ID: 1, Name: "thrift_internal", Type: stringType},
+			want: []string{
+				`t.thrift:0:1: error: field "thrift_internal" (1) uses reserved prefix "thrift_" (field.reserved.prefix)`,
+			},
+		},
+		{
+			node: &ast.Field{ID: 2, Name: "__isset", Type: stringType},
+			want: []string{
+				`t.thrift:0:1: error: field "__isset" (2) uses reserved prefix "__" (field.reserved.prefix)`,
+			},
+		},
+	}
+
+	check := checks.CheckReservedFieldPrefix(nil)
+	RunTests(t, &check, tests)
+
+	check = checks.CheckReservedFieldPrefix([]string{"gen_"})
+	RunTests(t, &check, []Test{
+		{
+			node: &ast.Field{ID: 1, Name: "thrift_internal", Type: stringType},
+			want: []string{},
+		},
+		{
+			node: &ast.Field{ID: 1, Name: "gen_token", Type: stringType},
+			want: []string{
+				`t.thrift:0:1: error: field "gen_token" (1) uses reserved prefix "gen_" (field.reserved.prefix)`,
+			},
+		},
+	})
+}
+
+func TestCheckContainerDefaultEmpty(t *testing.T) {
+	listType := ast.ListType{ValueType: ast.BaseType{ID: ast.StringTypeID}}
+	mapType := ast.MapType{KeyType: ast.BaseType{ID: ast.StringTypeID}, ValueType: ast.BaseType{ID: ast.I32TypeID}}
+	prog := &ast.Program{Definitions: []ast.Definition{
+		&ast.Constant{Name: "DefaultNames", Type: listType, Value: ast.ConstantList{Items: []ast.ConstantValue{ast.ConstantString("root")}}},
+	}}
+
+	tests := []Test{
+		{
+			node: &ast.Field{ID: 1, Name: "names", Type: listType, Default: ast.ConstantList{}},
+			want: []string{},
+		},
+		{
+			node: &ast.Field{ID: 1, Name: "names", Type: listType, Default: ast.ConstantList{Items: []ast.ConstantValue{ast.ConstantString("root")}}},
+			want: []string{
+				`t.thrift:0:1: warning: container field "names" (1) has a non-empty default value (field.container.default)`,
+			},
+		},
+		{
+			node: &ast.Field{ID: 1, Name: "names", Type: listType},
+			want: []string{},
+		},
+		{
+			node: &ast.Field{ID: 2, Name: "counts", Type: mapType, Default: ast.ConstantMap{Items: []ast.ConstantMapItem{{Key: ast.ConstantString("a"), Value: ast.ConstantInteger(1)}}}},
+			want: []string{
+				`t.thrift:0:1: warning: container field "counts" (2) has a non-empty default value (field.container.default)`,
+			},
+		},
+		{
+			prog: prog,
+			node: &ast.Field{ID: 3, Name: "aliases", Type: listType, Default: ast.ConstantReference{Name: "DefaultNames"}},
+			want: []string{
+				`t.thrift:0:1: warning: container field "aliases" (3) has a non-empty default value (field.container.default)`,
+			},
+		},
+		{
+			node: &ast.Field{ID: 4, Name: "name", Type: ast.BaseType{ID: ast.StringTypeID}, Default: ast.ConstantString("root")},
+			want: []string{},
+		},
+	}
+
+	check := checks.CheckContainerDefaultEmpty()
+	RunTests(t, &check, tests)
+}
+
+func TestCheckContainerFieldOptional(t *testing.T) {
+	listType := ast.ListType{ValueType: ast.BaseType{ID: ast.StringTypeID}}
+	prog := &ast.Program{Definitions: []ast.Definition{
+		&ast.Typedef{Name: "Names", Type: listType},
+	}}
+
+	tests := []Test{
+		{
+			node: &ast.Field{ID: 1, Name: "names", Requiredness: ast.Optional, Type: listType},
+			want: []string{},
+		},
+		{
+			node: &ast.Field{ID: 1, Name: "names", Requiredness: ast.Required, Type: listType},
+			want: []string{
+				`t.thrift:0:1: warning: container field "names" (1) should be "optional" rather than "required" (field.container.optional)`,
+			},
+		},
+		{
+			node: &ast.Field{ID: 2, Name: "tags", Requiredness: ast.Required, Type: ast.SetType{ValueType: ast.BaseType{ID: ast.StringTypeID}}},
+			want: []string{
+				`t.thrift:0:1: warning: container field "tags" (2) should be "optional" rather than "required" (field.container.optional)`,
+			},
+		},
+		{
+			prog: prog,
+			node: &ast.Field{ID: 3, Name: "aliases", Requiredness: ast.Required, Type: ast.TypeReference{Name: "Names"}},
+			want: []string{
+				`t.thrift:0:1: warning: container field "aliases" (3) should be "optional" rather than "required" (field.container.optional)`,
+			},
+		},
+		{
+			node: &ast.Field{ID: 4, Name: "id", Requiredness: ast.Required, Type: ast.BaseType{ID: ast.I64TypeID}},
+			want: []string{},
+		},
+	}
+
+	check := checks.CheckContainerFieldOptional()
+	RunTests(t, &check, tests)
+}
+
+func TestCheckFieldIDGap(t *testing.T) {
+	tests := []Test{
+		{
+			node: &ast.Struct{Name: "S", Fields: []*ast.Field{
+				{ID: 10, Name: "a"},
+				{ID: 30, Name: "c"},
+				{ID: 20, Name: "b"},
+				{Name: "unset", IDUnset: true},
+			}},
+			want: []string{},
+		},
+		{
+			node: &ast.Struct{Name: "S", Fields: []*ast.Field{
+				{ID: 10, Name: "a", Line: 2},
+				{ID: 11, Name: "b", Line: 3},
+				{ID: 21, Name: "c", Line: 4},
+				{ID: 25, Name: "d", Line: 5},
+			}},
+			want: []string{
+				`t.thrift:3:1: warning: field "b" (11) should be at least 10 IDs after "a" (10) (field.id.gap)`,
+				`t.thrift:5:1: warning: field "d" (25) should be at least 10 IDs after "c" (21) (field.id.gap)`,
+			},
+		},
+	}
+
+	check := checks.CheckFieldIDGap(10)
+	RunTests(t, &check, tests)
+
+	disabled := checks.CheckFieldIDGap(0)
+	RunTests(t, &disabled, []Test{{node: tests[1].node, want: []string{}}})
+}
+
+func TestCheckFirstFieldIDIsOne(t *testing.T) {
+	tests := []Test{
+		{
+			node: &ast.Struct{Name: "S"},
+			want: []string{},
+		},
+		{
+			node: &ast.Struct{Name: "S", Fields: []*ast.Field{{ID: 2}, {ID: 1}}},
+			want: []string{},
+		},
+		{
+			node: &ast.Struct{Name: "S", Fields: []*ast.Field{{ID: 2}, {ID: 3}}},
+			want: []string{
+				`t.thrift:0:1: warning: "S" should start with field ID 1 (lowest is 2) (field.id.first)`,
+			},
+		},
+		{
+			node: &ast.Struct{Name: "S", Fields: []*ast.Field{{ID: 1}, {ID: 5}}},
+			want: []string{},
+		},
+		{
+			node: &ast.Struct{Name: "S", Fields: []*ast.Field{{IDUnset: true}}},
+			want: []string{},
+		},
+	}
+
+	check := checks.CheckFirstFieldIDIsOne(false)
+	RunTests(t, &check, tests)
+
+	tests = []Test{
+		{
+			node: &ast.Struct{Name: "S", Fields: []*ast.Field{{ID: 1}, {ID: 2}}},
+			want: []string{},
+		},
+		{
+			node: &ast.Struct{Name: "S", Fields: []*ast.Field{{ID: 1}, {ID: 4}}},
+			want: []string{
+				`t.thrift:0:1: warning: "S" has a gap in field IDs between 1 and 4 (field.id.first)`,
+			},
+		},
+	}
+
+	check = checks.CheckFirstFieldIDIsOne(true)
+	RunTests(t, &check, tests)
+}
+
+func TestCheckIDFieldLengthBound(t *testing.T) {
+	stringType := ast.BaseType{ID: ast.StringTypeID}
+	maxlen := []*ast.Annotation{{Name: "maxlen", Value: "64"}}
+	prog := &ast.Program{Definitions: []ast.Definition{
+		&ast.Typedef{Name: "Token", Type: ast.BaseType{ID: ast.BinaryTypeID}},
+	}}
+
+	tests := []Test{
+		{
+			node: &ast.Field{ID: 1, Name: "user_id", Type: stringType, Annotations: maxlen},
+			want: []string{},
+		},
+		{
+			node: &ast.Field{ID: 1, Name: "user_id", Type: stringType, Doc: "@maxlen(64)"},
+			want: []string{},
+		},
+		{
+			node: &ast.Field{ID: 1, Name: "user_id", Type: ast.BaseType{ID: ast.I64TypeID}},
+			want: []string{},
+		},
+		{
+			node: &ast.Field{ID: 1, Name: "name", Type: stringType},
+			want: []string{},
+		},
+		{
+			node: &ast.Field{ID: 1, Name: "user_id", Type: stringType},
+			want: []string{
+				`t.thrift:0:1: warning: ID field "user_id" (1) should bound its length with a maxlen annotation (field.id.length.bound)`,
+			},
+		},
+		{
+			prog: prog,
+			node: &ast.Field{ID: 2, Name: "sessionId", Type: ast.TypeReference{Name: "Token"}},
+			want: []string{
+				`t.thrift:0:1: warning: ID field "sessionId" (2) should bound its length with a maxlen annotation (field.id.length.bound)`,
+			},
+		},
+	}
+
+	check := checks.CheckIDFieldLengthBound(nil, "")
+	RunTests(t, &check, tests)
+
+	check = checks.CheckIDFieldLengthBound(regexp.MustCompile(`_key$`), "max_length")
+	RunTests(t, &check, []Test{
+		{
+			node: &ast.Field{ID: 1, Name: "user_id", Type: stringType},
+			want: []string{},
+		},
+		{
+			node: &ast.Field{ID: 1, Name: "cache_key", Type: stringType, Annotations: maxlen},
+			want: []string{
+				`t.thrift:0:1: warning: ID field "cache_key" (1) should bound its length with a max_length annotation (field.id.length.bound)`,
+			},
+		},
+	})
+}
+
 func TestCheckFieldIDNegative(t *testing.T) {
 	tests := []Test{
 		{
@@ -135,6 +679,66 @@ func TestCheckFieldRequiredness(t *testing.T) {
 
 	check := checks.CheckFieldRequiredness()
 	RunTests(t, &check, tests)
+
+	// The parser keeps fields without a qualifier distinct from optional ones.
+	linter := thriftcheck.NewLinter(thriftcheck.Checks{check})
+	src := "struct S {\n  1: string a\n  2: optional string b\n  3: required string c\n}"
+	msgs, err := linter.Lint(strings.NewReader(src), "t.thrift")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := `t.thrift:2:3: warning: field "a" (1) should be explicitly "required" or "optional" (field.requiredness)`
+	if len(msgs) != 1 || msgs[0].String() != want {
+		t.Errorf("expected [%s], got %v", want, msgs)
+	}
+}
+
+func TestCheckNoNewRequiredField(t *testing.T) {
+	dir := t.TempDir()
+	baseline := "struct User {\n  1: required i64 id\n  2: optional string name\n}"
+	if err := os.WriteFile(filepath.Join(dir, "t.thrift"), []byte(baseline), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []Test{
+		{
+			node: &ast.Struct{Name: "User", Fields: []*ast.Field{
+				{ID: 1, Name: "id", Requiredness: ast.Required},
+				{ID: 2, Name: "name", Requiredness: ast.Optional},
+				{ID: 3, Name: "email", Requiredness: ast.Optional},
+			}},
+			want: []string{},
+		},
+		{
+			node: &ast.Struct{Name: "User", Fields: []*ast.Field{
+				{ID: 1, Name: "id", Requiredness: ast.Required},
+				{ID: 2, Name: "name", Requiredness: ast.Required},
+				{ID: 3, Name: "email", Requiredness: ast.Required, Line: 4},
+			}},
+			want: []string{
+				`t.thrift:4:1: error: new field "email" (3) in "User" must not be "required" (field.required.added)`,
+			},
+		},
+		{
+			node: &ast.Struct{Name: "Account", Fields: []*ast.Field{
+				{ID: 1, Name: "id", Requiredness: ast.Required},
+			}},
+			want: []string{},
+		},
+		{
+			name: "missing.thrift",
+			node: &ast.Struct{Name: "User", Fields: []*ast.Field{
+				{ID: 3, Name: "email", Requiredness: ast.Required},
+			}},
+			want: []string{},
+		},
+	}
+
+	check := checks.CheckNoNewRequiredField(dir)
+	RunTests(t, &check, tests)
+
+	disabled := checks.CheckNoNewRequiredField("")
+	RunTests(t, &disabled, []Test{{node: tests[1].node, want: []string{}}})
 }
 
 func TestCheckFieldDocMissing(t *testing.T) {
@@ -154,3 +758,233 @@ func TestCheckFieldDocMissing(t *testing.T) {
 	check := checks.CheckFieldDocMissing()
 	RunTests(t, &check, tests)
 }
+
+func TestCheckConsistentIDWidth(t *testing.T) {
+	i32 := ast.BaseType{ID: ast.I32TypeID}
+	i64 := ast.BaseType{ID: ast.I64TypeID}
+	prog := &ast.Program{Definitions: []ast.Definition{
+		&ast.Typedef{Name: "UserId", Type: i64},
+	}}
+
+	tests := []Test{
+		{
+			prog: prog,
+			node: &ast.Struct{Name: "Edge", Fields: []*ast.Field{
+				{ID: 1, Name: "parent_id", Type: i64},
+				{ID: 2, Name: "childId", Type: ast.TypeReference{Name: "UserId"}},
+				{ID: 3, Name: "count", Type: i32},
+				{ID: 4, Name: "request_id", Type: ast.BaseType{ID: ast.StringTypeID}},
+			}},
+			want: []string{},
+		},
+		{
+			prog: prog,
+			node: &ast.Struct{Name: "Edge", Fields: []*ast.Field{
+				{ID: 1, Name: "parent_id", Type: i64},
+				{ID: 2, Name: "child_id", Type: i32},
+				{ID: 3, Name: "ownerID", Type: ast.TypeReference{Name: "UserId"}},
+			}},
+			want: []string{
+				`t.thrift:0:1: warning: field "child_id" is i32 but "parent_id" is i64 (field.id.width.consistent)`,
+			},
+		},
+		{
+			prog: prog,
+			node: &ast.Struct{Name: "Video", Fields: []*ast.Field{
+				{ID: 1, Name: "id", Type: i32},
+				{ID: 2, Name: "valid", Type: i64},
+				{ID: 3, Name: "paid", Type: i64},
+			}},
+			want: []string{},
+		},
+	}
+
+	check := checks.CheckConsistentIDWidth(nil)
+	RunTests(t, &check, tests)
+}
+
+func TestCheckDocTypeConsistency(t *testing.T) {
+	i64Type := ast.BaseType{ID: ast.I64TypeID}
+	stringType := ast.BaseType{ID: ast.StringTypeID}
+	prog := &ast.Program{Definitions: []ast.Definition{
+		&ast.Typedef{Name: "Timestamp", Type: i64Type},
+	}}
+
+	tests := []Test{
+		{
+			node: &ast.Field{ID: 1, Name: "created", Type: i64Type, Doc: "Timestamp in millis"},
+			want: []string{},
+		},
+		{
+			prog: prog,
+			node: &ast.Field{ID: 1, Name: "created", Type: ast.TypeReference{Name: "Timestamp"}, Doc: "Creation timestamp"},
+			want: []string{},
+		},
+		{
+			node: &ast.Field{ID: 1, Name: "created", Type: stringType, Doc: "Timestamps are hard"},
+			want: []string{},
+		},
+		{
+			node: &ast.Field{ID: 1, Name: "created", Type: stringType, Doc: "Timestamp in millis"},
+			want: []string{
+				`t.thrift:0:1: warning: field "created" (1) is documented as a timestamp but has type "string" rather than one of [i64] (field.doc.type.mismatch)`,
+			},
+		},
+		{
+			node: &ast.Field{ID: 2, Name: "views", Type: ast.BaseType{ID: ast.DoubleTypeID}, Doc: "The view count"},
+			want: []string{
+				`t.thrift:0:1: warning: field "views" (2) is documented as a count but has type "double" rather than one of [i8 i16 i32 i64] (field.doc.type.mismatch)`,
+			},
+		},
+		{
+			node: &ast.Field{ID: 3, Name: "flags", Type: ast.BaseType{ID: ast.I32TypeID}, Doc: "The count of set flags"},
+			want: []string{},
+		},
+	}
+
+	check := checks.CheckDocTypeConsistency(nil)
+	RunTests(t, &check, tests)
+
+	check = checks.CheckDocTypeConsistency([]checks.DocTypeRule{
+		{Keyword: "ratio", Types: []thriftcheck.ThriftType{ParseType(t, "double")}},
+	})
+	RunTests(t, &check, []Test{
+		{
+			node: &ast.Field{ID: 1, Name: "created", Type: stringType, Doc: "Timestamp in millis"},
+			want: []string{},
+		},
+		{
+			node: &ast.Field{ID: 1, Name: "ratio", Type: i64Type, Doc: "Hit ratio"},
+			want: []string{
+				`t.thrift:0:1: warning: field "ratio" (1) is documented as a ratio but has type "i64" rather than one of [double] (field.doc.type.mismatch)`,
+			},
+		},
+	})
+}
+
+func TestCheckDefaultConstDefined(t *testing.T) {
+	tests := []MultiFileTest{
+		{
+			files: map[string]string{
+				"a.thrift": "include \"b.thrift\"\nconst i32 LIMIT = 10\nstruct S {\n  1: optional i32 x = LIMIT\n  2: optional i32 y = b.OTHER\n  3: optional b.Kind kind = b.Kind.A\n}",
+				"b.thrift": "const i32 OTHER = 1\nenum Kind { A }",
+			},
+			want: []string{},
+		},
+		{
+			files: map[string]string{
+				"a.thrift": "struct S {\n  1: optional i32 x = UNDEFINED_CONST\n  2: optional i32 y = 5\n  3: optional string z = \"UNDEFINED_CONST\"\n}",
+			},
+			want: []string{
+				`a.thrift:2:21: error: default value of field "x" refers to undefined constant "UNDEFINED_CONST" (field.default.const.undefined)`,
+			},
+		},
+		{
+			files: map[string]string{
+				"a.thrift": "include \"b.thrift\"\nstruct S {\n  1: optional i32 x = b.MISSING\n  2: optional b.Kind kind = b.Kind.B\n}",
+				"b.thrift": "enum Kind { A }",
+			},
+			want: []string{
+				`a.thrift:3:21: error: default value of field "x" refers to undefined constant "b.MISSING" (field.default.const.undefined)`,
+				`a.thrift:4:27: error: default value of field "kind" refers to undefined constant "b.Kind.B" (field.default.const.undefined)`,
+			},
+		},
+	}
+
+	check := checks.CheckDefaultConstDefined()
+	RunMultiFileTests(t, &check, tests)
+}
+
+func TestCheckOptionalDoc(t *testing.T) {
+	stringType := ast.BaseType{ID: ast.StringTypeID}
+
+	tests := []Test{
+		{
+			node: &ast.Field{ID: 1, Name: "nickname", Requiredness: ast.Optional, Type: stringType, Doc: "The nickname, or unset if none was chosen"},
+			want: []string{},
+		},
+		{
+			node: &ast.Field{ID: 1, Name: "nickname", Requiredness: ast.Optional, Type: stringType, Doc: "Absent for anonymous users"},
+			want: []string{},
+		},
+		{
+			node: &ast.Field{ID: 1, Name: "nickname", Requiredness: ast.Required, Type: stringType},
+			want: []string{},
+		},
+		{
+			node: &ast.Field{ID: 1, Name: "nickname", Requiredness: ast.Optional, Type: stringType, Doc: "The user's nickname"},
+			want: []string{
+				`t.thrift:0:1: warning: optional field "nickname" (1) should document what it means when it's absent (field.optional.doc)`,
+			},
+		},
+		{
+			node: &ast.Field{ID: 2, Name: "nulls", Requiredness: ast.Optional, Type: stringType},
+			want: []string{
+				`t.thrift:0:1: warning: optional field "nulls" (2) should document what it means when it's absent (field.optional.doc)`,
+			},
+		},
+	}
+
+	check := checks.CheckOptionalDoc()
+	RunTests(t, &check, tests)
+}
+
+func TestCheckNoTypeInFieldName(t *testing.T) {
+	prog := &ast.Program{Definitions: []ast.Definition{
+		&ast.Struct{Name: "User", Type: ast.StructType},
+		&ast.Enum{Name: "Status"},
+		&ast.Typedef{Name: "Count", Type: ast.BaseType{ID: ast.I32TypeID}},
+	}}
+	user := ast.TypeReference{Name: "User"}
+	i32 := ast.BaseType{ID: ast.I32TypeID}
+
+	tests := []Test{
+		{
+			prog: prog,
+			node: &ast.Field{ID: 1, Name: "user", Type: user},
+			want: []string{},
+		},
+		{
+			prog: prog,
+			node: &ast.Field{ID: 1, Name: "user_struct", Type: user},
+			want: []string{
+				`t.thrift:0:1: warning: field "user_struct" (1) of type User restates its type with "struct" (field.name.type.redundant)`,
+			},
+		},
+		{
+			node: &ast.Field{ID: 2, Name: "count", Type: i32},
+			want: []string{},
+		},
+		{
+			node: &ast.Field{ID: 2, Name: "count_int", Type: i32},
+			want: []string{
+				`t.thrift:0:1: warning: field "count_int" (2) of type i32 restates its type with "int" (field.name.type.redundant)`,
+			},
+		},
+		{
+			prog: prog,
+			node: &ast.Field{ID: 3, Name: "intCount", Type: ast.TypeReference{Name: "Count"}},
+			want: []string{
+				`t.thrift:0:1: warning: field "intCount" (3) of type Count restates its type with "int" (field.name.type.redundant)`,
+			},
+		},
+		{
+			prog: prog,
+			node: &ast.Field{ID: 4, Name: "statusEnum", Type: ast.TypeReference{Name: "Status"}},
+			want: []string{
+				`t.thrift:0:1: warning: field "statusEnum" (4) of type Status restates its type with "Enum" (field.name.type.redundant)`,
+			},
+		},
+		{
+			node: &ast.Field{ID: 5, Name: "is_string", Type: ast.BaseType{ID: ast.BoolTypeID}},
+			want: []string{},
+		},
+		{
+			node: &ast.Field{ID: 6, Name: "max_int_value", Type: i32},
+			want: []string{},
+		},
+	}
+
+	check := checks.CheckNoTypeInFieldName()
+	RunTests(t, &check, tests)
+}
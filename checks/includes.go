@@ -15,19 +15,24 @@
 package checks
 
 import (
+	"errors"
+	"maps"
 	"os"
 	"path/filepath"
 	"regexp"
+	"slices"
+	"strings"
 
 	"github.com/danwakefield/fnmatch"
 	"github.com/pinterest/thriftcheck"
 	"go.uber.org/thriftrw/ast"
+	"go.uber.org/thriftrw/idl"
 )
 
 // CheckIncludePath returns a thriftcheck.Check that verifies that all of the
 // files `include`'d by a Thrift file can be found in the includes paths.
 func CheckIncludePath() thriftcheck.Check {
-	return thriftcheck.NewCheck("include.path", func(c *thriftcheck.C, i *ast.Include) {
+	return newCheck("include.path", func(c *thriftcheck.C, i *ast.Include) {
 		// If the path is absolute, we don't need to check the include paths.
 		if filepath.IsAbs(i.Path) {
 			if info, err := os.Stat(i.Path); err != nil || info.IsDir() {
@@ -36,27 +41,163 @@ func CheckIncludePath() thriftcheck.Check {
 			return
 		}
 
-		found := false
-		for _, dir := range c.Dirs {
-			path := filepath.Join(dir, i.Path)
-			if info, err := os.Stat(path); err == nil && !info.IsDir() {
-				found = true
-				break
-			}
-		}
-		if !found {
+		if _, ok := thriftcheck.FindFile(i.Path, c.Dirs); !ok {
 			c.Errorf(i, "unable to find include file %q", i.Path)
 		}
 	})
 }
 
+// CheckCircularImport returns a multi-file thriftcheck.Check that reports an
+// error for each chain of includes between the linted files that leads back
+// to the file where it started. The error is reported at the include that
+// starts the chain, and the message lists every file in it.
+//
+// Includes are resolved relative to the including file and then to each of
+// the linter's include directories, and files are identified by their
+// absolute paths, so a file is recognized whether it was linted directly or
+// found through an include directory.
+func CheckCircularImport() thriftcheck.Check {
+	return CheckCircularImportResolver(filepath.Abs)
+}
+
+// CheckCircularImportResolver returns a CheckCircularImport check that uses
+// resolve, rather than filepath.Abs, to normalize the paths that identify
+// files. When a path can't be normalized, its cleaned form is used instead
+// and a warning is reported at the include, because a cycle through it might
+// not be detected.
+func CheckCircularImportResolver(resolve func(path string) (string, error)) thriftcheck.Check {
+	type failure struct {
+		loc  thriftcheck.Location
+		path string
+		err  error
+	}
+	graph := make(includeGraph)
+	includes := make(map[[2]string]thriftcheck.Location)
+	names := make(map[string]string)
+	paths := make(pathCache)
+	var failures []failure
+
+	canonical := func(path string) (string, error) {
+		if canonical, ok := paths[path]; ok {
+			return canonical, nil
+		}
+		canonical, err := resolve(path)
+		if err != nil {
+			return filepath.Clean(path), err
+		}
+		paths[path] = canonical
+		return canonical, nil
+	}
+
+	return newMultiFileCheck("import.cycle.disallowed", func(c *thriftcheck.C, i *ast.Include) {
+		path, ok := thriftcheck.FindFile(i.Path, c.Dirs)
+		if !ok {
+			return
+		}
+
+		// Messages name files as they were linted, if they were, or as they
+		// were found otherwise.
+		filename, err := canonical(c.Filename)
+		if err != nil {
+			failures = append(failures, failure{c.Locate(i), c.Filename, err})
+		}
+		target, err := canonical(path)
+		if err != nil {
+			failures = append(failures, failure{c.Locate(i), path, err})
+		}
+		names[filename] = filepath.Clean(c.Filename)
+		if _, ok := names[target]; !ok {
+			names[target] = path
+		}
+
+		edge := [2]string{filename, target}
+		if _, ok := includes[edge]; !ok {
+			graph[filename] = append(graph[filename], target)
+			includes[edge] = c.Locate(i)
+		}
+	}, func(c *thriftcheck.C) {
+		defer clear(graph)
+		defer clear(includes)
+		defer clear(names)
+		defer clear(paths)
+		defer func() { failures = nil }()
+
+		for _, f := range failures {
+			c.WarningfAt(f.loc, "couldn't normalize the path %q, so circular imports through it may be missed: %v", f.path, f.err)
+		}
+		for _, cycle := range graph.cycles() {
+			chain := make([]string, 0, len(cycle)+1)
+			for _, filename := range append(cycle, cycle[0]) {
+				chain = append(chain, names[filename])
+			}
+			c.ErrorfAt(includes[[2]string{cycle[0], cycle[1%len(cycle)]}], "circular import: %s", strings.Join(chain, " -> "))
+		}
+	})
+}
+
+// canonicalPath returns the absolute, cleaned form of a path, which
+// identifies a file regardless of the directory that it was found through.
+func canonicalPath(path string) string {
+	if abs, err := filepath.Abs(path); err == nil {
+		return abs
+	}
+	return filepath.Clean(path)
+}
+
+// pathCache memoizes canonicalPath, which otherwise looks up the working
+// directory every time it's called, for checks that see the same paths over
+// and over again.
+type pathCache map[string]string
+
+// canonical returns the canonicalPath of a path.
+func (pc pathCache) canonical(path string) string {
+	if canonical, ok := pc[path]; ok {
+		return canonical
+	}
+	canonical := canonicalPath(path)
+	pc[path] = canonical
+	return canonical
+}
+
+// includeClosure returns the (cleaned) filenames of roots and of every file
+// that they include, directly or indirectly, in breadth-first order. Files
+// are parsed through the run's shared program cache; those that can't be
+// parsed are returned, but their includes aren't followed.
+func includeClosure(c *thriftcheck.C, roots ...string) []string {
+	var closure []string
+	seen := make(map[string]bool)
+	for _, root := range roots {
+		if root = filepath.Clean(root); !seen[root] {
+			seen[root] = true
+			closure = append(closure, root)
+		}
+	}
+	for i := 0; i < len(closure); i++ {
+		filename := closure[i]
+		program, _ := c.Included(filename)
+		if program == nil {
+			continue
+		}
+		dirs := append([]string{filepath.Dir(filename)}, c.Dirs...)
+		for _, h := range program.Headers {
+			if inc, ok := h.(*ast.Include); ok {
+				if path, ok := thriftcheck.FindFile(inc.Path, dirs); ok && !seen[filepath.Clean(path)] {
+					seen[filepath.Clean(path)] = true
+					closure = append(closure, filepath.Clean(path))
+				}
+			}
+		}
+	}
+	return closure
+}
+
 // CheckIncludeRestricted returns a thriftcheck.Check that restricts some files
 // from being imported by other  files using a map of patterns: the key is a
 // file name pattern that matches the including filename and the value is a
 // regular expression that matches the included filename. When both match, the
 // `include` is flagged as "restricted" and an error is reported.
 func CheckIncludeRestricted(patterns map[string]*regexp.Regexp) thriftcheck.Check {
-	return thriftcheck.NewCheck("include.restricted", func(c *thriftcheck.C, i *ast.Include) {
+	return newCheck("include.restricted", func(c *thriftcheck.C, i *ast.Include) {
 		for fpat, ire := range patterns {
 			if fnmatch.Match(fpat, c.Filename, fnmatch.FNM_NOESCAPE) && ire.MatchString(i.Path) {
 				c.Logf("%q (%s) matches %q (%s)\n", c.Filename, fpat, i.Path, ire)
@@ -66,3 +207,405 @@ func CheckIncludeRestricted(patterns map[string]*regexp.Regexp) thriftcheck.Chec
 		}
 	})
 }
+
+// CheckIncludeSeparator returns a thriftcheck.Check that reports an error
+// when an include path uses backslashes (Windows-style separators), which
+// can't be resolved on other systems.
+func CheckIncludeSeparator() thriftcheck.Check {
+	return newCheck("include.separator", func(c *thriftcheck.C, i *ast.Include) {
+		if strings.Contains(i.Path, `\`) {
+			c.Errorf(i, "include path %q must use forward slashes", i.Path)
+		}
+	})
+}
+
+// CheckNarrowerInclude returns a multi-file thriftcheck.Check that warns
+// when a file uses exactly one symbol from an included file and that symbol
+// is a typedef of a type that is defined in another file, such as one of the
+// included file's own includes. Typedefs of typedefs are followed to the
+// file that defines the underlying type. Including that file directly would
+// narrow the file's dependencies. Symbols are resolved through the run's
+// shared symbol tables once every file has been linted.
+func CheckNarrowerInclude() thriftcheck.Check {
+	type candidate struct {
+		filename string
+		include  *ast.Include
+		loc      thriftcheck.Location
+		symbol   string
+	}
+	var candidates []candidate
+
+	return newMultiFileCheck("include.narrower", func(c *thriftcheck.C, p *ast.Program) {
+		used := usedIncludes(p)
+		for _, h := range p.Headers {
+			include, ok := h.(*ast.Include)
+			if !ok {
+				continue
+			}
+			prefix := includePrefix(include)
+			if len(used[prefix]) != 1 {
+				continue
+			}
+			symbol := slices.Collect(maps.Keys(used[prefix]))[0]
+			candidates = append(candidates, candidate{c.Filename, include, c.Locate(include), prefix + "." + symbol})
+		}
+	}, func(c *thriftcheck.C) {
+		defer func() { candidates = nil }()
+
+		for _, cand := range candidates {
+			sym, ok := c.SymbolsFor(cand.filename).Lookup(cand.symbol)
+			if !ok {
+				continue
+			}
+			// Typedefs of the included file's own types, and of the
+			// including file's, can't be narrowed.
+			origin, ok := typedefOrigin(c, sym)
+			if !ok || canonicalPath(origin) == canonicalPath(sym.Filename) || canonicalPath(origin) == canonicalPath(cand.filename) {
+				continue
+			}
+			c.WarningfAt(cand.loc, "include %q is only used for %q, which is defined in %q; consider including that file directly",
+				cand.include.Path, cand.symbol, filepath.Clean(origin))
+		}
+	})
+}
+
+// CheckUnusedInclude returns a thriftcheck.Check that warns about includes
+// whose files aren't referenced by any type, constant, or service in the
+// including file.
+func CheckUnusedInclude() thriftcheck.Check {
+	return newCheck("include.unused", func(c *thriftcheck.C, p *ast.Program) {
+		used := usedIncludes(p)
+		for _, h := range p.Headers {
+			if include, ok := h.(*ast.Include); ok && len(used[includePrefix(include)]) == 0 {
+				c.Warningf(include, "include %q is unused", include.Path)
+			}
+		}
+	})
+}
+
+// usedIncludes returns the symbols that a program uses from each of its
+// included files, keyed by the include's prefix.
+func usedIncludes(p *ast.Program) map[string]map[string]bool {
+	used := make(map[string]map[string]bool)
+	record := func(name string) {
+		if prefix, symbol, ok := strings.Cut(name, "."); ok {
+			if used[prefix] == nil {
+				used[prefix] = make(map[string]bool)
+			}
+			used[prefix][symbol] = true
+		}
+	}
+
+	var visitor thriftcheck.VisitorFunc
+	visitor = func(w ast.Walker, n ast.Node) thriftcheck.VisitorFunc {
+		switch n := n.(type) {
+		case ast.TypeReference:
+			record(n.Name)
+		case ast.ConstantReference:
+			record(n.Name)
+		case *ast.Service:
+			if n.Parent != nil {
+				record(n.Parent.Name)
+			}
+		}
+		return visitor
+	}
+	ast.Walk(visitor, p)
+	return used
+}
+
+// includePrefix returns the prefix that qualifies references to an included
+// file's symbols: the include's name, if it has one, or else the file's base
+// name without its extension.
+func includePrefix(i *ast.Include) string {
+	if i.Name != "" {
+		return i.Name
+	}
+	return strings.TrimSuffix(filepath.Base(i.Path), ".thrift")
+}
+
+// typedefOrigin follows a typedef symbol through the typedefs that it refers
+// to, returning the path of the file that defines the underlying type. It
+// returns false if the symbol isn't a typedef of a named type, or if a
+// reference along the way can't be resolved.
+func typedefOrigin(c *thriftcheck.C, sym thriftcheck.Symbol) (string, bool) {
+	found := false
+	for range 16 {
+		t, ok := sym.Definition.(*ast.Typedef)
+		if !ok {
+			break
+		}
+		ref, ok := t.Type.(ast.TypeReference)
+		if !ok {
+			break
+		}
+		if sym, ok = c.SymbolsFor(sym.Filename).Lookup(ref.Name); !ok {
+			return "", false
+		}
+		found = true
+	}
+	return sym.Filename, found
+}
+
+// CheckOrphanFiles returns a multi-file thriftcheck.Check that warns about
+// linted files that aren't reachable through any chain of includes from a
+// root file. Roots are glob patterns that are matched against the linted
+// filenames. If no roots are given, the check is a no-op.
+func CheckOrphanFiles(roots []string) thriftcheck.Check {
+	graph := make(includeGraph)
+	files := make(map[string]thriftcheck.Location)
+
+	return newMultiFileCheck("file.orphan", func(c *thriftcheck.C, p *ast.Program) {
+		filename := filepath.Clean(c.Filename)
+		graph.add(filename, p, c.Dirs)
+		files[filename] = c.Locate(p)
+	}, func(c *thriftcheck.C) {
+		defer clear(graph)
+		defer clear(files)
+		if len(roots) == 0 {
+			return
+		}
+
+		var rootFiles []string
+		for filename := range files {
+			for _, root := range roots {
+				if fnmatch.Match(root, filename, fnmatch.FNM_NOESCAPE) {
+					rootFiles = append(rootFiles, filename)
+					break
+				}
+			}
+		}
+
+		// Files that couldn't be read are reported by the linter, so only
+		// those that couldn't be parsed are reported here.
+		reachable, failed := graph.reachable(rootFiles, c.Dirs, c.Included)
+		for _, filename := range slices.Sorted(maps.Keys(failed)) {
+			if err := failed[filename]; errors.As(err, new(*idl.ParseError)) {
+				c.WarningfAt(thriftcheck.Location{Filename: filename}, "included file couldn't be parsed: %v", err)
+			}
+		}
+		for _, filename := range slices.Sorted(maps.Keys(files)) {
+			if !reachable[filename] {
+				c.WarningfAt(files[filename], "file is not reachable from any root file")
+			}
+		}
+	})
+}
+
+// IncludeGraph is the graph of the files that are reachable through includes
+// from a set of root files.
+type IncludeGraph struct {
+	// Includes maps each reachable file to the paths of the files that it
+	// includes. Includes that can't be found are omitted.
+	Includes map[string][]string
+	// Components lists the groups of files whose includes lead back to each
+	// other (the graph's strongly connected components that have cycles),
+	// with each group sorted.
+	Components [][]string
+	// Failed maps the reachable files that couldn't be read or parsed to
+	// their errors.
+	Failed map[string]error
+}
+
+// NewIncludeGraph parses the root files and everything that they include,
+// directly or indirectly. Each file's includes are resolved relative to its
+// own directory and then to each of dirs.
+func NewIncludeGraph(roots, dirs []string) IncludeGraph {
+	g := make(includeGraph)
+	cleaned := make([]string, len(roots))
+	for i, root := range roots {
+		cleaned[i] = filepath.Clean(root)
+	}
+	_, failed := g.reachable(cleaned, dirs, func(filename string) (*ast.Program, error) {
+		p, _, err := thriftcheck.ParseFile(filename, []string{"."})
+		return p, err
+	})
+	return IncludeGraph{Includes: g, Components: g.components(), Failed: failed}
+}
+
+// includeGraph maps (cleaned) filenames to the paths of the files that they
+// include. Includes that can't be found are omitted.
+type includeGraph map[string][]string
+
+// add records the includes of a parsed program.
+func (g includeGraph) add(filename string, p *ast.Program, dirs []string) {
+	includes := []string{}
+	for _, h := range p.Headers {
+		if i, ok := h.(*ast.Include); ok {
+			if path, ok := thriftcheck.FindFile(i.Path, dirs); ok {
+				includes = append(includes, path)
+			}
+		}
+	}
+	g[filename] = includes
+}
+
+// components returns the graph's strongly connected components that contain
+// a cycle: those with more than one file, and single files that include
+// themselves. They're found using Tarjan's algorithm, so this takes linear
+// time. Each component is sorted, and components are ordered by their first
+// files.
+func (g includeGraph) components() [][]string {
+	vertices := make(map[string]bool)
+	for filename, includes := range g {
+		vertices[filename] = true
+		for _, path := range includes {
+			vertices[path] = true
+		}
+	}
+
+	index := make(map[string]int)
+	low := make(map[string]int)
+	onStack := make(map[string]bool)
+	var stack []string
+	var components [][]string
+
+	var connect func(filename string)
+	connect = func(filename string) {
+		index[filename] = len(index)
+		low[filename] = index[filename]
+		stack = append(stack, filename)
+		onStack[filename] = true
+		for _, next := range g[filename] {
+			if _, ok := index[next]; !ok {
+				connect(next)
+				low[filename] = min(low[filename], low[next])
+			} else if onStack[next] {
+				low[filename] = min(low[filename], index[next])
+			}
+		}
+		if low[filename] != index[filename] {
+			return
+		}
+
+		i := slices.Index(stack, filename)
+		component := slices.Sorted(slices.Values(stack[i:]))
+		for _, f := range component {
+			onStack[f] = false
+		}
+		stack = stack[:i]
+		if len(component) > 1 || slices.Contains(g[filename], filename) {
+			components = append(components, component)
+		}
+	}
+
+	for _, filename := range slices.Sorted(maps.Keys(vertices)) {
+		if _, ok := index[filename]; !ok {
+			connect(filename)
+		}
+	}
+	slices.SortFunc(components, func(a, b []string) int { return strings.Compare(a[0], b[0]) })
+	return components
+}
+
+// cycles returns a set of cycles that covers every include within each of the
+// graph's strongly connected components. Each cycle is a list of files where
+// each file includes the next one, and the last file includes the first. For
+// each include that isn't already part of a returned cycle, the shortest
+// cycle through it is added, unless a cycle over the same set of files was
+// already returned. This reports independent cycles (and cycles that only
+// share some of their files) separately while staying polynomial, even for
+// densely connected files, where listing every elementary cycle isn't. Files
+// and their includes are visited in sorted order, and each cycle starts with
+// its smallest filename, so that the results are the same on every run.
+func (g includeGraph) cycles() [][]string {
+	var cycles [][]string
+	seen := make(map[string]bool)
+	for _, component := range g.components() {
+		members := make(map[string]bool, len(component))
+		for _, filename := range component {
+			members[filename] = true
+		}
+
+		covered := make(map[[2]string]bool)
+		for _, from := range component {
+			for _, to := range slices.Compact(slices.Sorted(slices.Values(g[from]))) {
+				if !members[to] || covered[[2]string{from, to}] {
+					continue
+				}
+				cycle := append([]string{from}, g.shortestPath(to, from, members)...)
+				cycle = cycle[:len(cycle)-1]
+				for i, filename := range cycle {
+					covered[[2]string{filename, cycle[(i+1)%len(cycle)]}] = true
+				}
+
+				key := strings.Join(slices.Sorted(slices.Values(cycle)), "\x00")
+				if seen[key] {
+					continue
+				}
+				seen[key] = true
+				i := slices.Index(cycle, slices.Min(cycle))
+				cycles = append(cycles, append(cycle[i:], cycle[:i]...))
+			}
+		}
+	}
+	slices.SortFunc(cycles, slices.Compare)
+	return cycles
+}
+
+// shortestPath returns the shortest chain of includes from one file to
+// another (including both of them), only passing through the given files,
+// which must connect them.
+func (g includeGraph) shortestPath(from, to string, members map[string]bool) []string {
+	if from == to {
+		return []string{from}
+	}
+
+	prev := map[string]string{from: ""}
+	queue := []string{from}
+	for len(queue) > 0 {
+		filename := queue[0]
+		queue = queue[1:]
+		for _, next := range slices.Sorted(slices.Values(g[filename])) {
+			if _, ok := prev[next]; ok || !members[next] {
+				continue
+			}
+			prev[next] = filename
+			if next == to {
+				queue = nil
+				break
+			}
+			queue = append(queue, next)
+		}
+	}
+
+	path := []string{to}
+	for filename := to; filename != from; {
+		filename = prev[filename]
+		path = append(path, filename)
+	}
+	slices.Reverse(path)
+	return path
+}
+
+// reachable returns the set of files reachable from the given roots,
+// including the roots themselves. Files that aren't already in the graph are
+// parsed using parse (and added to it) as they're discovered, with their own
+// directory and then dirs used to resolve their includes. Files that can't be
+// read or parsed are returned along with their errors rather than aborting
+// the walk.
+func (g includeGraph) reachable(roots []string, dirs []string, parse func(filename string) (*ast.Program, error)) (map[string]bool, map[string]error) {
+	seen := make(map[string]bool)
+	failed := make(map[string]error)
+	queue := slices.Clone(roots)
+	for len(queue) > 0 {
+		filename := queue[0]
+		queue = queue[1:]
+		if seen[filename] {
+			continue
+		}
+		seen[filename] = true
+
+		if _, ok := g[filename]; !ok {
+			g[filename] = nil
+			p, err := parse(filename)
+			if err != nil {
+				failed[filename] = err
+				continue
+			}
+			g.add(filename, p, append([]string{filepath.Dir(filename)}, dirs...))
+		}
+		queue = append(queue, g[filename]...)
+	}
+	return seen, failed
+}
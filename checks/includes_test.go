@@ -15,9 +15,18 @@
 package checks_test
 
 import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"reflect"
 	"regexp"
+	"strings"
 	"testing"
 
+	"github.com/pinterest/thriftcheck"
 	"github.com/pinterest/thriftcheck/checks"
 	"go.uber.org/thriftrw/ast"
 )
@@ -85,3 +94,556 @@ func TestCheckIncludeRestricted(t *testing.T) {
 	})
 	RunTests(t, &check, tests)
 }
+
+func TestCheckIncludeSeparator(t *testing.T) {
+	tests := []Test{
+		{
+			node: &ast.Include{Path: "shared/types.thrift"},
+			want: []string{},
+		},
+		{
+			node: &ast.Include{Path: `shared\types.thrift`},
+			want: []string{
+				`t.thrift:0:1: error: include path "shared\\types.thrift" must use forward slashes (include.separator)`,
+			},
+		},
+	}
+
+	check := checks.CheckIncludeSeparator()
+	RunTests(t, &check, tests)
+}
+
+func TestCheckNarrowerInclude(t *testing.T) {
+	tests := []MultiFileTest{
+		{
+			files: map[string]string{
+				"a.thrift": "include \"b.thrift\"\nstruct A {\n  1: optional b.Id id\n  2: optional b.Id other\n}",
+				"b.thrift": "include \"c.thrift\"\ntypedef c.Id Id\nstruct B {}",
+				"c.thrift": "typedef i64 Id",
+			},
+			want: []string{
+				`a.thrift:1:1: warning: include "b.thrift" is only used for "b.Id", which is defined in "c.thrift"; consider including that file directly (include.narrower)`,
+			},
+		},
+		{
+			files: map[string]string{
+				"a.thrift": "include \"b.thrift\"\nstruct A {\n  1: optional b.Id id\n  2: optional b.B b\n}",
+				"b.thrift": "include \"c.thrift\"\ntypedef c.Id Id\nstruct B {}",
+				"c.thrift": "typedef i64 Id",
+			},
+			want: []string{},
+		},
+		{
+			files: map[string]string{
+				"a.thrift": "include \"b.thrift\"\nstruct A {\n  1: optional b.B b\n}",
+				"b.thrift": "struct B {}",
+			},
+			want: []string{},
+		},
+		{
+			// Typedefs are followed through files that aren't linted, to the
+			// file that defines the underlying type.
+			files: map[string]string{
+				"a.thrift": "include \"b.thrift\"\nconst b.Id ID = 1",
+			},
+			unlinted: map[string]string{
+				"b.thrift": "include \"c.thrift\"\ntypedef c.Id Id",
+				"c.thrift": "include \"d.thrift\"\ntypedef d.Id Id",
+				"d.thrift": "typedef i64 Id",
+			},
+			want: []string{
+				`a.thrift:1:1: warning: include "b.thrift" is only used for "b.Id", which is defined in "d.thrift"; consider including that file directly (include.narrower)`,
+			},
+		},
+		{
+			files: map[string]string{
+				"a.thrift": "include \"b.thrift\"\nconst b.Id ID = 1",
+				"b.thrift": "typedef Local Id\ntypedef i64 Local",
+			},
+			want: []string{},
+		},
+	}
+
+	check := checks.CheckNarrowerInclude()
+	RunMultiFileTests(t, &check, tests)
+}
+
+func TestCheckUnusedInclude(t *testing.T) {
+	tests := []MultiFileTest{
+		{
+			files: map[string]string{
+				"a.thrift": "include \"b.thrift\"\ninclude \"c.thrift\"\nstruct A {\n  1: optional b.B b\n}",
+				"b.thrift": "struct B {}",
+				"c.thrift": "struct C {}",
+			},
+			want: []string{
+				`a.thrift:2:1: warning: include "c.thrift" is unused (include.unused)`,
+			},
+		},
+		{
+			files: map[string]string{
+				"a.thrift": "include \"b.thrift\"\nconst list<i32> VALUES = [1, b.MAX]",
+				"b.thrift": "const i32 MAX = 10",
+			},
+			want: []string{},
+		},
+		{
+			files: map[string]string{
+				"a.thrift":        "include \"nested/b.thrift\"\nservice A extends b.B {}",
+				"nested/b.thrift": "service B {}",
+			},
+			want: []string{},
+		},
+	}
+
+	check := checks.CheckUnusedInclude()
+	RunMultiFileTests(t, &check, tests)
+}
+
+func TestCheckOrphanFiles(t *testing.T) {
+	tests := []MultiFileTest{
+		{
+			files: map[string]string{
+				"root.thrift":       `include "a.thrift"`,
+				"a.thrift":          `include "nested/b.thrift"`,
+				"nested/b.thrift":   `struct B {}`,
+				"orphan.thrift":     `include "a.thrift"`,
+				"nested/end.thrift": `struct End {}`,
+			},
+			want: []string{
+				`nested/end.thrift:0:1: warning: file is not reachable from any root file (file.orphan)`,
+				`orphan.thrift:0:1: warning: file is not reachable from any root file (file.orphan)`,
+			},
+		},
+		{
+			files: map[string]string{
+				"root.thrift":         `struct Root {}`,
+				"other.thrift":        `struct Other {}`,
+				"service.root.thrift": `include "other.thrift"`,
+			},
+			want: []string{},
+		},
+	}
+
+	check := checks.CheckOrphanFiles([]string{"*/root.thrift", "*.root.thrift"})
+	RunMultiFileTests(t, &check, tests)
+
+	check = checks.CheckOrphanFiles(nil)
+	RunMultiFileTests(t, &check, []MultiFileTest{
+		{files: map[string]string{"orphan.thrift": `struct S {}`}, want: []string{}},
+	})
+}
+
+func TestCheckOrphanFilesUnreadableInclude(t *testing.T) {
+	dir := t.TempDir()
+	root := filepath.Join(dir, "root.thrift")
+	files := map[string]string{
+		root:                                `include "broken.thrift"`,
+		filepath.Join(dir, "broken.thrift"): `struct {`,
+	}
+	for filename, content := range files {
+		if err := os.WriteFile(filename, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	linter := thriftcheck.NewLinter(thriftcheck.Checks{checks.CheckOrphanFiles([]string{"*/root.thrift"})})
+	msgs, err := linter.LintFiles([]string{root})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(msgs) != 1 || msgs[0].Severity != thriftcheck.Warning || msgs[0].Filename != filepath.Join(dir, "broken.thrift") {
+		t.Errorf("expected a warning for broken.thrift, got %v", msgs)
+	}
+
+	// Files that can't be read are read using the linter's ParseOptions,
+	// and they're reported once, by the linter.
+	read := thriftcheck.WithReadFile(func(name string) ([]byte, error) {
+		if filepath.Base(name) == "broken.thrift" {
+			return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrPermission}
+		}
+		return os.ReadFile(name)
+	})
+	linter = thriftcheck.NewLinter(thriftcheck.Checks{checks.CheckOrphanFiles([]string{"*/root.thrift"})}, thriftcheck.WithParseOptions(read))
+	msgs, err = linter.LintFiles([]string{root})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(msgs) != 1 || msgs[0].Check != "include.unreadable" || msgs[0].Filename != filepath.Join(dir, "broken.thrift") {
+		t.Errorf("expected an include.unreadable warning for broken.thrift, got %v", msgs)
+	}
+}
+
+func TestCheckCircularImport(t *testing.T) {
+	tests := []MultiFileTest{
+		{
+			files: map[string]string{
+				"a.thrift":        `include "b.thrift"`,
+				"b.thrift":        `include "nested/c.thrift"`,
+				"nested/c.thrift": `struct C {}`,
+			},
+			want: []string{},
+		},
+		{
+			files: map[string]string{
+				"a.thrift": `include "b.thrift"`,
+				"b.thrift": "include \"a.thrift\"\nstruct B {}",
+			},
+			want: []string{
+				`a.thrift:1:1: error: circular import: a.thrift -> b.thrift -> a.thrift (import.cycle.disallowed)`,
+			},
+		},
+		{
+			files: map[string]string{
+				"a.thrift":        `include "nested/b.thrift"`,
+				"nested/b.thrift": `include "c.thrift"`,
+				"nested/c.thrift": `include "../a.thrift"`,
+				"self.thrift":     `include "self.thrift"`,
+			},
+			want: []string{
+				`a.thrift:1:1: error: circular import: a.thrift -> nested/b.thrift -> nested/c.thrift -> a.thrift (import.cycle.disallowed)`,
+				`self.thrift:1:1: error: circular import: self.thrift -> self.thrift (import.cycle.disallowed)`,
+			},
+		},
+	}
+
+	check := checks.CheckCircularImport()
+	RunMultiFileTests(t, &check, tests)
+}
+
+func TestCheckCircularImportResolverError(t *testing.T) {
+	tests := []MultiFileTest{
+		{
+			files: map[string]string{
+				"a.thrift": `include "b.thrift"`,
+				"b.thrift": `include "a.thrift"`,
+			},
+			want: []string{
+				`a.thrift:1:1: warning: couldn't normalize the path "b.thrift", so circular imports through it may be missed: getwd: no such file or directory (import.cycle.disallowed)`,
+				`b.thrift:1:1: warning: couldn't normalize the path "b.thrift", so circular imports through it may be missed: getwd: no such file or directory (import.cycle.disallowed)`,
+				`a.thrift:1:1: error: circular import: a.thrift -> b.thrift -> a.thrift (import.cycle.disallowed)`,
+			},
+		},
+	}
+
+	check := checks.CheckCircularImportResolver(func(path string) (string, error) {
+		if filepath.Base(path) == "b.thrift" {
+			return "", errors.New("getwd: no such file or directory")
+		}
+		return filepath.Abs(path)
+	})
+	RunMultiFileTests(t, &check, tests)
+}
+
+func TestCheckCircularImportMultipleCycles(t *testing.T) {
+	tests := []MultiFileTest{
+		{
+			files: map[string]string{
+				"a.thrift": `include "b.thrift"`,
+				"b.thrift": `include "a.thrift"`,
+				"c.thrift": `include "d.thrift"`,
+				"d.thrift": `include "c.thrift"`,
+			},
+			want: []string{
+				`a.thrift:1:1: error: circular import: a.thrift -> b.thrift -> a.thrift (import.cycle.disallowed)`,
+				`c.thrift:1:1: error: circular import: c.thrift -> d.thrift -> c.thrift (import.cycle.disallowed)`,
+			},
+		},
+		{
+			// Both cycles share a.thrift.
+			files: map[string]string{
+				"a.thrift": "include \"b.thrift\"\ninclude \"c.thrift\"",
+				"b.thrift": `include "a.thrift"`,
+				"c.thrift": `include "a.thrift"`,
+			},
+			want: []string{
+				`a.thrift:1:1: error: circular import: a.thrift -> b.thrift -> a.thrift (import.cycle.disallowed)`,
+				`a.thrift:2:1: error: circular import: a.thrift -> c.thrift -> a.thrift (import.cycle.disallowed)`,
+			},
+		},
+		{
+			// a -> c -> a is only found by revisiting c after a -> b -> c -> a.
+			files: map[string]string{
+				"a.thrift": "include \"b.thrift\"\ninclude \"c.thrift\"",
+				"b.thrift": `include "c.thrift"`,
+				"c.thrift": "include \"a.thrift\"\ninclude \"b.thrift\"",
+			},
+			want: []string{
+				`a.thrift:1:1: error: circular import: a.thrift -> b.thrift -> c.thrift -> a.thrift (import.cycle.disallowed)`,
+				`a.thrift:2:1: error: circular import: a.thrift -> c.thrift -> a.thrift (import.cycle.disallowed)`,
+				`b.thrift:1:1: error: circular import: b.thrift -> c.thrift -> b.thrift (import.cycle.disallowed)`,
+			},
+		},
+		{
+			// Every include is part of one of the shorter cycles, so the
+			// longer ones (like a -> b -> c -> a) aren't also reported.
+			files: map[string]string{
+				"a.thrift": "include \"b.thrift\"\ninclude \"c.thrift\"",
+				"b.thrift": "include \"a.thrift\"\ninclude \"c.thrift\"",
+				"c.thrift": "include \"a.thrift\"\ninclude \"b.thrift\"",
+			},
+			want: []string{
+				`a.thrift:1:1: error: circular import: a.thrift -> b.thrift -> a.thrift (import.cycle.disallowed)`,
+				`a.thrift:2:1: error: circular import: a.thrift -> c.thrift -> a.thrift (import.cycle.disallowed)`,
+				`b.thrift:2:1: error: circular import: b.thrift -> c.thrift -> b.thrift (import.cycle.disallowed)`,
+			},
+		},
+	}
+
+	check := checks.CheckCircularImport()
+	RunMultiFileTests(t, &check, tests)
+}
+
+func TestCheckCircularImportDenseGraph(t *testing.T) {
+	// Each file includes the next few, so the number of elementary cycles
+	// grows exponentially with the number of files. Each include is reported
+	// as part of at most one cycle.
+	const files, includes = 40, 4
+	dir := t.TempDir()
+	var filenames []string
+	for i := range files {
+		var src strings.Builder
+		for j := 1; j <= includes; j++ {
+			fmt.Fprintf(&src, "include \"f%d.thrift\"\n", (i+j)%files)
+		}
+		filename := filepath.Join(dir, fmt.Sprintf("f%d.thrift", i))
+		if err := os.WriteFile(filename, []byte(src.String()), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		filenames = append(filenames, filename)
+	}
+
+	linter := thriftcheck.NewLinter(thriftcheck.Checks{checks.CheckCircularImport()})
+	msgs, err := linter.LintFiles(filenames)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(msgs) == 0 || len(msgs) > files*includes {
+		t.Errorf("expected between 1 and %d cycles, got %d", files*includes, len(msgs))
+	}
+}
+
+func TestCheckCircularImportDeterministic(t *testing.T) {
+	tests := []MultiFileTest{
+		{
+			files: map[string]string{
+				"z.thrift": `include "m.thrift"`,
+				"m.thrift": `include "b.thrift"`,
+				"b.thrift": `include "z.thrift"`,
+				"y.thrift": "include \"x.thrift\"\ninclude \"c.thrift\"",
+				"x.thrift": `include "y.thrift"`,
+				"c.thrift": `include "y.thrift"`,
+			},
+			want: []string{
+				`b.thrift:1:1: error: circular import: b.thrift -> z.thrift -> m.thrift -> b.thrift (import.cycle.disallowed)`,
+				`c.thrift:1:1: error: circular import: c.thrift -> y.thrift -> c.thrift (import.cycle.disallowed)`,
+				`x.thrift:1:1: error: circular import: x.thrift -> y.thrift -> x.thrift (import.cycle.disallowed)`,
+			},
+		},
+	}
+
+	// Each cycle starts from its smallest filename on every run, regardless
+	// of map iteration order.
+	check := checks.CheckCircularImport()
+	for range 20 {
+		RunMultiFileTests(t, &check, tests)
+	}
+}
+
+func TestCheckCircularImportConcurrent(t *testing.T) {
+	// Each file includes the next, and every tenth file closes a cycle.
+	dir := t.TempDir()
+	var filenames []string
+	for i := range 40 {
+		next := i + 1
+		if next%10 == 0 {
+			next -= 10
+		}
+		src := fmt.Sprintf("include \"%02d.thrift\"\nstruct S%d {\n  1: optional i32 a\n}", next, i)
+		filename := filepath.Join(dir, fmt.Sprintf("%02d.thrift", i))
+		if err := os.WriteFile(filename, []byte(src), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		filenames = append(filenames, filename)
+	}
+
+	lint := func(jobs int) []string {
+		linter := thriftcheck.NewLinter(thriftcheck.Checks{
+			checks.CheckFieldDocMissing(),
+			checks.CheckCircularImport(),
+		}, thriftcheck.WithJobs(jobs))
+		msgs, err := linter.LintFiles(filenames)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		strings := make([]string, len(msgs))
+		for i, m := range msgs {
+			strings[i] = m.String()
+		}
+		return strings
+	}
+
+	want := lint(1)
+	if len(want) != 40+4 {
+		t.Fatalf("expected %d messages, got %v", 40+4, want)
+	}
+	for range 5 {
+		if got := lint(8); !reflect.DeepEqual(got, want) {
+			t.Errorf("- %v\n+ %v", want, got)
+		}
+	}
+}
+
+func TestCheckCircularImportNoIncludes(t *testing.T) {
+	var out strings.Builder
+	linter := thriftcheck.NewLinter(thriftcheck.Checks{
+		checks.CheckCircularImport(),
+	}, thriftcheck.WithLogger(log.New(&out, "", 0)))
+
+	msgs, err := linter.Lint(strings.NewReader("struct S {}"), "t.thrift")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(msgs) != 0 {
+		t.Errorf("expected no messages, got %v", msgs)
+	}
+	want := "multi-file check import.cycle.disallowed wasn't called for any nodes"
+	if !strings.Contains(out.String(), want) {
+		t.Errorf("expected the log to contain %q, got:\n%s", want, out.String())
+	}
+
+	// A check that wasn't run on any files at all is reported.
+	msgs, err = linter.LintFiles(nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(msgs) != 1 || msgs[0].Check != "multifile.unused" || msgs[0].Severity != thriftcheck.Warning {
+		t.Errorf("expected a multifile.unused warning, got %v", msgs)
+	}
+}
+
+func TestCheckCircularImportSpellings(t *testing.T) {
+	tests := []MultiFileTest{
+		{
+			files: map[string]string{
+				"a.thrift":     `include "./b.thrift"`,
+				"b.thrift":     "include \"a.thrift\"\ninclude \"sub/../c.thrift\"",
+				"c.thrift":     `include "sub/./../b.thrift"`,
+				"sub/x.thrift": "",
+			},
+			want: []string{
+				`a.thrift:1:1: error: circular import: a.thrift -> b.thrift -> a.thrift (import.cycle.disallowed)`,
+				`b.thrift:2:1: error: circular import: b.thrift -> c.thrift -> b.thrift (import.cycle.disallowed)`,
+			},
+		},
+	}
+
+	// Each file is a single vertex in the include graph, however it's
+	// spelled by the files that include it.
+	check := checks.CheckCircularImport()
+	RunMultiFileTests(t, &check, tests)
+}
+
+func BenchmarkCheckCircularImport(b *testing.B) {
+	dir := b.TempDir()
+	var filenames []string
+	// Most of the files include the same ten shared files.
+	for i := range 100 {
+		var src strings.Builder
+		if i >= 10 {
+			for j := range 10 {
+				fmt.Fprintf(&src, "include \"./%02d.thrift\"\n", j)
+			}
+		}
+		filename := filepath.Join(dir, fmt.Sprintf("%02d.thrift", i))
+		if err := os.WriteFile(filename, []byte(src.String()), 0o644); err != nil {
+			b.Fatal(err)
+		}
+		filenames = append(filenames, filename)
+	}
+
+	linter := thriftcheck.NewLinter(thriftcheck.Checks{checks.CheckCircularImport()})
+	for range b.N {
+		if _, err := linter.LintFiles(filenames); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestCheckCircularImportIncludeDirs(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"gen-src/common/types.thrift": `include "shared/base.thrift"`,
+		"vendor/shared/base.thrift":   `include "common/types.thrift"`,
+	}
+	for name, content := range files {
+		filename := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(filename), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filename, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// The include directories are relative to the working directory, but
+	// the linted files are named by their absolute paths.
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(wd)
+
+	linter := thriftcheck.NewLinter(thriftcheck.Checks{checks.CheckCircularImport()},
+		thriftcheck.WithIncludes([]string{"gen-src", "vendor"}))
+	msgs, err := linter.LintFiles([]string{
+		filepath.Join(dir, "gen-src/common/types.thrift"),
+		filepath.Join(dir, "vendor/shared/base.thrift"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{
+		`gen-src/common/types.thrift:1:1: error: circular import: gen-src/common/types.thrift -> vendor/shared/base.thrift -> gen-src/common/types.thrift (import.cycle.disallowed)`,
+	}
+	got := make([]string, len(msgs))
+	for i, m := range msgs {
+		got[i] = strings.ReplaceAll(m.String(), dir+string(filepath.Separator), "")
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("- %v\n+ %v", want, got)
+	}
+}
+
+func TestCheckCircularImportLaterInclude(t *testing.T) {
+	tests := []MultiFileTest{
+		{
+			files: map[string]string{
+				"a.thrift": "include \"b.thrift\"\ninclude \"c.thrift\"",
+				"b.thrift": `struct B {}`,
+				"c.thrift": `include "a.thrift"`,
+			},
+			want: []string{
+				`a.thrift:2:1: error: circular import: a.thrift -> c.thrift -> a.thrift (import.cycle.disallowed)`,
+			},
+		},
+		{
+			files: map[string]string{
+				"a.thrift": "include \"b.thrift\"\ninclude \"c.thrift\"",
+				"b.thrift": `struct B {}`,
+				"c.thrift": "include \"b.thrift\"\ninclude \"d.thrift\"",
+				"d.thrift": `include "c.thrift"`,
+			},
+			want: []string{
+				`c.thrift:2:1: error: circular import: c.thrift -> d.thrift -> c.thrift (import.cycle.disallowed)`,
+			},
+		},
+	}
+
+	check := checks.CheckCircularImport()
+	RunMultiFileTests(t, &check, tests)
+}
@@ -0,0 +1,687 @@
+// Copyright 2026 Pinterest
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checks
+
+import (
+	"github.com/pinterest/thriftcheck"
+)
+
+// newCheck creates a new thriftcheck.Check with its descriptive CheckInfo.
+func newCheck(name string, fn any) thriftcheck.Check {
+	check := thriftcheck.NewCheck(name, fn)
+	check.Info = checkInfo[name]
+	return check
+}
+
+// newMultiFileCheck creates a new multi-file thriftcheck.Check with its
+// descriptive CheckInfo.
+func newMultiFileCheck(name string, fn any, finalize func(*thriftcheck.C)) thriftcheck.Check {
+	check := thriftcheck.NewMultiFileCheck(name, fn, finalize)
+	check.Info = checkInfo[name]
+	return check
+}
+
+// checkInfo describes each of the built-in checks, keyed by name. Examples
+// that depend on configuration assume the values in cmd/example.toml, and
+// examples that span multiple files introduce each one with a
+// "// filename.thrift" line. `thriftcheck selftest` verifies all of them.
+var checkInfo = map[string]thriftcheck.CheckInfo{
+	"annotation.conflict": {
+		Description: "Reports an error if a node carries two annotations that are configured as conflicting.",
+		Severity:    thriftcheck.Error,
+		Rationale:   "Contradictory annotations leave readers and code generators guessing which one was intended.",
+		Bad:         "struct S {\n    /** @required @optional */\n    1: optional string name\n}",
+		Good:        "struct S {\n    /** @optional */\n    1: optional string name\n}",
+	},
+	"const.divergence": {
+		Description: "Warns if same-named constants matching a configured pattern have different values in different files.",
+		Severity:    thriftcheck.Warning,
+		Rationale:   "Copies of a shared allowlist or setting drift apart over time, and services reading different copies quietly disagree.",
+		Bad:         "// a.thrift\nconst set<string> ALLOWED_HOSTS = [\"a.example.com\", \"b.example.com\"]\n\n// b.thrift\nconst set<string> ALLOWED_HOSTS = [\"a.example.com\"]",
+		Good:        "// a.thrift\nconst set<string> ALLOWED_HOSTS = [\"a.example.com\", \"b.example.com\"]\n\n// b.thrift\nconst set<string> ALLOWED_HOSTS = [\"b.example.com\", \"a.example.com\"]",
+	},
+	"const.enum.qualified": {
+		Description: "Warns if an enum-typed constant's value is an unqualified identifier rather than a qualified enum item.",
+		Severity:    thriftcheck.Warning,
+		Rationale:   "Qualified references like `Status.ACTIVE` make it clear which enum a value comes from, and not all generators accept bare item names.",
+		Bad:         "enum Status {\n    ACTIVE\n}\n\nconst Status DEFAULT_STATUS = ACTIVE",
+		Good:        "enum Status {\n    ACTIVE\n}\n\nconst Status DEFAULT_STATUS = Status.ACTIVE",
+	},
+	"const.i64.jsunsafe": {
+		Description: "Warns when an i64 constant or field default exceeds JavaScript's safe integer range.",
+		Severity:    thriftcheck.Warning,
+		Rationale:   "JavaScript numbers can only represent integers up to 2^53-1 exactly, so larger values silently lose precision in generated JavaScript and TypeScript code.",
+		Bad:         `const i64 VALUE = 9007199254740992`,
+		Good:        `const i64 VALUE = 9007199254740991`,
+	},
+	"const.struct.type": {
+		Description: "Warns if a constant's type is a struct or union.",
+		Severity:    thriftcheck.Warning,
+		Rationale:   "Struct- and union-valued constants are poorly supported across Thrift code generators.",
+		Bad:         "struct Point {\n    1: optional i32 x\n    2: optional i32 y\n}\n\nconst Point ORIGIN = {\"x\": 0, \"y\": 0}",
+		Good:        "const i32 ORIGIN_X = 0\nconst i32 ORIGIN_Y = 0",
+	},
+	"const.unit.typedef": {
+		Description: "Warns if a numeric constant whose name implies a unit doesn't use one of the configured unit typedefs.",
+		Severity:    thriftcheck.Warning,
+		Rationale:   "A unit typedef like `Milliseconds` documents what a value means wherever it's used, where a raw i64 relies on the name alone.",
+		Bad:         "const i64 TIMEOUT_MS = 3600000",
+		Good:        "typedef i64 Milliseconds\n\nconst Milliseconds TIMEOUT_MS = 3600000",
+	},
+	"constant.ref": {
+		Description: "Reports an error if a referenced constant or enum value cannot be found.",
+		Severity:    thriftcheck.Error,
+		Rationale:   "Unresolvable references fail at code generation time.",
+		Bad:         `const i32 VALUE = MISSING`,
+		Good:        "const i32 OTHER = 1\nconst i32 VALUE = OTHER",
+	},
+	"container.repeated.inline": {
+		Description: "Warns if the same container type is used inline by several struct fields instead of through a typedef.",
+		Severity:    thriftcheck.Warning,
+		Rationale:   "A typedef gives a repeated type a name and keeps its uses from drifting apart.",
+		Bad:         "struct A {\n    1: optional map<string, list<i32>> scores\n}\n\nstruct B {\n    1: optional map<string, list<i32>> scores\n}\n\nstruct C {\n    1: optional map<string, list<i32>> scores\n}",
+		Good:        "typedef map<string, list<i32>> Scores\n\nstruct A {\n    1: optional Scores scores\n}\n\nstruct B {\n    1: optional Scores scores\n}\n\nstruct C {\n    1: optional Scores scores\n}",
+	},
+	"definition.order": {
+		Description: "Warns if a service references a type that is defined later in the same file.",
+		Severity:    thriftcheck.Warning,
+		Rationale:   "Files are easier to read from top to bottom when services follow the types they use.",
+		Bad:         "service Users {\n    User getUser(1: i64 id)\n}\n\nstruct User {}",
+		Good:        "struct User {}\n\nservice Users {\n    User getUser(1: i64 id)\n}",
+	},
+	"enum.alias": {
+		Description: "Reports an error if an enumeration item reuses another item's value without an alias annotation.",
+		Severity:    thriftcheck.Error,
+		Rationale:   "Duplicate values are usually mistakes; intentional aliases should say so.",
+		Bad:         "enum State {\n    RUNNING = 1\n    ACTIVE = 1\n}",
+		Good:        "enum State {\n    RUNNING = 1\n    /** @alias */\n    ACTIVE = 1\n}",
+	},
+	"enum.flags.power.of.two": {
+		Description: "Reports an error if a nonzero item of a flags enum isn't a power of two.",
+		Severity:    thriftcheck.Error,
+		Rationale:   "Flags are combined with bitwise operations, so each one needs its own bit.",
+		Bad:         "/** @flags */\nenum Permissions {\n    READ = 1\n    WRITE = 2\n    EXECUTE = 3\n}",
+		Good:        "/** @flags */\nenum Permissions {\n    NONE = 0\n    READ = 1\n    WRITE = 2\n    EXECUTE = 4\n}",
+	},
+	"enum.location": {
+		Description: "Warns if service methods in other files use an enum that isn't defined in a shared types file.",
+		Severity:    thriftcheck.Warning,
+		Rationale:   "Clients shouldn't need to include a service's file just to use the enums in its API.",
+		Bad:         "// users.thrift\nenum Status { ACTIVE, INACTIVE }\n\nservice Users {\n    Status getStatus(1: i64 id)\n}\n\n// admin.thrift\ninclude \"users.thrift\"\n\nservice Admin {\n    void setStatus(1: i64 id, 2: users.Status status)\n}",
+		Good:        "// shared/types.thrift\nenum Status { ACTIVE, INACTIVE }\n// users.thrift\ninclude \"shared/types.thrift\"\n\nservice Users {\n    types.Status getStatus(1: i64 id)\n}",
+	},
+	"enum.member.unused": {
+		Description: "Warns about enumeration items that aren't referenced by any constant or default value.",
+		Severity:    thriftcheck.Warning,
+		Rationale:   "Items that nothing refers to may be left over from removed code, though the check can't see runtime usage.",
+		Bad:         "enum Status {\n    ACTIVE\n    LEGACY\n}\n\nconst Status DEFAULT_STATUS = Status.ACTIVE",
+		Good:        "enum Status {\n    ACTIVE\n}\n\nconst Status DEFAULT_STATUS = Status.ACTIVE",
+	},
+	"enum.members.max": {
+		Description: "Reports an error if an enumeration has more than a maximum number of members.",
+		Severity:    thriftcheck.Error,
+		Rationale:   "Very large enumerations are hard to maintain, and some code generators can't handle them at all.",
+		Bad:         "enum State {\n    STOPPED = 1\n    STARTING = 2\n    RUNNING = 3\n    STOPPING = 4\n    FAILED = 5\n}",
+		Good:        "enum State {\n    STOPPED = 1\n    RUNNING = 2\n}",
+	},
+	"enum.size": {
+		Description: "Warns or errors if an enumeration's element size grows beyond a limit.",
+		Severity:    thriftcheck.Warning,
+		Rationale:   "Very large enumerations are hard to maintain and strain some code generators.",
+		Good:        "enum State {\n    STOPPED = 1\n    RUNNING = 2\n}",
+	},
+	"enum.stability": {
+		Description: "Reports an error if an enumeration drops or renames a value that exists in the baseline version of its file.",
+		Severity:    thriftcheck.Error,
+		Rationale:   "Consumers built against the baseline still send and expect its values, so removing or renumbering them breaks compatibility.",
+		Good:        "enum Status {\n    ACTIVE = 1\n    INACTIVE = 2\n}",
+	},
+	"exception.field.duplicate": {
+		Description: "Warns if an exception redeclares a field that the configured base exception already defines.",
+		Severity:    thriftcheck.Warning,
+		Rationale:   "Copied fields drift from the base exception's definitions over time.",
+		Bad:         "// errors.thrift\nexception BaseError {\n    1: optional string message\n}\n// users.thrift\ninclude \"errors.thrift\"\n\nexception NotFound {\n    1: optional string message\n}",
+		Good:        "// errors.thrift\nexception BaseError {\n    1: optional string message\n}\n// users.thrift\ninclude \"errors.thrift\"\n\nexception NotFound {\n    1: optional errors.BaseError base\n}",
+	},
+	"exception.field.required": {
+		Description: "Warns if an exception has a \"required\" field.",
+		Severity:    thriftcheck.Warning,
+		Rationale:   "Exceptions cross service boundaries heavily, and a required field can never be removed without breaking the callers that still expect it.",
+		Bad:         "exception NotFound {\n    1: required string message\n}",
+		Good:        "exception NotFound {\n    1: optional string message\n}",
+	},
+	"field.bool.naming": {
+		Description: "Warns if a bool field's name doesn't start with a predicate prefix like is_ or has_.",
+		Severity:    thriftcheck.Warning,
+		Rationale:   "Boolean fields that read as assertions make the meaning of true and false obvious.",
+		Bad:         "struct User {\n    1: optional bool active\n}",
+		Good:        "struct User {\n    1: optional bool is_active\n}",
+	},
+	"field.container.default": {
+		Description: "Warns if a list, set, or map field has a non-empty default value.",
+		Severity:    thriftcheck.Warning,
+		Rationale:   "A populated default is easy to miss, and readers and writers that disagree about it see different values for an unset field.",
+		Bad:         "struct S {\n    1: optional list<string> names = [\"root\"]\n}",
+		Good:        "struct S {\n    1: optional list<string> names = []\n}",
+	},
+	"field.container.optional": {
+		Description: "Warns if a list, set, or map field is declared as \"required\".",
+		Severity:    thriftcheck.Warning,
+		Rationale:   "Many runtimes can't distinguish a required container from an empty one.",
+		Bad:         "struct S {\n    1: required list<string> names\n}",
+		Good:        "struct S {\n    1: optional list<string> names\n}",
+	},
+	"field.default.const.undefined": {
+		Description: "Reports an error if a field's default value refers to a constant or enum item that isn't defined.",
+		Severity:    thriftcheck.Error,
+		Rationale:   "A misspelled or removed constant in a default value fails at code generation time, far from the field that uses it.",
+		Bad:         "struct S {\n    1: optional i32 limit = DEFAULT_LIMIT\n}",
+		Good:        "const i32 DEFAULT_LIMIT = 10\n\nstruct S {\n    1: optional i32 limit = DEFAULT_LIMIT\n}",
+	},
+	"field.doc.missing": {
+		Description: "Warns if a field is missing a documentation comment.",
+		Severity:    thriftcheck.Warning,
+		Rationale:   "Field documentation is the primary reference for consumers of an API.",
+		Bad:         "struct User {\n    1: optional string name\n}",
+		Good:        "struct User {\n    /** The user's display name. */\n    1: optional string name\n}",
+	},
+	"field.doc.type.mismatch": {
+		Description: "Warns if a field's documentation suggests a different type than the one it has.",
+		Severity:    thriftcheck.Warning,
+		Rationale:   "A field whose type contradicts its documentation is likely to be misused by clients.",
+		Bad:         "struct Event {\n    /** The event's timestamp in milliseconds. */\n    1: optional string created\n}",
+		Good:        "struct Event {\n    /** The event's timestamp in milliseconds. */\n    1: optional i64 created\n}",
+	},
+	"field.id.duplicate": {
+		Description: "Reports an error if two of a struct's fields use the same field ID.",
+		Severity:    thriftcheck.Error,
+		Rationale:   "Field IDs identify fields on the wire, so a reused ID makes the two fields indistinguishable.",
+		Bad:         "struct User {\n    1: optional string name\n    1: optional string email\n}",
+		Good:        "struct User {\n    1: optional string name\n    2: optional string email\n}",
+	},
+	"field.id.first": {
+		Description: "Warns if a struct's lowest explicit field ID isn't 1.",
+		Severity:    thriftcheck.Warning,
+		Rationale:   "Consistently numbering fields from 1 makes structs easier to read and extend.",
+		Bad:         "struct User {\n    2: optional string name\n}",
+		Good:        "struct User {\n    1: optional string name\n}",
+	},
+	"field.id.gap": {
+		Description: "Warns if consecutive field IDs are closer together than a configured step.",
+		Severity:    thriftcheck.Warning,
+		Rationale:   "Leaving gaps between field IDs (e.g. 10, 20, 30) makes room to insert related fields next to each other later.",
+		Good:        "struct User {\n    10: optional i64 id\n    20: optional string name\n}",
+	},
+	"field.id.length.bound": {
+		Description: "Warns if a string or binary ID field doesn't have a maxlen annotation.",
+		Severity:    thriftcheck.Warning,
+		Rationale:   "Unbounded identifiers let callers send arbitrarily large payloads that have to be stored and indexed.",
+		Bad:         "struct User {\n    1: optional string user_id\n}",
+		Good:        "struct User {\n    /** @maxlen(64) */\n    1: optional string user_id\n}",
+	},
+	"field.id.missing": {
+		Description: "Reports an error if a field's ID is missing.",
+		Severity:    thriftcheck.Error,
+		Rationale:   "Implicitly assigned field IDs change when fields are reordered, which breaks wire compatibility.",
+		Bad:         "struct User {\n    optional string name\n}",
+		Good:        "struct User {\n    1: optional string name\n}",
+	},
+	"field.id.negative": {
+		Description: "Reports an error if a field's ID is explicitly negative.",
+		Severity:    thriftcheck.Error,
+		Rationale:   "Negative field IDs are reserved for implicitly assigned IDs and require special compiler options.",
+		Bad:         "struct User {\n    -1: optional string name\n}",
+		Good:        "struct User {\n    1: optional string name\n}",
+	},
+	"field.id.width.consistent": {
+		Description: "Warns if a struct's ID fields don't all use the same integer width.",
+		Severity:    thriftcheck.Warning,
+		Rationale:   "Related identifiers (e.g. a parent and child ID) that use different widths are usually a mistake and can truncate values.",
+		Bad:         "struct Edge {\n    1: optional i64 parent_id\n    2: optional i32 child_id\n}",
+		Good:        "struct Edge {\n    1: optional i64 parent_id\n    2: optional i64 child_id\n}",
+	},
+	"field.id.zero": {
+		Description: "Reports an error if a field's ID is explicitly zero.",
+		Severity:    thriftcheck.Error,
+		Rationale:   "A zero field ID is generally unsupported by the Apache Thrift compiler.",
+		Bad:         "struct User {\n    0: optional string name\n}",
+		Good:        "struct User {\n    1: optional string name\n}",
+	},
+	"field.name.case.collision": {
+		Description: "Reports an error if two of a struct's fields have names that differ only in case.",
+		Severity:    thriftcheck.Error,
+		Rationale:   "Fields that differ only in case collide in case-insensitive languages and serialization formats.",
+		Bad:         "struct User {\n    1: optional i64 userId\n    2: optional i64 userid\n}",
+		Good:        "struct User {\n    1: optional i64 userId\n    2: optional i64 parentUserId\n}",
+	},
+	"field.name.type.redundant": {
+		Description: "Warns if a field's name starts or ends with a word that names its type, like `count_int` or `user_struct`.",
+		Severity:    thriftcheck.Warning,
+		Rationale:   "The type is already part of the field's declaration, and the name goes stale if the type ever changes.",
+		Bad:         "struct Stats {\n    1: optional i32 count_int\n}",
+		Good:        "struct Stats {\n    1: optional i32 count\n}",
+	},
+	"field.optional": {
+		Description: `Warns if a field isn't declared as "optional".`,
+		Severity:    thriftcheck.Warning,
+		Rationale:   "Optional fields can be safely removed later, which is considered a best practice.",
+		Bad:         "struct User {\n    1: required string name\n}",
+		Good:        "struct User {\n    1: optional string name\n}",
+	},
+	"field.optional.doc": {
+		Description: "Warns if an optional field's documentation doesn't describe what it means when the field is absent.",
+		Severity:    thriftcheck.Warning,
+		Rationale:   "Clients need to know whether an absent field means \"unknown\", \"not applicable\", or a default value.",
+		Bad:         "struct User {\n    /** The user's nickname. */\n    1: optional string nickname\n}",
+		Good:        "struct User {\n    /** The user's nickname, or unset if they haven't chosen one. */\n    1: optional string nickname\n}",
+	},
+	"field.pii.annotation": {
+		Description: "Reports an error if a field that appears to contain PII isn't annotated with `pii`.",
+		Severity:    thriftcheck.Error,
+		Rationale:   "Compliance tooling relies on the annotation to find and protect personally identifiable information.",
+		Bad:         "struct User {\n    1: optional string email\n}",
+		Good:        "struct User {\n    /** @pii */\n    1: optional string email\n}",
+	},
+	"field.required.added": {
+		Description: "Reports an error if a field that isn't in the baseline version of its struct is \"required\".",
+		Severity:    thriftcheck.Error,
+		Rationale:   "Existing writers don't set a newly added field, so making it required breaks them.",
+		Good:        "struct User {\n    1: required i64 id\n    2: optional string name\n}",
+	},
+	"field.requiredness": {
+		Description: `Warns if a field isn't explicitly declared as "required" or "optional".`,
+		Severity:    thriftcheck.Warning,
+		Rationale:   "The default requiredness behaves differently across languages.",
+		Bad:         "struct User {\n    1: string name\n}",
+		Good:        "struct User {\n    1: optional string name\n}",
+	},
+	"field.reserved.prefix": {
+		Description: "Reports an error if a field's name starts with a prefix reserved for generated code, like thrift_ or __.",
+		Severity:    thriftcheck.Error,
+		Rationale:   "Generated scaffolding uses these prefixes, so hand-written fields that share them can collide with it.",
+		Bad:         "struct User {\n    1: optional string thrift_internal\n}",
+		Good:        "struct User {\n    1: optional string internal_note\n}",
+	},
+	"field.small.int": {
+		Description: "Warns if a field is `i16` or `byte` unless its struct carries the `low-level` annotation.",
+		Severity:    thriftcheck.Warning,
+		Rationale:   "Small integer types rarely save space on the wire and are painful to widen once values outgrow them.",
+		Bad:         "struct Endpoint {\n    1: optional i16 port\n}",
+		Good:        "struct Endpoint {\n    1: optional i32 port\n}",
+	},
+	"file.orphan": {
+		Description: "Warns about files that aren't reachable through any chain of includes from a root file.",
+		Severity:    thriftcheck.Warning,
+		Rationale:   "Files that are never included by anything are usually dead code that will rot over time.",
+	},
+	"function.arg.container": {
+		Description: "Warns if a function's only argument is a list, set, or map.",
+		Severity:    thriftcheck.Warning,
+		Rationale:   "A request struct can gain new fields over time, while a bare container argument can't be extended without breaking callers.",
+		Bad:         "service Users {\n    void deleteUsers(1: list<i64> ids)\n}",
+		Good:        "struct DeleteUsersRequest {\n    1: optional list<i64> ids\n}\n\nservice Users {\n    void deleteUsers(1: DeleteUsersRequest request)\n}",
+	},
+	"function.bool.result": {
+		Description: "Warns if a method that appears to mutate state returns bool.",
+		Severity:    thriftcheck.Warning,
+		Rationale:   "A bool can't say why an operation failed or what it changed; a result type (and exceptions for failures) can, and can grow new fields later.",
+		Bad:         "service Users {\n    bool deleteUser(1: i64 id)\n}",
+		Good:        "struct DeleteUserResult {}\n\nservice Users {\n    DeleteUserResult deleteUser(1: i64 id)\n}",
+	},
+	"function.public.named.types": {
+		Description: "Warns if a method of a `public` service uses an inline container or base type for an argument or its return value.",
+		Severity:    thriftcheck.Warning,
+		Rationale:   "Named request and response types can gain fields as a public API evolves, while raw types can't.",
+		Bad:         "/** @public */\nservice Users {\n    string getName(1: i64 id)\n}",
+		Good:        "struct GetNameRequest {\n    1: optional i64 id\n}\n\nstruct GetNameResponse {\n    1: optional string name\n}\n\n/** @public */\nservice Users {\n    GetNameResponse getName(1: GetNameRequest request)\n}",
+	},
+	"function.result.complexity": {
+		Description: "Warns if a function declares more exceptions than a configured limit.",
+		Severity:    thriftcheck.Warning,
+		Rationale:   "Every exception becomes a field of the function's generated result struct, and long throws clauses make results unwieldy for clients.",
+		Bad:         "exception E1 {}\nexception E2 {}\nexception E3 {}\nexception E4 {}\nexception E5 {}\nexception E6 {}\nexception E7 {}\nexception E8 {}\nexception E9 {}\nexception E10 {}\nexception E11 {}\n\nservice S {\n    void f() throws (1: E1 e1, 2: E2 e2, 3: E3 e3, 4: E4 e4, 5: E5 e5, 6: E6 e6, 7: E7 e7, 8: E8 e8, 9: E9 e9, 10: E10 e10, 11: E11 e11)\n}",
+		Good:        "exception NotFound {}\n\nservice Users {\n    string getName(1: i64 id) throws (1: NotFound notFound)\n}",
+	},
+	"function.return.exception": {
+		Description: "Warns if a function returns an exception type as its success value.",
+		Severity:    thriftcheck.Warning,
+		Rationale:   "Exceptions should be thrown (using throws) rather than returned.",
+		Bad:         "exception NotFound {}\nservice Users {\n    NotFound getUser(1: i64 id)\n}",
+		Good:        "struct User {}\nexception NotFound {}\nservice Users {\n    User getUser(1: i64 id) throws (1: NotFound notFound)\n}",
+	},
+	"import.cycle.disallowed": {
+		Description: "Reports an error if a chain of includes leads back to the file where it started.",
+		Severity:    thriftcheck.Error,
+		Rationale:   "Many code generators can't handle circular imports, and they make files impossible to understand in isolation.",
+		Bad:         "// a.thrift\ninclude \"b.thrift\"\n\n// b.thrift\ninclude \"a.thrift\"",
+		Good:        "// a.thrift\ninclude \"b.thrift\"\n\n// b.thrift\nstruct B {}",
+	},
+	"include.narrower": {
+		Description: "Warns if a file uses a single typedef from an included file that re-exports a type from another file.",
+		Severity:    thriftcheck.Warning,
+		Rationale:   "Including the file that actually defines the type narrows the file's dependencies.",
+		Bad:         "// ids.thrift\ntypedef i64 UserId\n// users.thrift\ninclude \"ids.thrift\"\ntypedef ids.UserId UserId\nstruct User {}\n// example.thrift\ninclude \"users.thrift\"\nstruct Post {\n    1: optional users.UserId author\n}",
+		Good:        "// ids.thrift\ntypedef i64 UserId\n// users.thrift\ninclude \"ids.thrift\"\ntypedef ids.UserId UserId\nstruct User {}\n// example.thrift\ninclude \"ids.thrift\"\nstruct Post {\n    1: optional ids.UserId author\n}",
+	},
+	"include.path": {
+		Description: "Reports an error if an included file can't be found in the include paths.",
+		Severity:    thriftcheck.Error,
+		Rationale:   "Missing includes fail at code generation time.",
+		Bad:         `include "missing.thrift"`,
+	},
+	"include.restricted": {
+		Description: "Reports an error if a file includes a restricted file.",
+		Severity:    thriftcheck.Error,
+		Rationale:   "Some files are too large or too specialized to be included everywhere.",
+		Bad:         `include "huge.thrift"`,
+	},
+	"include.separator": {
+		Description: "Reports an error if an include path uses backslashes as separators.",
+		Severity:    thriftcheck.Error,
+		Rationale:   "Backslash-separated paths only resolve on Windows.",
+		Bad:         `include "shared\\types.thrift"`,
+		Good:        `include "shared/types.thrift"`,
+	},
+	"include.unused": {
+		Description: "Warns if an included file isn't referenced by any type, constant, or service.",
+		Severity:    thriftcheck.Warning,
+		Rationale:   "Stale includes slow down code generation and suggest dependencies that don't exist.",
+		Bad:         "// user.thrift\ninclude \"shared.thrift\"\n\nstruct User {\n    1: optional i64 id\n}\n\n// shared.thrift\nstruct Audit {}",
+		Good:        "// user.thrift\ninclude \"shared.thrift\"\n\nstruct User {\n    1: optional shared.Audit audit\n}\n\n// shared.thrift\nstruct Audit {}",
+	},
+	"int.64bit": {
+		Description: "Warns when an integer constant exceeds the 32-bit number range.",
+		Severity:    thriftcheck.Warning,
+		Rationale:   "Some languages (e.g. JavaScript) don't support 64-bit integers.",
+		Bad:         `const i64 VALUE = 4294967296`,
+		Good:        `const i64 VALUE = 1024`,
+	},
+	"limits.size": {
+		Description: "Warns if a struct, union, or exception has too many fields.",
+		Severity:    thriftcheck.Warning,
+		Rationale:   "Very large structs are hard to read and slow to compile, serialize, and deserialize in generated code.",
+		Good:        "struct User {\n    1: optional i64 id\n}",
+	},
+	"map.key.doc": {
+		Description: "Warns if a map field's documentation says that it's keyed by an ID but its key type isn't an integer.",
+		Severity:    thriftcheck.Warning,
+		Rationale:   "Either the documentation or the key type is wrong, and readers can't tell which one to trust.",
+		Bad:         "struct S {\n    /** Scores, keyed by user id. */\n    1: optional map<string, i32> scores\n}",
+		Good:        "struct S {\n    /** Scores, keyed by user id. */\n    1: optional map<i64, i32> scores\n}",
+	},
+	"map.key.type": {
+		Description: "Reports an error if a map's key type isn't allowed.",
+		Severity:    thriftcheck.Error,
+		Rationale:   "Complex key types aren't supported by all languages' serializers.",
+		Bad:         "struct S {\n    1: optional map<i64, string> names\n}",
+		Good:        "struct S {\n    1: optional map<string, bool> names\n}",
+	},
+	"map.key.value.same": {
+		Description: "Warns when an ID-like map field has identical key and value types.",
+		Severity:    thriftcheck.Warning,
+		Rationale:   "When both sides of a map share a type, it's easy to confuse which side is which, especially for maps between related IDs.",
+		Bad:         "struct S {\n    1: optional map<i64, i64> parent_child\n}",
+		Good:        "struct S {\n    1: optional map<i64, list<i64>> parent_children\n}",
+	},
+	"map.key.width.consistent": {
+		Description: "Warns if map fields keyed by the same concept use different integer key widths.",
+		Severity:    thriftcheck.Warning,
+		Rationale:   "A key such as a user ID should have one width everywhere so that values can be passed between maps without conversion.",
+		Bad:         "struct A {\n    1: optional map<i64, string> names_by_user_id\n}\n\nstruct B {\n    1: optional map<i32, i32> scores_by_user_id\n}",
+		Good:        "struct A {\n    1: optional map<i64, string> names_by_user_id\n}\n\nstruct B {\n    1: optional map<i64, i32> scores_by_user_id\n}",
+	},
+	"map.value.type": {
+		Description: "Reports an error if a map's value type isn't allowed.",
+		Severity:    thriftcheck.Error,
+		Rationale:   "Some value types (such as nested maps) are discouraged by coding standards.",
+		Bad:         "struct S {\n    1: optional map<string, string> names\n}",
+		Good:        "struct S {\n    1: optional map<string, bool> names\n}",
+	},
+	"names.reserved": {
+		Description: "Reports an error if a name is in the list of reserved names.",
+		Severity:    thriftcheck.Error,
+		Rationale:   "Reserved names conflict with keywords in some target languages.",
+		Bad:         "struct template {}",
+		Good:        "struct Template {}",
+	},
+	"naming.convention": {
+		Description: "Warns if a type, service, enumeration item, or field name doesn't follow the naming convention for its category.",
+		Severity:    thriftcheck.Warning,
+		Rationale:   "Consistently cased names make IDL files easier to read and map predictably onto each language's generated code.",
+		Bad:         "struct user_info {\n    1: optional string displayName\n}",
+		Good:        "struct UserInfo {\n    1: optional string displayName\n}",
+	},
+	"naming.reserved": {
+		Description: "Reports an error if a name is a reserved word in one of the configured target languages.",
+		Severity:    thriftcheck.Error,
+		Rationale:   "Names that are valid in Thrift but reserved in a target language break that language's generated code.",
+		Bad:         "struct User {\n    1: optional string strictfp\n}",
+		Good:        "struct User {\n    1: optional string strict\n}",
+	},
+	"namespace.duplicate.language": {
+		Description: "Reports an error if a file declares more than one namespace for the same language.",
+		Severity:    thriftcheck.Error,
+		Rationale:   "Conflicting namespaces make the generated code's location ambiguous.",
+		Bad:         "namespace java com.example.a\nnamespace java com.example.b",
+		Good:        "namespace java com.example.a\nnamespace py example.a",
+	},
+	"namespace.include.consistency": {
+		Description: "Warns if an included file is missing a namespace for a configured language that the including file declares.",
+		Severity:    thriftcheck.Warning,
+		Rationale:   "Included types without the namespace end up in a different package than the code that uses them.",
+		Bad:         "// a.thrift\nnamespace py idl.a\ninclude \"b.thrift\"\n// b.thrift\nstruct B {}",
+		Good:        "// a.thrift\nnamespace py idl.a\ninclude \"b.thrift\"\n// b.thrift\nnamespace py idl.b\nstruct B {}",
+	},
+	"namespace.path.match": {
+		Description: "Reports an error if a file in a configured directory doesn't declare that directory's namespace language.",
+		Severity:    thriftcheck.Error,
+		Rationale:   "Layouts that group files by target language rely on each file generating code for that language in its own package.",
+		Bad:         "// java/users.thrift\nstruct User {}",
+		Good:        "// java/users.thrift\nnamespace java com.example.users\n\nstruct User {}",
+	},
+	"namespace.patterns": {
+		Description: "Reports an error if a namespace doesn't match its language's pattern.",
+		Severity:    thriftcheck.Error,
+		Rationale:   "Consistent namespaces keep generated code organized.",
+		Bad:         `namespace py example.user`,
+		Good:        `namespace py idl.example.user`,
+	},
+	"namespace.required": {
+		Description: "Reports an error if a file doesn't declare a namespace for each of the required languages.",
+		Severity:    thriftcheck.Error,
+		Rationale:   "Code generation for a language fails or falls back to an unexpected location when its namespace is missing.",
+		Bad:         "namespace java com.example.user",
+		Good:        "namespace java com.example.user\nnamespace py idl.example.user",
+	},
+	"namespace.wildcard": {
+		Description: `Warns if a namespace uses the "*" (all languages) scope.`,
+		Severity:    thriftcheck.Warning,
+		Rationale:   "Wildcard namespaces hide each language's intended namespace.",
+		Bad:         "namespace * example",
+		Good:        "namespace go example",
+	},
+	"reference.qualification.depth": {
+		Description: "Warns if a type reference has more qualifiers than a configured limit.",
+		Severity:    thriftcheck.Warning,
+		Rationale:   "References that reach through multiple includes (a.b.Type) are fragile and depend on the include structure of other files.",
+		Bad:         "struct S {\n    1: optional a.b.Type value\n}",
+		Good:        "struct S {\n    1: optional b.Type value\n}",
+	},
+	"reference.qualify.included": {
+		Description: "Warns if an unqualified type reference only matches a definition in an included file.",
+		Severity:    thriftcheck.Warning,
+		Rationale:   "Thrift doesn't flatten included definitions into the current scope, so references to them must be qualified with the include's name.",
+		Bad:         "// users.thrift\nstruct User {}\n\n// example.thrift\ninclude \"users.thrift\"\n\nstruct S {\n    1: optional User user\n}",
+		Good:        "// users.thrift\nstruct User {}\n\n// example.thrift\ninclude \"users.thrift\"\n\nstruct S {\n    1: optional users.User user\n}",
+	},
+	"service.cohesion": {
+		Description: "Warns if a service's methods act on more distinct resources (the trailing nouns of their names) than a configured limit.",
+		Severity:    thriftcheck.Warning,
+		Rationale:   "Services that sprawl across many resources are hard to own, scale, and evolve, and usually want to be split.",
+		Bad:         "service Platform {\n    void getUser()\n    void listOrders()\n    void createInvoice()\n    void updatePolicy()\n    void deleteAddress()\n}",
+		Good:        "service Users {\n    void getUser()\n    void listUsers()\n    void updateUserAddress()\n}",
+	},
+	"service.cqrs": {
+		Description: "Warns if a service annotated with `cqrs` contains both read and write methods.",
+		Severity:    thriftcheck.Warning,
+		Rationale:   "Command/query separation keeps reads and writes in separate services so that they can be scaled, secured, and evolved independently.",
+		Bad:         "service Users {\n    User getUser(1: i64 id)\n    void updateUser(1: User user)\n} (cqrs = \"true\")",
+		Good:        "service UserQueries {\n    User getUser(1: i64 id)\n} (cqrs = \"true\")\n\nservice UserCommands {\n    void updateUser(1: User user)\n} (cqrs = \"true\")",
+	},
+	"service.deprecated.methods": {
+		Description: "Warns if a service is marked `deprecated` but one of its methods isn't.",
+		Severity:    thriftcheck.Warning,
+		Rationale:   "Methods of a deprecated service are deprecated too; a method without the marker was usually added after the service was deprecated, which it shouldn't be.",
+		Bad:         "/** @deprecated */\nservice Users {\n    /** @deprecated */\n    User getUser(1: i64 id)\n    void deleteUser(1: i64 id)\n}",
+		Good:        "/** @deprecated */\nservice Users {\n    /** @deprecated */\n    User getUser(1: i64 id)\n    /** @deprecated */\n    void deleteUser(1: i64 id)\n}",
+	},
+	"service.duplicate": {
+		Description: "Warns if a service with the same name is defined in more than one file.",
+		Severity:    thriftcheck.Warning,
+		Rationale:   "Code generated from both files defines the same service, which clashes when they are built together.",
+		Bad:         "// a.thrift\nservice UserService {}\n\n// b.thrift\nservice UserService {}",
+		Good:        "// a.thrift\nservice UserService {}\n\n// b.thrift\nservice AccountService {}",
+	},
+	"service.list.ordering": {
+		Description: "Warns if a method returns a list without an `ordered` or `unordered` annotation.",
+		Severity:    thriftcheck.Warning,
+		Rationale:   "Consumers of a list need to know whether its order is meaningful before they depend on it (or sort it).",
+		Bad:         "service Users {\n    list<i64> listUserIDs()\n}",
+		Good:        "service Users {\n    /** Newest first. @ordered */\n    list<i64> listUserIDs()\n}",
+	},
+	"service.method.id": {
+		Description: "Reports an error if a service method is missing a numeric methodId annotation or shares one with another method.",
+		Severity:    thriftcheck.Error,
+		Rationale:   "Gateways that route requests by method ID need every method to have its own stable ID.",
+		Bad:         "service Users {\n    /** @methodId(1) */\n    void ping()\n    /** @methodId(1) */\n    void pong()\n}",
+		Good:        "service Users {\n    /** @methodId(1) */\n    void ping()\n    /** @methodId(2) */\n    void pong()\n}",
+	},
+	"service.method.inherited.case": {
+		Description: "Reports an error if a method's name differs only in case from a method inherited from a parent service.",
+		Severity:    thriftcheck.Error,
+		Rationale:   "Methods whose names differ only in case make dispatch ambiguous in languages and transports that compare names case-insensitively.",
+		Bad:         "service Base {\n    void GetUser()\n}\n\nservice Users extends Base {\n    void getUser()\n}",
+		Good:        "service Base {\n    void getUser()\n}\n\nservice Users extends Base {\n    void getUser()\n}",
+	},
+	"service.method.void.mutator": {
+		Description: "Warns if a method that appears to mutate state returns void.",
+		Severity:    thriftcheck.Warning,
+		Rationale:   "Mutating methods should return a result, which can carry information like idempotency tokens.",
+		Bad:         "service Users {\n    void createUser(1: string name)\n}",
+		Good:        "struct User {}\nservice Users {\n    User createUser(1: string name)\n}",
+	},
+	"service.throws.doc": {
+		Description: "Warns if a method's documentation comment doesn't mention each of its declared exceptions.",
+		Severity:    thriftcheck.Warning,
+		Rationale:   "Callers need to know when each exception is thrown in order to handle it correctly.",
+		Bad:         "exception NotFound {}\n\nservice Users {\n    /** Returns the user. */\n    void getUser(1: i64 id) throws (1: NotFound notFound)\n}",
+		Good:        "exception NotFound {}\n\nservice Users {\n    /** Returns the user, or throws NotFound if it doesn't exist. */\n    void getUser(1: i64 id) throws (1: NotFound notFound)\n}",
+	},
+	"service.visibility": {
+		Description: "Reports an error if a service doesn't declare an allowed visibility annotation, like public or internal.",
+		Severity:    thriftcheck.Error,
+		Rationale:   "Gateways and service registries rely on each service declaring who is allowed to call it.",
+		Bad:         "service Users {\n    void ping()\n}",
+		Good:        "/** @visibility(\"internal\") */\nservice Users {\n    void ping()\n}",
+	},
+	"set.value.type": {
+		Description: "Reports an error if a set's value type isn't allowed.",
+		Severity:    thriftcheck.Error,
+		Rationale:   "Complex value types aren't supported by all languages' serializers.",
+		Bad:         "struct S {\n    1: optional set<list<string>> names\n}",
+		Good:        "struct S {\n    1: optional set<string> names\n}",
+	},
+	"struct.duplicated.fields": {
+		Description: "Warns if the same block of fields is repeated across several structs.",
+		Severity:    thriftcheck.Warning,
+		Rationale:   "Copy-pasted fields drift apart over time; a shared struct keeps them consistent.",
+		Bad:         "struct User {\n    1: optional i64 id\n    2: optional i64 created_at\n    3: optional i64 updated_at\n}\n\nstruct Group {\n    1: optional i64 id\n    2: optional i64 created_at\n    3: optional i64 updated_at\n}\n\nstruct Team {\n    1: optional i64 id\n    2: optional i64 created_at\n    3: optional i64 updated_at\n}",
+		Good:        "struct Audit {\n    1: optional i64 created_at\n    2: optional i64 updated_at\n}\n\nstruct User {\n    1: optional i64 id\n    2: optional Audit audit\n}\n\nstruct Group {\n    1: optional i64 id\n    2: optional Audit audit\n}\n\nstruct Team {\n    1: optional i64 id\n    2: optional Audit audit\n}",
+	},
+	"struct.stable.no.default": {
+		Description: "Reports an error if a field in a `wire-stable` struct declares a default value.",
+		Severity:    thriftcheck.Error,
+		Rationale:   "Structs that must serialize deterministically can't have defaults, which generators apply inconsistently.",
+		Bad:         "/** @wire-stable */\nstruct Event {\n    1: optional i32 version = 1\n}",
+		Good:        "/** @wire-stable */\nstruct Event {\n    1: optional i32 version\n}",
+	},
+	"struct.paired.ids": {
+		Description: "Warns if paired request and response structs use different IDs for the same field.",
+		Severity:    thriftcheck.Warning,
+		Rationale:   "Matching fields in paired structs are easier to reason about when they share IDs.",
+		Bad:         "struct GetUserRequest {\n    1: optional i64 id\n}\nstruct GetUserResponse {\n    2: optional i64 id\n}",
+		Good:        "struct GetUserRequest {\n    1: optional i64 id\n}\nstruct GetUserResponse {\n    1: optional i64 id\n}",
+	},
+	"struct.role.separation": {
+		Description: "Warns if a struct is used both as a function's sole argument and as the type of another struct's field.",
+		Severity:    thriftcheck.Warning,
+		Rationale:   "A request struct evolves with its API, while a data struct evolves with the model; sharing one couples the two.",
+		Bad:         "struct User {}\n\nstruct Team {\n    1: optional User owner\n}\n\nservice Users {\n    void createUser(1: User user)\n}",
+		Good:        "struct User {}\n\nstruct Team {\n    1: optional User owner\n}\n\nstruct CreateUserRequest {\n    1: optional User user\n}\n\nservice Users {\n    void createUser(1: CreateUserRequest request)\n}",
+	},
+	"struct.unused": {
+		Description: "Warns if a struct or union isn't referenced by any field, constant, typedef, or function in the linted files or their includes.",
+		Severity:    thriftcheck.Warning,
+		Rationale:   "Types that nothing uses accumulate just like orphan files, and they make a schema harder to navigate.",
+		Bad:         "struct User {}\n\nstruct LegacyUser {}\n\nservice Users {\n    User getUser(1: i64 id)\n}",
+		Good:        "struct User {}\n\nservice Users {\n    User getUser(1: i64 id)\n}",
+	},
+	"type.complexity.budget": {
+		Description: "Warns if the number of type nodes reachable from a struct exceeds a configured budget.",
+		Severity:    thriftcheck.Warning,
+		Rationale:   "Some code generators have practical limits on the combined complexity of a type and everything that it references.",
+		Good:        "struct User {\n    1: optional list<string> names\n    2: optional map<string, i64> counts\n}",
+	},
+	"type.recursion": {
+		Description: "Reports an error if a struct or typedef contains itself by value.",
+		Severity:    thriftcheck.Error,
+		Rationale:   "Generated code in some languages can't represent a type that contains itself without a reference, and fails to compile or overflows the stack.",
+		Bad:         "typedef Node Next\nstruct Node {\n  1: required Next tail\n}",
+		Good:        "struct Node {\n  1: optional list<Node> children\n}",
+	},
+	"type.reference.cycle": {
+		Description: "Warns if cross-file type references form a cycle between files.",
+		Severity:    thriftcheck.Warning,
+		Rationale:   "Files whose types depend on each other can't be generated into separate packages in languages that forbid import cycles, and removing an include won't fix it.",
+		Bad:         "// a.thrift\ninclude \"b.thrift\"\nstruct User {\n    1: optional b.TeamID team\n}\n\n// b.thrift\ninclude \"a.thrift\"\ntypedef i64 TeamID\nstruct Team {\n    1: optional a.User owner\n}",
+		Good:        "// a.thrift\ninclude \"b.thrift\"\nstruct User {\n    1: optional b.TeamID team\n}\n\n// b.thrift\ntypedef i64 TeamID\nstruct Team {\n    1: optional i64 owner_id\n}",
+	},
+	"typedef.inconsistent": {
+		Description: "Reports an error if typedefs with the same name have different target types in a file and the files that it includes.",
+		Severity:    thriftcheck.Error,
+		Rationale:   "Code that mixes definitions from both files will silently disagree about the type's representation.",
+		Bad:         "// a.thrift\ninclude \"b.thrift\"\ntypedef i32 Id\n\n// b.thrift\ntypedef i64 Id",
+		Good:        "// a.thrift\ninclude \"b.thrift\"\ntypedef i64 Id\n\n// b.thrift\ntypedef i64 Id",
+	},
+	"typedef.name.keyword": {
+		Description: "Reports an error if a typedef's name matches a base type keyword, ignoring case.",
+		Severity:    thriftcheck.Error,
+		Rationale:   "A typedef named like a base type (e.g. String for an i64) is easily mistaken for that type, and is invalid in generators for case-insensitive languages.",
+		Bad:         "typedef i64 String",
+		Good:        "typedef i64 BigInt",
+	},
+	"types": {
+		Description: "Reports an error if a type isn't allowed in any context.",
+		Severity:    thriftcheck.Error,
+		Rationale:   "Some types are discouraged by coding standards.",
+		Bad:         "union U {\n    1: string name\n}\nstruct S {\n    1: optional U u\n}",
+	},
+	"union.migration.ids": {
+		Description: "Reports an error if a struct that was converted to a union doesn't preserve its field IDs.",
+		Severity:    thriftcheck.Error,
+		Rationale:   "Field IDs identify fields on the wire, so changing them while migrating a struct to a union breaks compatibility with existing data.",
+		Good:        "union ID {\n    1: i64 number\n    2: string name\n}",
+	},
+}
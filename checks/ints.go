@@ -23,9 +23,39 @@ import (
 
 // CheckInteger64bit warns when an integer constant exceeds the 32-bit number range.
 func CheckInteger64bit() thriftcheck.Check {
-	return thriftcheck.NewCheck("int.64bit", func(c *thriftcheck.C, i ast.ConstantInteger) {
+	return newCheck("int.64bit", func(c *thriftcheck.C, i ast.ConstantInteger) {
 		if i < math.MinInt32 || i > math.MaxInt32 {
 			c.Warningf(i, "64-bit integer constant %d may not work in all languages", i)
 		}
 	})
 }
+
+// maxSafeInteger is JavaScript's Number.MAX_SAFE_INTEGER (2^53-1).
+const maxSafeInteger = 1<<53 - 1
+
+// CheckInt64JSUnsafe warns when an i64 constant, or the default value of an
+// i64 field, is an integer literal whose magnitude exceeds JavaScript's
+// Number.MAX_SAFE_INTEGER, which can't be represented exactly by a JavaScript
+// number. Typedefs of i64 are resolved.
+func CheckInt64JSUnsafe() thriftcheck.Check {
+	return newCheck("const.i64.jsunsafe", func(c *thriftcheck.C, n ast.Node) {
+		var t ast.Type
+		var value ast.ConstantValue
+		switch n := n.(type) {
+		case *ast.Constant:
+			t, value = n.Type, n.Value
+		case *ast.Field:
+			t, value = n.Type, n.Default
+		default:
+			return
+		}
+
+		i, ok := value.(ast.ConstantInteger)
+		if !ok || (i >= -maxSafeInteger && i <= maxSafeInteger) {
+			return
+		}
+		if b, ok := resolveType(c, t).(ast.BaseType); ok && b.ID == ast.I64TypeID {
+			c.Warningf(n, "i64 value %d exceeds the JavaScript safe integer range of ±%d", i, maxSafeInteger)
+		}
+	})
+}
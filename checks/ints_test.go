@@ -45,3 +45,55 @@ func TestCheckInteger64bit(t *testing.T) {
 	check := checks.CheckInteger64bit()
 	RunTests(t, &check, tests)
 }
+
+func TestCheckInt64JSUnsafe(t *testing.T) {
+	i64 := ast.BaseType{ID: ast.I64TypeID}
+	prog := &ast.Program{Definitions: []ast.Definition{
+		&ast.Typedef{Name: "Timestamp", Type: i64},
+	}}
+
+	tests := []Test{
+		{
+			node: &ast.Constant{Name: "MAX", Type: i64, Value: ast.ConstantInteger(1<<53 - 1)},
+			want: []string{},
+		},
+		{
+			node: &ast.Constant{Name: "MIN", Type: i64, Value: ast.ConstantInteger(-(1<<53 - 1))},
+			want: []string{},
+		},
+		{
+			node: &ast.Constant{Name: "TOO_BIG", Type: i64, Value: ast.ConstantInteger(1 << 53)},
+			want: []string{
+				`t.thrift:0:1: warning: i64 value 9007199254740992 exceeds the JavaScript safe integer range of ±9007199254740991 (const.i64.jsunsafe)`,
+			},
+		},
+		{
+			node: &ast.Constant{Name: "TOO_SMALL", Type: i64, Value: ast.ConstantInteger(-(1 << 53))},
+			want: []string{
+				`t.thrift:0:1: warning: i64 value -9007199254740992 exceeds the JavaScript safe integer range of ±9007199254740991 (const.i64.jsunsafe)`,
+			},
+		},
+		{
+			prog: prog,
+			node: &ast.Field{ID: 1, Name: "ts", Type: ast.TypeReference{Name: "Timestamp"}, Default: ast.ConstantInteger(math.MaxInt64)},
+			want: []string{
+				`t.thrift:0:1: warning: i64 value 9223372036854775807 exceeds the JavaScript safe integer range of ±9007199254740991 (const.i64.jsunsafe)`,
+			},
+		},
+		{
+			node: &ast.Field{ID: 1, Name: "ts", Type: i64},
+			want: []string{},
+		},
+		{
+			node: &ast.Constant{Name: "BIG", Type: ast.BaseType{ID: ast.DoubleTypeID}, Value: ast.ConstantDouble(1e300)},
+			want: []string{},
+		},
+		{
+			node: &ast.Constant{Name: "NAME", Type: ast.BaseType{ID: ast.StringTypeID}, Value: ast.ConstantString("9007199254740992")},
+			want: []string{},
+		},
+	}
+
+	check := checks.CheckInt64JSUnsafe()
+	RunTests(t, &check, tests)
+}
@@ -0,0 +1,32 @@
+// Copyright 2026 Pinterest
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checks
+
+import (
+	"github.com/pinterest/thriftcheck"
+	"go.uber.org/thriftrw/ast"
+)
+
+// CheckSizeLimits returns a thriftcheck.Check that warns when a struct,
+// union, or exception has more than maxFields fields. Enumeration sizes are
+// limited by CheckEnumSize and CheckMaxEnumMembers instead. A maxFields value
+// of 0 disables the check.
+func CheckSizeLimits(maxFields int) thriftcheck.Check {
+	return newCheck("limits.size", func(c *thriftcheck.C, s *ast.Struct) {
+		if maxFields > 0 && len(s.Fields) > maxFields {
+			c.Warningf(s, "%s %q has %d fields, more than the limit of %d", namingCategory(s), s.Name, len(s.Fields), maxFields)
+		}
+	})
+}
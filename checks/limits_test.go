@@ -0,0 +1,60 @@
+// Copyright 2026 Pinterest
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checks_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/pinterest/thriftcheck/checks"
+	"go.uber.org/thriftrw/ast"
+)
+
+func TestCheckSizeLimits(t *testing.T) {
+	fields := func(n int) []*ast.Field {
+		fs := make([]*ast.Field, n)
+		for i := range fs {
+			fs[i] = &ast.Field{ID: i + 1, Name: fmt.Sprintf("f%d", i+1), Type: ast.BaseType{ID: ast.I32TypeID}}
+		}
+		return fs
+	}
+
+	tests := []Test{
+		{
+			node: &ast.Struct{Name: "S", Type: ast.StructType, Fields: fields(3)},
+			want: []string{},
+		},
+		{
+			node: &ast.Struct{Name: "S", Type: ast.StructType, Fields: fields(4)},
+			want: []string{
+				`t.thrift:0:1: warning: struct "S" has 4 fields, more than the limit of 3 (limits.size)`,
+			},
+		},
+		{
+			node: &ast.Struct{Name: "E", Type: ast.ExceptionType, Fields: fields(4)},
+			want: []string{
+				`t.thrift:0:1: warning: exception "E" has 4 fields, more than the limit of 3 (limits.size)`,
+			},
+		},
+	}
+
+	check := checks.CheckSizeLimits(3)
+	RunTests(t, &check, tests)
+
+	disabled := checks.CheckSizeLimits(0)
+	RunTests(t, &disabled, []Test{
+		{node: &ast.Struct{Name: "S", Type: ast.StructType, Fields: fields(300)}, want: []string{}},
+	})
+}
@@ -15,6 +15,11 @@
 package checks
 
 import (
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+
 	"github.com/pinterest/thriftcheck"
 	"go.uber.org/thriftrw/ast"
 )
@@ -22,7 +27,7 @@ import (
 // CheckMapKeyType returns a thriftcheck.Check that checks if a `map<>` key
 // type is allowed.
 func CheckMapKeyType(allowedTypes, disallowedTypes []thriftcheck.ThriftType) thriftcheck.Check {
-	return thriftcheck.NewCheck("map.key.type", func(c *thriftcheck.C, mt ast.MapType) {
+	return newCheck("map.key.type", func(c *thriftcheck.C, mt ast.MapType) {
 		if ok, name := c.IsTypeAllowed(mt.KeyType, allowedTypes, disallowedTypes); !ok {
 			c.Errorf(mt, "map key type %q is not allowed", name)
 		}
@@ -32,9 +37,139 @@ func CheckMapKeyType(allowedTypes, disallowedTypes []thriftcheck.ThriftType) thr
 // CheckMapKeyType returns a thriftcheck.Check that checks if a `map<>` value
 // type is allowed.
 func CheckMapValueType(allowedTypes, disallowedTypes []thriftcheck.ThriftType) thriftcheck.Check {
-	return thriftcheck.NewCheck("map.value.type", func(c *thriftcheck.C, mt ast.MapType) {
+	return newCheck("map.value.type", func(c *thriftcheck.C, mt ast.MapType) {
 		if ok, name := c.IsTypeAllowed(mt.ValueType, allowedTypes, disallowedTypes); !ok {
 			c.Errorf(mt, "map value type %q is not allowed", name)
 		}
 	})
 }
+
+// keyedByIDRegexp matches documentation that describes a map as keyed by an
+// ID, like "keyed by user id" or "by account_id".
+var keyedByIDRegexp = regexp.MustCompile(`(?i)\bby\s+(?:[\w.'-]+[\s_]){0,2}ids?\b`)
+
+// CheckMapKeyDocConsistency returns a thriftcheck.Check that warns when a map
+// field's documentation says that it's keyed by an ID, but its key type
+// (resolving typedefs) isn't an integer.
+func CheckMapKeyDocConsistency() thriftcheck.Check {
+	return newCheck("map.key.doc", func(c *thriftcheck.C, f *ast.Field) {
+		mt, ok := resolveType(c, f.Type).(ast.MapType)
+		if !ok || !keyedByIDRegexp.MatchString(f.Doc) {
+			return
+		}
+		if t, ok := resolveType(c, mt.KeyType).(ast.BaseType); ok {
+			switch t.ID {
+			case ast.I8TypeID, ast.I16TypeID, ast.I32TypeID, ast.I64TypeID:
+				return
+			}
+		}
+		c.Warningf(f, "map %q is documented as keyed by ID but has %s keys", f.Name, mt.KeyType)
+	})
+}
+
+var defaultSameKeyValueRegexp = regexp.MustCompile(`(^|_)(ids?|parents?|child(ren)?)(_|$)|(Ids?|Parents?|Child(ren)?)([A-Z]|$)`)
+
+// CheckMapSameKeyValueType returns a thriftcheck.Check that warns when a map
+// field whose name matches nameRegexp has identical key and value types. If
+// nameRegexp is nil, a default pattern matching ID-like names is used.
+func CheckMapSameKeyValueType(nameRegexp *regexp.Regexp) thriftcheck.Check {
+	if nameRegexp == nil {
+		nameRegexp = defaultSameKeyValueRegexp
+	}
+
+	return newCheck("map.key.value.same", func(c *thriftcheck.C, f *ast.Field) {
+		mt, ok := resolveType(c, f.Type).(ast.MapType)
+		if !ok || !nameRegexp.MatchString(f.Name) {
+			return
+		}
+		if sameType(resolveType(c, mt.KeyType), resolveType(c, mt.ValueType)) {
+			c.Warningf(f, "map %q has identical key and value types (%s)", f.Name, mt.KeyType)
+		}
+	})
+}
+
+var defaultMapKeyNameRegexp = regexp.MustCompile(`(?:^|_)by_(\w+)$|[a-z0-9]By([A-Z]\w*)$`)
+
+// CheckConsistentMapKeyWidth returns a multi-file thriftcheck.Check that
+// warns when map fields whose names imply the same key concept use different
+// integer key widths. The concept is the first non-empty group matched by
+// keyNameRegexp (or the whole match), compared case-insensitively and ignoring
+// underscores. If keyNameRegexp is nil, a default pattern that matches names
+// like "scores_by_user_id" and "scoresByUserId" is used.
+func CheckConsistentMapKeyWidth(keyNameRegexp *regexp.Regexp) thriftcheck.Check {
+	if keyNameRegexp == nil {
+		keyNameRegexp = defaultMapKeyNameRegexp
+	}
+
+	type mapKey struct {
+		loc   thriftcheck.Location
+		name  string
+		width ast.BaseTypeID
+	}
+	concepts := make(map[string][]mapKey)
+
+	return newMultiFileCheck("map.key.width.consistent", func(c *thriftcheck.C, f *ast.Field) {
+		mt, ok := resolveType(c, f.Type).(ast.MapType)
+		if !ok {
+			return
+		}
+		t, ok := resolveType(c, mt.KeyType).(ast.BaseType)
+		if !ok || (t.ID != ast.I8TypeID && t.ID != ast.I16TypeID && t.ID != ast.I32TypeID && t.ID != ast.I64TypeID) {
+			return
+		}
+		m := keyNameRegexp.FindStringSubmatch(f.Name)
+		if m == nil {
+			return
+		}
+		concept := m[0]
+		for _, group := range m[1:] {
+			if group != "" {
+				concept = group
+				break
+			}
+		}
+		concept = strings.ToLower(strings.ReplaceAll(concept, "_", ""))
+		concepts[concept] = append(concepts[concept], mapKey{loc: c.Locate(f), name: f.Name, width: t.ID})
+	}, func(c *thriftcheck.C) {
+		for _, concept := range slices.Sorted(maps.Keys(concepts)) {
+			keys := concepts[concept]
+			first := keys[0]
+			for _, k := range keys[1:] {
+				if k.width != first.width {
+					c.WarningfAt(k.loc, "map %q has %s keys but %q has %s keys", k.name, ast.BaseType{ID: k.width}, first.name, ast.BaseType{ID: first.width})
+				}
+			}
+		}
+		clear(concepts)
+	})
+}
+
+// resolveType follows type references (including chains of typedefs) until
+// it reaches a concrete type or definition. Unresolvable references are
+// returned as-is.
+func resolveType(c *thriftcheck.C, t ast.Type) ast.Node {
+	var n ast.Node = t
+	for range 16 {
+		ref, ok := n.(ast.TypeReference)
+		if !ok {
+			break
+		}
+		if n = c.ResolveType(ref); n == nil {
+			return ref
+		}
+	}
+	return n
+}
+
+func sameType(a, b ast.Node) bool {
+	switch a := a.(type) {
+	case ast.BaseType:
+		b, ok := b.(ast.BaseType)
+		return ok && a.ID == b.ID
+	case ast.Type:
+		b, ok := b.(ast.Type)
+		return ok && a.String() == b.String()
+	default:
+		return a == b
+	}
+}
@@ -15,6 +15,7 @@
 package checks_test
 
 import (
+	"regexp"
 	"testing"
 
 	"github.com/pinterest/thriftcheck"
@@ -44,6 +45,22 @@ func TestCheckMapKeyType(t *testing.T) {
 				ValueType: ast.BaseType{ID: ast.StringTypeID}},
 			want: []string{},
 		},
+		{
+			prog: &ast.Program{},
+			node: ast.MapType{
+				KeyType:   ast.TypeReference{Name: "Enum"},
+				ValueType: ast.BaseType{ID: ast.StringTypeID}},
+			want: []string{`t.thrift:0:1: error: map key type "Enum" is not allowed (map.key.type)`},
+		},
+		{
+			prog: &ast.Program{Definitions: []ast.Definition{
+				&ast.Struct{Name: "Key", Type: ast.StructType},
+			}},
+			node: ast.MapType{
+				KeyType:   ast.TypeReference{Name: "Key"},
+				ValueType: ast.BaseType{ID: ast.StringTypeID}},
+			want: []string{`t.thrift:0:1: error: map key type "Key" is not allowed (map.key.type)`},
+		},
 		{
 			node: ast.MapType{
 				KeyType:   ast.BaseType{ID: ast.StringTypeID},
@@ -232,3 +249,155 @@ func TestCheckMapValueType(t *testing.T) {
 	checkUnion := checks.CheckMapValueType([]thriftcheck.ThriftType{}, []thriftcheck.ThriftType{unionType})
 	RunTests(t, &checkUnion, testsUnion)
 }
+
+func TestCheckMapSameKeyValueType(t *testing.T) {
+	i64Map := ast.MapType{
+		KeyType:   ast.BaseType{ID: ast.I64TypeID},
+		ValueType: ast.BaseType{ID: ast.I64TypeID}}
+	stringMap := ast.MapType{
+		KeyType:   ast.BaseType{ID: ast.StringTypeID},
+		ValueType: ast.BaseType{ID: ast.StringTypeID}}
+
+	tests := []Test{
+		{
+			node: &ast.Field{Name: "parent_child", Type: i64Map},
+			want: []string{
+				`t.thrift:0:1: warning: map "parent_child" has identical key and value types (i64) (map.key.value.same)`,
+			},
+		},
+		{
+			node: &ast.Field{Name: "userIds", Type: i64Map},
+			want: []string{
+				`t.thrift:0:1: warning: map "userIds" has identical key and value types (i64) (map.key.value.same)`,
+			},
+		},
+		{
+			prog: &ast.Program{Definitions: []ast.Definition{
+				&ast.Typedef{Name: "UserID", Type: ast.BaseType{ID: ast.I64TypeID}},
+			}},
+			node: &ast.Field{Name: "parent_ids", Type: ast.MapType{
+				KeyType:   ast.TypeReference{Name: "UserID"},
+				ValueType: ast.BaseType{ID: ast.I64TypeID}}},
+			want: []string{
+				`t.thrift:0:1: warning: map "parent_ids" has identical key and value types (UserID) (map.key.value.same)`,
+			},
+		},
+		{
+			node: &ast.Field{Name: "parent_names", Type: ast.MapType{
+				KeyType:   ast.BaseType{ID: ast.I64TypeID},
+				ValueType: ast.BaseType{ID: ast.StringTypeID}}},
+			want: []string{},
+		},
+		{
+			node: &ast.Field{Name: "labels", Type: stringMap},
+			want: []string{},
+		},
+		{
+			node: &ast.Field{Name: "valid", Type: i64Map},
+			want: []string{},
+		},
+	}
+
+	check := checks.CheckMapSameKeyValueType(nil)
+	RunTests(t, &check, tests)
+
+	tests = []Test{
+		{
+			node: &ast.Field{Name: "labels", Type: stringMap},
+			want: []string{
+				`t.thrift:0:1: warning: map "labels" has identical key and value types (string) (map.key.value.same)`,
+			},
+		},
+	}
+
+	check = checks.CheckMapSameKeyValueType(regexp.MustCompile(`.`))
+	RunTests(t, &check, tests)
+}
+
+func TestCheckConsistentMapKeyWidth(t *testing.T) {
+	tests := []MultiFileTest{
+		{
+			files: map[string]string{
+				"a.thrift": `
+typedef i64 UserID
+struct A {
+	1: map<i64, string> names_by_user_id
+	2: map<i32, string> names_by_group_id
+}`,
+				"b.thrift": `
+include "a.thrift"
+struct B {
+	1: map<a.UserID, i32> scoresByUserId
+	2: map<i32, i32> scores_by_group_id
+	3: map<i16, i32> scores
+}`,
+			},
+			want: []string{},
+		},
+		{
+			files: map[string]string{
+				"a.thrift": `
+struct A {
+	1: map<i64, string> names_by_user_id
+}`,
+				"b.thrift": `
+struct B {
+	1: map<i32, i32> scoresByUserId
+	2: map<string, i32> counts_by_user_id
+}`,
+			},
+			want: []string{
+				`b.thrift:3:2: warning: map "scoresByUserId" has i32 keys but "names_by_user_id" has i64 keys (map.key.width.consistent)`,
+			},
+		},
+	}
+
+	check := checks.CheckConsistentMapKeyWidth(nil)
+	RunMultiFileTests(t, &check, tests)
+}
+
+func TestCheckMapKeyDocConsistency(t *testing.T) {
+	prog := &ast.Program{Definitions: []ast.Definition{
+		&ast.Typedef{Name: "UserId", Type: ast.BaseType{ID: ast.I64TypeID}},
+		&ast.Typedef{Name: "Email", Type: ast.BaseType{ID: ast.StringTypeID}},
+	}}
+	mapOf := func(key ast.Type) ast.MapType {
+		return ast.MapType{KeyType: key, ValueType: ast.BaseType{ID: ast.I32TypeID}}
+	}
+
+	tests := []Test{
+		{
+			node: &ast.Field{ID: 1, Name: "scores", Doc: "Scores, keyed by user id.", Type: mapOf(ast.BaseType{ID: ast.I64TypeID})},
+			want: []string{},
+		},
+		{
+			prog: prog,
+			node: &ast.Field{ID: 1, Name: "scores", Doc: "Scores by user_id.", Type: mapOf(ast.TypeReference{Name: "UserId"})},
+			want: []string{},
+		},
+		{
+			node: &ast.Field{ID: 1, Name: "scores", Doc: "Scores, keyed by user id.", Type: mapOf(ast.BaseType{ID: ast.StringTypeID})},
+			want: []string{
+				`t.thrift:0:1: warning: map "scores" is documented as keyed by ID but has string keys (map.key.doc)`,
+			},
+		},
+		{
+			prog: prog,
+			node: &ast.Field{ID: 1, Name: "scores", Doc: "Indexed by ID.", Type: mapOf(ast.TypeReference{Name: "Email"})},
+			want: []string{
+				`t.thrift:0:1: warning: map "scores" is documented as keyed by ID but has Email keys (map.key.doc)`,
+			},
+		},
+		{
+			node: &ast.Field{ID: 1, Name: "ids", Doc: "Maps each email to its user id.", Type: mapOf(ast.BaseType{ID: ast.StringTypeID})},
+			want: []string{},
+		},
+		{
+			node: &ast.Field{ID: 1, Name: "scores", Doc: "Scores, keyed by user id.", Type: ast.ListType{ValueType: ast.BaseType{ID: ast.StringTypeID}}},
+			want: []string{},
+		},
+	}
+
+	check := checks.CheckMapKeyDocConsistency()
+	RunTests(t, &check, tests)
+}
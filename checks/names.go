@@ -15,7 +15,11 @@
 package checks
 
 import (
+	"maps"
 	"reflect"
+	"regexp"
+	"slices"
+	"strings"
 
 	"github.com/pinterest/thriftcheck"
 	"go.uber.org/thriftrw/ast"
@@ -38,9 +42,133 @@ func CheckNamesReserved(names []string) thriftcheck.Check {
 		reserved[name] = true
 	}
 
-	return thriftcheck.NewCheck("names.reserved", func(c *thriftcheck.C, n ast.Node) {
+	return newCheck("names.reserved", func(c *thriftcheck.C, n ast.Node) {
 		if name := nodeName(n); name != "" && reserved[name] {
 			c.Errorf(n, "%q is a reserved name", name)
 		}
 	})
 }
+
+// ReservedWords maps target languages (named by their namespace scopes) to
+// the identifiers that can't be used in their generated code: the language's
+// keywords, along with any builtin names that generators don't rename.
+var ReservedWords = map[string][]string{
+	"go": {
+		"break", "case", "chan", "const", "continue", "default", "defer",
+		"else", "fallthrough", "for", "func", "go", "goto", "if", "import",
+		"interface", "map", "package", "range", "return", "select", "struct",
+		"switch", "type", "var",
+	},
+	"java": {
+		"abstract", "assert", "boolean", "break", "byte", "case", "catch",
+		"char", "class", "const", "continue", "default", "do", "double",
+		"else", "enum", "extends", "false", "final", "finally", "float", "for",
+		"goto", "if", "implements", "import", "instanceof", "int", "interface",
+		"long", "native", "new", "null", "package", "private", "protected",
+		"public", "return", "short", "static", "strictfp", "super", "switch",
+		"synchronized", "this", "throw", "throws", "transient", "true", "try",
+		"void", "volatile", "while",
+	},
+	"js": {
+		"await", "break", "case", "catch", "class", "const", "continue",
+		"debugger", "default", "delete", "do", "else", "enum", "export",
+		"extends", "false", "finally", "for", "function", "if", "implements",
+		"import", "in", "instanceof", "interface", "let", "new", "null",
+		"package", "private", "protected", "public", "return", "static",
+		"super", "switch", "this", "throw", "true", "try", "typeof", "var",
+		"void", "while", "with", "yield",
+	},
+	"py": {
+		"False", "None", "True", "and", "as", "assert", "async", "await",
+		"break", "bool", "bytes", "class", "continue", "def", "del", "dict",
+		"elif", "else", "except", "finally", "float", "for", "from", "global",
+		"if", "import", "in", "int", "is", "lambda", "list", "nonlocal", "not",
+		"object", "or", "pass", "raise", "return", "set", "str", "try",
+		"tuple", "type", "while", "with", "yield",
+	},
+}
+
+// CheckLanguageReservedWords returns a thriftcheck.Check that reports an error
+// if a struct, union, exception, field, enum, service, or method name is a
+// reserved word (see ReservedWords) in any of the given target languages. The
+// check is disabled if no languages are given.
+func CheckLanguageReservedWords(languages []string) thriftcheck.Check {
+	reserved := make(map[string][]string)
+	for _, language := range slices.Sorted(slices.Values(languages)) {
+		for _, word := range ReservedWords[language] {
+			reserved[word] = append(reserved[word], language)
+		}
+	}
+
+	return newCheck("naming.reserved", func(c *thriftcheck.C, n ast.Node) {
+		switch n.(type) {
+		case *ast.Struct, *ast.Field, *ast.Enum, *ast.Service, *ast.Function:
+		default:
+			return
+		}
+		if name := nodeName(n); reserved[name] != nil {
+			c.Errorf(n, "%q is a reserved word in %s", name, strings.Join(reserved[name], ", "))
+		}
+	})
+}
+
+var (
+	pascalCaseRegexp      = regexp.MustCompile(`^[A-Z][A-Za-z0-9]*$`)
+	upperSnakeCaseRegexp  = regexp.MustCompile(`^[A-Z][A-Z0-9]*(_[A-Z0-9]+)*$`)
+	lowerCamelCaseRegexp  = regexp.MustCompile(`^[a-z][A-Za-z0-9]*$`)
+	defaultNamingPatterns = map[string]*regexp.Regexp{
+		"struct":    pascalCaseRegexp,
+		"union":     pascalCaseRegexp,
+		"exception": pascalCaseRegexp,
+		"enum":      pascalCaseRegexp,
+		"enumItem":  upperSnakeCaseRegexp,
+		"service":   pascalCaseRegexp,
+		"field":     lowerCamelCaseRegexp,
+	}
+)
+
+// namingCategory returns the naming convention category of a node, or an
+// empty string if its name isn't covered by a convention.
+func namingCategory(n ast.Node) string {
+	switch n := n.(type) {
+	case *ast.Struct:
+		switch n.Type {
+		case ast.UnionType:
+			return "union"
+		case ast.ExceptionType:
+			return "exception"
+		default:
+			return "struct"
+		}
+	case *ast.Enum:
+		return "enum"
+	case *ast.EnumItem:
+		return "enumItem"
+	case *ast.Service:
+		return "service"
+	case *ast.Field:
+		return "field"
+	}
+	return ""
+}
+
+// CheckNamingConvention returns a thriftcheck.Check that warns when a name
+// doesn't match the pattern for its category: struct, union, exception, enum,
+// enumItem, service, or field. By default, types and services are PascalCase,
+// enumeration items are UPPER_SNAKE_CASE, and fields are lowerCamelCase.
+// patterns overrides the defaults for individual categories.
+func CheckNamingConvention(patterns map[string]*regexp.Regexp) thriftcheck.Check {
+	merged := maps.Clone(defaultNamingPatterns)
+	maps.Copy(merged, patterns)
+
+	return newCheck("naming.convention", func(c *thriftcheck.C, n ast.Node) {
+		category := namingCategory(n)
+		re, ok := merged[category]
+		if !ok || re == nil {
+			return
+		}
+		if name := nodeName(n); !re.MatchString(name) {
+			c.Warningf(n, "%s name %q doesn't match %q", category, name, re)
+		}
+	})
+}
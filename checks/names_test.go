@@ -15,6 +15,7 @@
 package checks_test
 
 import (
+	"regexp"
 	"testing"
 
 	"github.com/pinterest/thriftcheck/checks"
@@ -48,3 +49,120 @@ func TestCheckNamesReserved(t *testing.T) {
 	check := checks.CheckNamesReserved([]string{"reserved"})
 	RunTests(t, &check, tests)
 }
+
+func TestCheckNamingConvention(t *testing.T) {
+	tests := []Test{
+		{
+			node: &ast.Struct{Name: "UserInfo"},
+			want: []string{},
+		},
+		{
+			node: &ast.Struct{Name: "user_info"},
+			want: []string{
+				`t.thrift:0:1: warning: struct name "user_info" doesn't match "^[A-Z][A-Za-z0-9]*$" (naming.convention)`,
+			},
+		},
+		{
+			node: &ast.Struct{Name: "not_found", Type: ast.ExceptionType},
+			want: []string{
+				`t.thrift:0:1: warning: exception name "not_found" doesn't match "^[A-Z][A-Za-z0-9]*$" (naming.convention)`,
+			},
+		},
+		{
+			node: &ast.EnumItem{Name: "NOT_FOUND"},
+			want: []string{},
+		},
+		{
+			node: &ast.EnumItem{Name: "notFound"},
+			want: []string{
+				`t.thrift:0:1: warning: enumItem name "notFound" doesn't match "^[A-Z][A-Z0-9]*(_[A-Z0-9]+)*$" (naming.convention)`,
+			},
+		},
+		{
+			node: &ast.Field{Name: "displayName"},
+			want: []string{},
+		},
+		{
+			node: &ast.Field{Name: "display_name"},
+			want: []string{
+				`t.thrift:0:1: warning: field name "display_name" doesn't match "^[a-z][A-Za-z0-9]*$" (naming.convention)`,
+			},
+		},
+		{
+			node: &ast.Typedef{Name: "user_id"},
+			want: []string{},
+		},
+	}
+
+	check := checks.CheckNamingConvention(nil)
+	RunTests(t, &check, tests)
+
+	check = checks.CheckNamingConvention(map[string]*regexp.Regexp{
+		"field": regexp.MustCompile(`^[a-z][a-z0-9]*(_[a-z0-9]+)*$`),
+	})
+	RunTests(t, &check, []Test{
+		{
+			node: &ast.Field{Name: "display_name"},
+			want: []string{},
+		},
+		{
+			node: &ast.Field{Name: "displayName"},
+			want: []string{
+				`t.thrift:0:1: warning: field name "displayName" doesn't match "^[a-z][a-z0-9]*(_[a-z0-9]+)*$" (naming.convention)`,
+			},
+		},
+		{
+			node: &ast.Struct{Name: "user_info"},
+			want: []string{
+				`t.thrift:0:1: warning: struct name "user_info" doesn't match "^[A-Z][A-Za-z0-9]*$" (naming.convention)`,
+			},
+		},
+	})
+}
+
+func TestCheckLanguageReservedWords(t *testing.T) {
+	java := checks.CheckLanguageReservedWords([]string{"java"})
+	RunTests(t, &java, []Test{
+		{
+			node: &ast.Field{ID: 1, Name: "class"},
+			want: []string{
+				`t.thrift:0:1: error: "class" is a reserved word in java (naming.reserved)`,
+			},
+		},
+		{
+			node: &ast.Field{ID: 2, Name: "list"},
+			want: []string{},
+		},
+		{
+			node: &ast.EnumItem{Name: "class"},
+			want: []string{},
+		},
+	})
+
+	py := checks.CheckLanguageReservedWords([]string{"py"})
+	RunTests(t, &py, []Test{
+		{
+			node: &ast.Field{ID: 1, Name: "list"},
+			want: []string{
+				`t.thrift:0:1: error: "list" is a reserved word in py (naming.reserved)`,
+			},
+		},
+		{
+			node: &ast.Field{ID: 2, Name: "synchronized"},
+			want: []string{},
+		},
+	})
+
+	both := checks.CheckLanguageReservedWords([]string{"py", "java"})
+	RunTests(t, &both, []Test{
+		{
+			node: &ast.Function{Name: "class"},
+			want: []string{
+				`t.thrift:0:1: error: "class" is a reserved word in java, py (naming.reserved)`,
+			},
+		},
+	})
+
+	disabled := checks.CheckLanguageReservedWords(nil)
+	RunTests(t, &disabled, []Test{{node: &ast.Field{ID: 1, Name: "class"}, want: []string{}}})
+}
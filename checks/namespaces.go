@@ -15,8 +15,13 @@
 package checks
 
 import (
+	"maps"
+	"path/filepath"
 	"regexp"
+	"slices"
+	"strings"
 
+	"github.com/danwakefield/fnmatch"
 	"github.com/pinterest/thriftcheck"
 	"go.uber.org/thriftrw/ast"
 )
@@ -25,9 +30,205 @@ import (
 // namespace's name matches a regular expression pattern. The pattern can
 // be configured one a per-language basis.
 func CheckNamespacePattern(patterns map[string]*regexp.Regexp) thriftcheck.Check {
-	return thriftcheck.NewCheck("namespace.patterns", func(c *thriftcheck.C, ns *ast.Namespace) {
+	return newCheck("namespace.patterns", func(c *thriftcheck.C, ns *ast.Namespace) {
 		if re, ok := patterns[ns.Scope]; ok && !re.MatchString(ns.Name) {
 			c.Errorf(ns, "%q namespace must match %q", ns.Scope, re)
 		}
 	})
 }
+
+// CheckDuplicateNamespaceLanguage returns a thriftcheck.Check that reports an
+// error if a file declares a namespace for the same scope more than once.
+// Both declarations in each conflict are reported, and each message names the
+// line of the other one.
+func CheckDuplicateNamespaceLanguage() thriftcheck.Check {
+	return newCheck("namespace.duplicate.language", func(c *thriftcheck.C, p *ast.Program) {
+		seen := make(map[string]*ast.Namespace)
+		for _, header := range p.Headers {
+			ns, ok := header.(*ast.Namespace)
+			if !ok {
+				continue
+			}
+			if first, ok := seen[ns.Scope]; ok {
+				c.Errorf(first, "%q namespace %q conflicts with %q (line %d)", first.Scope, first.Name, ns.Name, ns.Line)
+				c.Errorf(ns, "%q namespace %q conflicts with %q (line %d)", ns.Scope, ns.Name, first.Name, first.Line)
+			} else {
+				seen[ns.Scope] = ns
+			}
+		}
+	})
+}
+
+// CheckRequiredNamespaces returns a thriftcheck.Check that reports an error if
+// a file doesn't declare a (non-empty) namespace for each of the given scopes.
+func CheckRequiredNamespaces(scopes []string) thriftcheck.Check {
+	return newCheck("namespace.required", func(c *thriftcheck.C, p *ast.Program) {
+		declared := make(map[string]*ast.Namespace)
+		for _, header := range p.Headers {
+			if ns, ok := header.(*ast.Namespace); ok {
+				declared[ns.Scope] = ns
+			}
+		}
+		for _, scope := range scopes {
+			ns, ok := declared[scope]
+			switch {
+			case !ok:
+				c.Errorf(p, "missing required %q namespace", scope)
+			case ns.Name == "":
+				c.Errorf(ns, "required %q namespace is empty", scope)
+			}
+		}
+	})
+}
+
+// CheckNamespaceMatchesPath returns a thriftcheck.Check that reports an error
+// if a file in a directory matched by one of the rules doesn't declare a
+// (non-empty) namespace for the rule's language. Each rule maps a directory
+// pattern to a language. Patterns without a slash match the names of any of
+// the file's parent directories (so "java" matches every file below a `java`
+// directory); other patterns match the parent directories' paths.
+func CheckNamespaceMatchesPath(rules map[string]string) thriftcheck.Check {
+	patterns := slices.Sorted(maps.Keys(rules))
+
+	return newCheck("namespace.path.match", func(c *thriftcheck.C, p *ast.Program) {
+		declared := make(map[string]bool)
+		for _, header := range p.Headers {
+			if ns, ok := header.(*ast.Namespace); ok && ns.Name != "" {
+				declared[ns.Scope] = true
+			}
+		}
+		for _, pattern := range patterns {
+			lang := rules[pattern]
+			if declared[lang] {
+				continue
+			}
+			if dir, ok := matchParentDir(pattern, c.Filename); ok {
+				c.Errorf(p, "missing %q namespace required for files in %s", lang, dir)
+				declared[lang] = true
+			}
+		}
+	})
+}
+
+// matchParentDir returns the closest parent directory of filename that
+// matches pattern, as described by CheckNamespaceMatchesPath.
+func matchParentDir(pattern, filename string) (string, bool) {
+	dir := filepath.Dir(filepath.Clean(filename))
+	for {
+		var ok bool
+		if strings.Contains(pattern, "/") {
+			ok = fnmatch.Match(pattern, filepath.ToSlash(dir), fnmatch.FNM_NOESCAPE|fnmatch.FNM_PATHNAME)
+		} else {
+			ok = fnmatch.Match(pattern, filepath.Base(dir), fnmatch.FNM_NOESCAPE)
+		}
+		if ok {
+			return dir, true
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", false
+		}
+		dir = parent
+	}
+}
+
+// CheckNoWildcardNamespace returns a thriftcheck.Check that warns when a
+// namespace uses the "*" (all languages) scope.
+func CheckNoWildcardNamespace() thriftcheck.Check {
+	return newCheck("namespace.wildcard", func(c *thriftcheck.C, ns *ast.Namespace) {
+		if ns.Scope == "*" {
+			c.Warningf(ns, "wildcard namespace %q should be declared per language", ns.Name)
+		}
+	})
+}
+
+// CheckNamespaceConsistencyAcrossIncludes returns a multi-file
+// thriftcheck.Check that warns when a file declares a namespace for one of
+// the given languages but a file that it includes (directly or indirectly)
+// doesn't, which leaves the generated code for that language split between
+// packages. Each message is reported on the include that leads to the file
+// that is missing the namespace. Included files are parsed once per run.
+// No languages disables the check.
+func CheckNamespaceConsistencyAcrossIncludes(langs []string) thriftcheck.Check {
+	type file struct {
+		scopes   map[string]bool
+		includes []string
+	}
+	files := make(map[string]*file)
+
+	load := func(c *thriftcheck.C, path string, dirs []string) *file {
+		key := canonicalPath(path)
+		if f, ok := files[key]; ok {
+			return f
+		}
+		// Unreadable files are remembered (as nil) so that they're only
+		// tried once; the linter reports them.
+		files[key] = nil
+		p, err := c.Included(path)
+		if err != nil {
+			return nil
+		}
+		f := &file{scopes: make(map[string]bool)}
+		dirs = append([]string{filepath.Dir(path)}, dirs...)
+		for _, h := range p.Headers {
+			switch h := h.(type) {
+			case *ast.Namespace:
+				if h.Name != "" {
+					f.scopes[h.Scope] = true
+				}
+			case *ast.Include:
+				if path, ok := thriftcheck.FindFile(h.Path, dirs); ok {
+					f.includes = append(f.includes, path)
+				}
+			}
+		}
+		files[key] = f
+		return f
+	}
+
+	return newMultiFileCheck("namespace.include.consistency", func(c *thriftcheck.C, p *ast.Program) {
+		var required []string
+		for _, h := range p.Headers {
+			if ns, ok := h.(*ast.Namespace); ok && ns.Name != "" && slices.Contains(langs, ns.Scope) {
+				required = append(required, ns.Scope)
+			}
+		}
+		if len(required) == 0 {
+			return
+		}
+
+		seen := map[string]bool{canonicalPath(c.Filename): true}
+		for _, h := range p.Headers {
+			i, ok := h.(*ast.Include)
+			if !ok {
+				continue
+			}
+			path, ok := thriftcheck.FindFile(i.Path, c.Dirs)
+			if !ok {
+				continue
+			}
+			queue := []string{path}
+			for len(queue) > 0 {
+				path := queue[0]
+				queue = queue[1:]
+				if seen[canonicalPath(path)] {
+					continue
+				}
+				seen[canonicalPath(path)] = true
+
+				f := load(c, path, c.Dirs[1:])
+				if f == nil {
+					continue
+				}
+				for _, scope := range required {
+					if !f.scopes[scope] {
+						c.Warningf(i, "included file %q doesn't declare a %q namespace", path, scope)
+					}
+				}
+				queue = append(queue, f.includes...)
+			}
+		}
+	}, func(c *thriftcheck.C) {
+		clear(files)
+	})
+}
@@ -41,3 +41,183 @@ func TestCheckNamespacePattern(t *testing.T) {
 	})
 	RunTests(t, &check, tests)
 }
+
+func TestCheckDuplicateNamespaceLanguage(t *testing.T) {
+	tests := []Test{
+		{
+			node: &ast.Program{Headers: []ast.Header{
+				&ast.Namespace{Scope: "java", Name: "a.b", Line: 1},
+				&ast.Namespace{Scope: "py", Name: "a.b", Line: 2},
+			}},
+			want: []string{},
+		},
+		{
+			node: &ast.Program{Headers: []ast.Header{
+				&ast.Namespace{Scope: "java", Name: "a.b", Line: 1},
+				&ast.Namespace{Scope: "java", Name: "c.d", Line: 2},
+			}},
+			want: []string{
+				`t.thrift:1:1: error: "java" namespace "a.b" conflicts with "c.d" (line 2) (namespace.duplicate.language)`,
+				`t.thrift:2:1: error: "java" namespace "c.d" conflicts with "a.b" (line 1) (namespace.duplicate.language)`,
+			},
+		},
+		{
+			node: &ast.Program{Headers: []ast.Header{
+				&ast.Namespace{Scope: "java", Name: "a.b", Line: 1},
+				&ast.Namespace{Scope: "py", Name: "a.b", Line: 2},
+				&ast.Namespace{Scope: "java", Name: "c.d", Line: 3},
+				&ast.Namespace{Scope: "java", Name: "e.f", Line: 4},
+			}},
+			want: []string{
+				`t.thrift:1:1: error: "java" namespace "a.b" conflicts with "c.d" (line 3) (namespace.duplicate.language)`,
+				`t.thrift:3:1: error: "java" namespace "c.d" conflicts with "a.b" (line 1) (namespace.duplicate.language)`,
+				`t.thrift:1:1: error: "java" namespace "a.b" conflicts with "e.f" (line 4) (namespace.duplicate.language)`,
+				`t.thrift:4:1: error: "java" namespace "e.f" conflicts with "a.b" (line 1) (namespace.duplicate.language)`,
+			},
+		},
+	}
+
+	check := checks.CheckDuplicateNamespaceLanguage()
+	RunTests(t, &check, tests)
+}
+
+func TestCheckRequiredNamespaces(t *testing.T) {
+	tests := []Test{
+		{
+			node: &ast.Program{Headers: []ast.Header{
+				&ast.Namespace{Scope: "java", Name: "a.b", Line: 1},
+				&ast.Namespace{Scope: "py", Name: "a.b", Line: 2},
+			}},
+			want: []string{},
+		},
+		{
+			node: &ast.Program{Headers: []ast.Header{
+				&ast.Namespace{Scope: "java", Name: "a.b", Line: 1},
+			}},
+			want: []string{
+				`t.thrift:0:1: error: missing required "py" namespace (namespace.required)`,
+			},
+		},
+		{
+			node: &ast.Program{Headers: []ast.Header{
+				&ast.Namespace{Scope: "java", Name: "a.b", Line: 1},
+				&ast.Namespace{Scope: "py", Name: "", Line: 2},
+			}},
+			want: []string{
+				`t.thrift:2:1: error: required "py" namespace is empty (namespace.required)`,
+			},
+		},
+	}
+
+	check := checks.CheckRequiredNamespaces([]string{"java", "py"})
+	RunTests(t, &check, tests)
+}
+
+func TestCheckNoWildcardNamespace(t *testing.T) {
+	tests := []Test{
+		{
+			node: &ast.Namespace{Scope: "go", Name: "foo"},
+			want: []string{},
+		},
+		{
+			node: &ast.Namespace{Scope: "*", Name: "foo"},
+			want: []string{
+				`t.thrift:0:1: warning: wildcard namespace "foo" should be declared per language (namespace.wildcard)`,
+			},
+		},
+	}
+
+	check := checks.CheckNoWildcardNamespace()
+	RunTests(t, &check, tests)
+}
+
+func TestCheckNamespaceConsistencyAcrossIncludes(t *testing.T) {
+	tests := []MultiFileTest{
+		{
+			files: map[string]string{
+				"a.thrift": "namespace py a\nnamespace java a\ninclude \"b.thrift\"",
+				"b.thrift": "namespace py b\nnamespace java b\ninclude \"c.thrift\"",
+				"c.thrift": "namespace py c\nnamespace java c",
+			},
+			want: []string{},
+		},
+		{
+			files: map[string]string{
+				"a.thrift": "namespace py a\nnamespace go a\ninclude \"b.thrift\"",
+				"b.thrift": "namespace java b\ninclude \"c.thrift\"",
+				"c.thrift": "namespace py c",
+			},
+			want: []string{
+				`a.thrift:3:1: warning: included file "b.thrift" doesn't declare a "py" namespace (namespace.include.consistency)`,
+				`b.thrift:2:1: warning: included file "c.thrift" doesn't declare a "java" namespace (namespace.include.consistency)`,
+			},
+		},
+		{
+			files: map[string]string{
+				"a.thrift": "namespace py a\ninclude \"b.thrift\"",
+				"b.thrift": "namespace py b\ninclude \"c.thrift\"",
+				"c.thrift": "struct C {}",
+			},
+			want: []string{
+				`a.thrift:2:1: warning: included file "c.thrift" doesn't declare a "py" namespace (namespace.include.consistency)`,
+				`b.thrift:2:1: warning: included file "c.thrift" doesn't declare a "py" namespace (namespace.include.consistency)`,
+			},
+		},
+	}
+
+	check := checks.CheckNamespaceConsistencyAcrossIncludes([]string{"py", "java"})
+	RunMultiFileTests(t, &check, tests)
+}
+
+func TestCheckNamespaceMatchesPath(t *testing.T) {
+	java := &ast.Program{Headers: []ast.Header{
+		&ast.Namespace{Scope: "java", Name: "com.example", Line: 1},
+	}}
+	none := &ast.Program{}
+
+	tests := []Test{
+		{
+			name: "idl/java/users.thrift",
+			node: java,
+			want: []string{},
+		},
+		{
+			name: "idl/java/users.thrift",
+			node: none,
+			want: []string{
+				`idl/java/users.thrift:0:1: error: missing "java" namespace required for files in idl/java (namespace.path.match)`,
+			},
+		},
+		{
+			name: "idl/java/nested/users.thrift",
+			node: none,
+			want: []string{
+				`idl/java/nested/users.thrift:0:1: error: missing "java" namespace required for files in idl/java (namespace.path.match)`,
+			},
+		},
+		{
+			name: "idl/python/users.thrift",
+			node: none,
+			want: []string{
+				`idl/python/users.thrift:0:1: error: missing "py" namespace required for files in idl/python (namespace.path.match)`,
+			},
+		},
+		{
+			name: "python/users.thrift",
+			node: none,
+			want: []string{},
+		},
+		{
+			name: "users.thrift",
+			node: none,
+			want: []string{},
+		},
+	}
+
+	check := checks.CheckNamespaceMatchesPath(map[string]string{
+		"java":        "java",
+		"idl/py*":     "py",
+		"unused/dir*": "go",
+	})
+	RunTests(t, &check, tests)
+}
@@ -0,0 +1,95 @@
+// Copyright 2026 Pinterest
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checks
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/pinterest/thriftcheck"
+	"go.uber.org/thriftrw/ast"
+)
+
+// CheckQualifiedReferenceDepth returns a thriftcheck.Check that warns when a
+// type reference has more than max dot-separated qualifiers. A max value of 0
+// disables the check.
+func CheckQualifiedReferenceDepth(max int) thriftcheck.Check {
+	return newCheck("reference.qualification.depth", func(c *thriftcheck.C, ref ast.TypeReference) {
+		if max > 0 && strings.Count(ref.Name, ".") > max {
+			c.Warningf(ref, "type reference %q has more than %d qualifiers", ref.Name, max)
+		}
+	})
+}
+
+// CheckQualifyIncludedRefs returns a multi-file thriftcheck.Check that warns
+// when an unqualified type reference doesn't match a local definition but
+// does match a definition in one of the file's included files. Such
+// references rely on the included definitions being "flattened" into the
+// current scope, which Thrift doesn't guarantee.
+func CheckQualifyIncludedRefs() thriftcheck.Check {
+	type pendingRef struct {
+		loc      thriftcheck.Location
+		filename string
+	}
+	// included is a file that's found through an include, along with the
+	// prefix that qualifies references to its definitions.
+	type included struct{ path, prefix string }
+	includes := make(map[string][]included)
+	definitions := make(map[string]map[string]bool)
+	var pending []pendingRef
+
+	return newMultiFileCheck("reference.qualify.included", func(c *thriftcheck.C, ref ast.TypeReference) {
+		if strings.Contains(ref.Name, ".") || c.Resolve(ref.Name) != nil {
+			return
+		}
+		filename := filepath.Clean(c.Filename)
+		if _, ok := includes[filename]; !ok {
+			includes[filename] = []included{}
+			for _, h := range c.Program.Headers {
+				if i, ok := h.(*ast.Include); ok {
+					if path, ok := thriftcheck.FindFile(i.Path, c.Dirs); ok {
+						includes[filename] = append(includes[filename], included{path, includePrefix(i)})
+					}
+				}
+			}
+		}
+		pending = append(pending, pendingRef{loc: c.Locate(ref), filename: filename})
+	}, func(c *thriftcheck.C) {
+		defer clear(includes)
+		defer clear(definitions)
+		defer func() { pending = nil }()
+
+		for _, p := range pending {
+			name := p.loc.Node.(ast.TypeReference).Name
+			for _, include := range includes[p.filename] {
+				defs, ok := definitions[include.path]
+				if !ok {
+					defs = make(map[string]bool)
+					if program, err := c.Included(include.path); err == nil {
+						for _, def := range program.Definitions {
+							defs[def.Info().Name] = true
+						}
+					}
+					definitions[include.path] = defs
+				}
+				if defs[name] {
+					c.WarningfAt(p.loc, "type reference %q resolves to a definition in %q and should be written as %q",
+						name, filepath.Base(include.path), include.prefix+"."+name)
+					break
+				}
+			}
+		}
+	})
+}
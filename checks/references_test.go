@@ -0,0 +1,77 @@
+// Copyright 2026 Pinterest
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checks_test
+
+import (
+	"testing"
+
+	"github.com/pinterest/thriftcheck/checks"
+	"go.uber.org/thriftrw/ast"
+)
+
+func TestCheckQualifiedReferenceDepth(t *testing.T) {
+	tests := []Test{
+		{
+			node: ast.TypeReference{Name: "Bar"},
+			want: []string{},
+		},
+		{
+			node: ast.TypeReference{Name: "foo.Bar"},
+			want: []string{},
+		},
+		{
+			node: ast.TypeReference{Name: "foo.bar.Baz"},
+			want: []string{
+				`t.thrift:0:1: warning: type reference "foo.bar.Baz" has more than 1 qualifiers (reference.qualification.depth)`,
+			},
+		},
+	}
+
+	check := checks.CheckQualifiedReferenceDepth(1)
+	RunTests(t, &check, tests)
+}
+
+func TestCheckQualifyIncludedRefs(t *testing.T) {
+	tests := []MultiFileTest{
+		{
+			files: map[string]string{
+				"a.thrift": "include \"b.thrift\"\nstruct A {\n  1: optional b.B b\n  2: optional Local local\n}\nstruct Local {}",
+				"b.thrift": "struct B {}",
+			},
+			want: []string{},
+		},
+		{
+			files: map[string]string{
+				"a.thrift":        "include \"nested/b.thrift\"\nstruct A {\n  1: optional B b\n  2: optional Unknown u\n}",
+				"nested/b.thrift": "struct B {}",
+			},
+			want: []string{
+				`a.thrift:3:15: warning: type reference "B" resolves to a definition in "b.thrift" and should be written as "b.B" (reference.qualify.included)`,
+			},
+		},
+		{
+			files: map[string]string{
+				"a.thrift": "include t \"b.thrift\"\nstruct A {\n  1: optional B b\n  2: optional t.B qualified\n}",
+				"b.thrift": "struct B {}",
+			},
+			want: []string{
+				`a.thrift:3:15: warning: type reference "B" resolves to a definition in "b.thrift" and should be written as "t.B" (reference.qualify.included)`,
+			},
+		},
+	}
+
+	check := checks.CheckQualifyIncludedRefs()
+	RunMultiFileTests(t, &check, tests)
+}
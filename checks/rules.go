@@ -0,0 +1,107 @@
+// Copyright 2026 Pinterest
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checks
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"slices"
+
+	"github.com/kkyr/fig"
+	"github.com/pinterest/thriftcheck"
+	"go.uber.org/thriftrw/ast"
+)
+
+// Rule is a declarative check definition. Exactly one of its predicates must
+// be set.
+type Rule struct {
+	Name     string               `fig:"name" validate:"required"`
+	Severity thriftcheck.Severity `fig:"severity"`
+	Field    *FieldRule           `fig:"field"`
+	Struct   *StructRule          `fig:"struct"`
+}
+
+// FieldRule requires fields whose names match Name to have one of the given
+// Types.
+type FieldRule struct {
+	Name  *regexp.Regexp           `fig:"name" validate:"required"`
+	Types []thriftcheck.ThriftType `fig:"types" validate:"required"`
+}
+
+// StructRule requires structs whose names match Name to declare all of the
+// given Fields.
+type StructRule struct {
+	Name   *regexp.Regexp `fig:"name" validate:"required"`
+	Fields []string       `fig:"fields" validate:"required"`
+}
+
+// LoadRules loads a file of declarative rules and compiles them into checks.
+// The file can be formatted as TOML, JSON, or YAML based on its extension.
+func LoadRules(filename string) (thriftcheck.Checks, error) {
+	var file struct {
+		Rules []Rule `fig:"rules"`
+	}
+	if err := fig.Load(&file, fig.UseStrict(), fig.File(filepath.Base(filename)), fig.Dirs(filepath.Dir(filename))); err != nil {
+		return nil, fmt.Errorf("%s: %w", filename, err)
+	}
+
+	checks := make(thriftcheck.Checks, 0, len(file.Rules))
+	for _, rule := range file.Rules {
+		check, err := rule.Check()
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", filename, err)
+		}
+		checks = append(checks, check)
+	}
+	return checks, nil
+}
+
+// Check compiles the rule into a thriftcheck.Check.
+func (r Rule) Check() (thriftcheck.Check, error) {
+	switch {
+	case r.Field != nil && r.Struct == nil:
+		return thriftcheck.NewCheck(r.Name, func(c *thriftcheck.C, f *ast.Field) {
+			if !r.Field.Name.MatchString(f.Name) {
+				return
+			}
+			if ok, name := c.IsTypeAllowed(f.Type, r.Field.Types, nil); !ok {
+				r.report(c, f, "field %q type %q must be one of %v", f.Name, name, r.Field.Types)
+			}
+		}), nil
+
+	case r.Struct != nil && r.Field == nil:
+		return thriftcheck.NewCheck(r.Name, func(c *thriftcheck.C, s *ast.Struct) {
+			if !r.Struct.Name.MatchString(s.Name) {
+				return
+			}
+			for _, name := range r.Struct.Fields {
+				if !slices.ContainsFunc(s.Fields, func(f *ast.Field) bool { return f.Name == name }) {
+					r.report(c, s, "%q must have a field named %q", s.Name, name)
+				}
+			}
+		}), nil
+	}
+
+	return thriftcheck.Check{}, fmt.Errorf("rule %q must have exactly one predicate (field or struct)", r.Name)
+}
+
+func (r Rule) report(c *thriftcheck.C, n ast.Node, message string, args ...any) {
+	if r.Severity == thriftcheck.Error {
+		c.Errorf(n, message, args...)
+	} else {
+		c.Warningf(n, message, args...)
+	}
+}
@@ -0,0 +1,100 @@
+// Copyright 2026 Pinterest
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checks_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pinterest/thriftcheck/checks"
+	"go.uber.org/thriftrw/ast"
+)
+
+func TestLoadRules(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "rules.yaml")
+	err := os.WriteFile(filename, []byte(`
+rules:
+  - name: rules.id.type
+    severity: error
+    field:
+      name: "_id$"
+      types: ["i64"]
+  - name: rules.audit.fields
+    struct:
+      name: "Event$"
+      fields: ["created_at"]
+`), 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rules, err := checks.LoadRules(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rules) != 2 {
+		t.Fatalf("expected 2 rules, got %d", len(rules))
+	}
+
+	RunTests(t, &rules[0], []Test{
+		{
+			node: &ast.Field{Name: "user_id", Type: ast.BaseType{ID: ast.I64TypeID}},
+			want: []string{},
+		},
+		{
+			node: &ast.Field{Name: "user_id", Type: ast.BaseType{ID: ast.StringTypeID}},
+			want: []string{
+				`t.thrift:0:1: error: field "user_id" type "string" must be one of [i64] (rules.id.type)`,
+			},
+		},
+		{
+			node: &ast.Field{Name: "name", Type: ast.BaseType{ID: ast.StringTypeID}},
+			want: []string{},
+		},
+	})
+
+	RunTests(t, &rules[1], []Test{
+		{
+			node: &ast.Struct{Name: "LoginEvent", Fields: []*ast.Field{{Name: "created_at"}}},
+			want: []string{},
+		},
+		{
+			node: &ast.Struct{Name: "LoginEvent"},
+			want: []string{
+				`t.thrift:0:1: warning: "LoginEvent" must have a field named "created_at" (rules.audit.fields)`,
+			},
+		},
+		{
+			node: &ast.Struct{Name: "User"},
+			want: []string{},
+		},
+	})
+}
+
+func TestLoadRulesInvalid(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "rules.yaml")
+	err := os.WriteFile(filename, []byte(`
+rules:
+  - name: rules.empty
+`), 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := checks.LoadRules(filename); err == nil {
+		t.Errorf("expected an error for a rule without a predicate")
+	}
+}
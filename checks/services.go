@@ -0,0 +1,515 @@
+// Copyright 2026 Pinterest
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checks
+
+import (
+	"maps"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/pinterest/thriftcheck"
+	"go.uber.org/thriftrw/ast"
+)
+
+var defaultMutatorRegexp = regexp.MustCompile(`^(add|create|delete|insert|put|remove|set|update)([A-Z_]|$)`)
+
+var (
+	defaultReadVerbs  = []string{"count", "fetch", "find", "get", "list", "lookup", "query", "search"}
+	defaultWriteVerbs = []string{"add", "create", "delete", "insert", "put", "remove", "set", "update"}
+)
+
+// CheckVoidMutator returns a thriftcheck.Check that warns when a non-oneway
+// method whose name matches nameRegexp returns void. If nameRegexp is nil, a
+// default pattern matching common mutating verbs is used.
+func CheckVoidMutator(nameRegexp *regexp.Regexp) thriftcheck.Check {
+	if nameRegexp == nil {
+		nameRegexp = defaultMutatorRegexp
+	}
+
+	return newCheck("service.method.void.mutator", func(c *thriftcheck.C, f *ast.Function) {
+		if f.ReturnType == nil && !f.OneWay && nameRegexp.MatchString(f.Name) {
+			c.Warningf(f, "method %q returns void but appears to mutate state", f.Name)
+		}
+	})
+}
+
+// CheckNoBoolResult returns a thriftcheck.Check that warns when a method whose
+// name matches verbRegexp returns bool (including typedefs of it), which can't
+// say why an operation failed or what it did. If verbRegexp is nil, a default
+// pattern matching common mutating verbs is used.
+func CheckNoBoolResult(verbRegexp *regexp.Regexp) thriftcheck.Check {
+	if verbRegexp == nil {
+		verbRegexp = defaultMutatorRegexp
+	}
+
+	return newCheck("function.bool.result", func(c *thriftcheck.C, f *ast.Function) {
+		if f.ReturnType == nil || !verbRegexp.MatchString(f.Name) {
+			return
+		}
+		if b, ok := resolveType(c, f.ReturnType).(ast.BaseType); ok && b.ID == ast.BoolTypeID {
+			c.Warningf(f, "method %q returns bool but appears to mutate state; return a result type instead", f.Name)
+		}
+	})
+}
+
+// CheckListOrderingAnnotation returns a thriftcheck.Check that warns when a
+// method returns a list (including typedefs of one) without saying whether its
+// order is meaningful, using an `ordered` or `unordered` annotation on the
+// method or on the list type.
+func CheckListOrderingAnnotation() thriftcheck.Check {
+	hasOrdering := func(n ast.Node) bool {
+		_, ordered := annotation(n, "ordered")
+		_, unordered := annotation(n, "unordered")
+		return ordered || unordered
+	}
+
+	return newCheck("service.list.ordering", func(c *thriftcheck.C, f *ast.Function) {
+		if f.ReturnType == nil {
+			return
+		}
+		if l, ok := resolveType(c, f.ReturnType).(ast.ListType); ok && !hasOrdering(f) && !hasOrdering(l) {
+			c.Warningf(f, "method %q returns a list but isn't annotated as ordered or unordered", f.Name)
+		}
+	})
+}
+
+// CheckNoExceptionReturn returns a thriftcheck.Check that warns when a
+// function's return type resolves to an exception, including through
+// typedefs.
+func CheckNoExceptionReturn() thriftcheck.Check {
+	return newCheck("function.return.exception", func(c *thriftcheck.C, f *ast.Function) {
+		ref, ok := f.ReturnType.(ast.TypeReference)
+		if !ok {
+			return
+		}
+		if s, ok := resolveType(c, ref).(*ast.Struct); ok && s.Type == ast.ExceptionType {
+			c.Warningf(f, "method %q returns exception %q as its result", f.Name, ref.Name)
+		}
+	})
+}
+
+// CheckNoBareContainerArg returns a thriftcheck.Check that warns when a
+// function's only argument is a list, set, or map. Such functions are hard to
+// evolve compared to ones that accept a request struct.
+func CheckNoBareContainerArg() thriftcheck.Check {
+	return newCheck("function.arg.container", func(c *thriftcheck.C, f *ast.Function) {
+		if len(f.Parameters) != 1 {
+			return
+		}
+		p := f.Parameters[0]
+		switch resolveType(c, p.Type).(type) {
+		case ast.ListType, ast.SetType, ast.MapType:
+			c.Warningf(p, "method %q takes container argument %q; use a request struct instead", f.Name, p.Name)
+		}
+	})
+}
+
+// CheckPublicFunctionNamedTypes returns a thriftcheck.Check that warns when a
+// method of a service carrying the given annotation (`public` by default)
+// takes an argument or returns a value whose type is written inline as a
+// container or base type rather than as a reference to a named type. Public
+// APIs evolve by adding fields to their request and response types, which
+// raw types don't have.
+func CheckPublicFunctionNamedTypes(serviceAnnotation string) thriftcheck.Check {
+	if serviceAnnotation == "" {
+		serviceAnnotation = "public"
+	}
+
+	return newCheck("function.public.named.types", func(c *thriftcheck.C, s *ast.Service) {
+		if _, ok := annotation(s, serviceAnnotation); !ok {
+			return
+		}
+		for _, f := range s.Functions {
+			for _, p := range f.Parameters {
+				if _, ok := p.Type.(ast.TypeReference); !ok {
+					c.Warningf(p, "method %q of %s service %q takes %s argument %q; use a named type instead", f.Name, serviceAnnotation, s.Name, p.Type, p.Name)
+				}
+			}
+			if _, ok := f.ReturnType.(ast.TypeReference); f.ReturnType != nil && !ok {
+				c.Warningf(f, "method %q of %s service %q returns %s; use a named type instead", f.Name, serviceAnnotation, s.Name, f.ReturnType)
+			}
+		}
+	})
+}
+
+// CheckMethodIDAnnotation returns a thriftcheck.Check that reports an error
+// when a service method doesn't have a numeric `methodId` annotation, or when
+// two methods in the same service share a value. Gateways that route requests
+// by these IDs depend on them being unique and stable.
+func CheckMethodIDAnnotation() thriftcheck.Check {
+	return newCheck("service.method.id", func(c *thriftcheck.C, s *ast.Service) {
+		seen := make(map[int]*ast.Function, len(s.Functions))
+		for _, f := range s.Functions {
+			value, ok := annotation(f, "methodId")
+			if !ok {
+				c.Errorf(f, "method %q is missing a methodId annotation", f.Name)
+				continue
+			}
+			id, err := strconv.Atoi(strings.TrimSpace(value))
+			if err != nil || id < 0 {
+				c.Errorf(f, "method %q has an invalid methodId %q", f.Name, value)
+				continue
+			}
+			if first, ok := seen[id]; ok {
+				c.Errorf(f, "method %q reuses methodId %d of %q", f.Name, id, first.Name)
+				continue
+			}
+			seen[id] = f
+		}
+	})
+}
+
+var defaultVisibilities = []string{"public", "internal"}
+
+// CheckServiceVisibility returns a thriftcheck.Check that reports an error
+// when a service doesn't have a visibility annotation named key, or when its
+// value isn't one of the allowed visibilities. The key defaults to
+// `visibility`, and the allowed values default to "public" and "internal".
+func CheckServiceVisibility(key string, allowed []string) thriftcheck.Check {
+	if key == "" {
+		key = "visibility"
+	}
+	if len(allowed) == 0 {
+		allowed = defaultVisibilities
+	}
+
+	return newCheck("service.visibility", func(c *thriftcheck.C, s *ast.Service) {
+		value, ok := annotation(s, key)
+		if !ok {
+			c.Errorf(s, "service %q is missing a %s annotation", s.Name, key)
+			return
+		}
+		// Documentation tags are written as @visibility("public").
+		value = strings.Trim(strings.TrimSpace(value), `"`)
+		if !slices.Contains(allowed, value) {
+			c.Errorf(s, "service %q has %s %q, expected one of: %s", s.Name, key, value, strings.Join(allowed, ", "))
+		}
+	})
+}
+
+// CheckDeprecatedServiceMethods returns a thriftcheck.Check that warns when a
+// service is marked `deprecated` but one of its methods isn't, which usually
+// means that the method was added after the service was deprecated.
+func CheckDeprecatedServiceMethods() thriftcheck.Check {
+	return newCheck("service.deprecated.methods", func(c *thriftcheck.C, s *ast.Service) {
+		if _, ok := annotation(s, "deprecated"); !ok {
+			return
+		}
+		for _, f := range s.Functions {
+			if _, ok := annotation(f, "deprecated"); !ok {
+				c.Warningf(f, "method %q of deprecated service %q isn't marked deprecated", f.Name, s.Name)
+			}
+		}
+	})
+}
+
+// CheckInheritedMethodCaseClash returns a multi-file thriftcheck.Check that
+// reports an error when a service method's name differs only in case from a
+// method that it inherits through its chain of parent services, which makes
+// dispatch ambiguous in case-insensitive languages. Overriding a method with
+// exactly the same name is fine. Parent services are only followed through
+// the linted files.
+func CheckInheritedMethodCaseClash() thriftcheck.Check {
+	type serviceKey struct{ filename, name string }
+	type method struct {
+		name    string
+		service string
+		loc     thriftcheck.Location
+	}
+	type service struct {
+		parent  *serviceKey
+		methods []method
+	}
+	var keys []serviceKey
+	services := make(map[serviceKey]*service)
+
+	return newMultiFileCheck("service.method.inherited.case", func(c *thriftcheck.C, s *ast.Service) {
+		filename := canonicalPath(c.Filename)
+		svc := &service{}
+		for _, f := range s.Functions {
+			svc.methods = append(svc.methods, method{name: f.Name, service: s.Name, loc: c.Locate(f)})
+		}
+		if s.Parent != nil {
+			svc.parent = &serviceKey{filename, s.Parent.Name}
+			if prefix, name, ok := strings.Cut(s.Parent.Name, "."); ok {
+				svc.parent = nil
+				for _, h := range c.Program.Headers {
+					if i, ok := h.(*ast.Include); ok && includePrefix(i) == prefix {
+						if path, ok := thriftcheck.FindFile(i.Path, c.Dirs); ok {
+							svc.parent = &serviceKey{canonicalPath(path), name}
+						}
+						break
+					}
+				}
+			}
+		}
+		key := serviceKey{filename, s.Name}
+		keys = append(keys, key)
+		services[key] = svc
+	}, func(c *thriftcheck.C) {
+		defer clear(services)
+		defer func() { keys = keys[:0] }()
+
+		for _, key := range keys {
+			svc := services[key]
+
+			// Collect the inherited methods by their lowercased names. The
+			// nearest parent's method wins.
+			inherited := make(map[string]method)
+			seen := map[serviceKey]bool{key: true}
+			for parent := svc.parent; parent != nil && !seen[*parent]; {
+				seen[*parent] = true
+				p, ok := services[*parent]
+				if !ok {
+					break
+				}
+				for _, m := range p.methods {
+					lower := strings.ToLower(m.name)
+					if _, ok := inherited[lower]; !ok {
+						inherited[lower] = m
+					}
+				}
+				parent = p.parent
+			}
+
+			for _, m := range svc.methods {
+				lower := strings.ToLower(m.name)
+				if other, ok := inherited[lower]; ok && other.name != m.name {
+					c.ErrorfAt(m.loc, "method %q differs only in case from %q inherited from %q", m.name, other.name, other.service)
+				}
+			}
+		}
+	})
+}
+
+// CheckDuplicateServiceDefinition returns a multi-file thriftcheck.Check that
+// warns when a service with the same name is defined in more than one of the
+// linted files. Each later definition is reported along with the location of
+// the first one.
+func CheckDuplicateServiceDefinition() thriftcheck.Check {
+	locs := make(map[string][]thriftcheck.Location)
+
+	return newMultiFileCheck("service.duplicate", func(c *thriftcheck.C, s *ast.Service) {
+		locs[s.Name] = append(locs[s.Name], c.Locate(s))
+	}, func(c *thriftcheck.C) {
+		for _, name := range slices.Sorted(maps.Keys(locs)) {
+			first := locs[name][0]
+			for _, loc := range locs[name][1:] {
+				if canonicalPath(loc.Filename) != canonicalPath(first.Filename) {
+					c.WarningfAt(loc, "service %q is also defined in %s (line %d)", name, first.Filename, first.Pos.Line)
+				}
+			}
+		}
+		clear(locs)
+	})
+}
+
+// CheckThrowsDocumented returns a thriftcheck.Check that warns when a method
+// declares exceptions that aren't mentioned in its documentation comment. An
+// exception is considered documented if either its field name or its type
+// name appears in the comment as a whole word.
+func CheckThrowsDocumented() thriftcheck.Check {
+	return newCheck("service.throws.doc", func(c *thriftcheck.C, f *ast.Function) {
+		for _, e := range f.Exceptions {
+			if mentions(f.Doc, e.Name) {
+				continue
+			}
+			if ref, ok := e.Type.(ast.TypeReference); ok && mentions(f.Doc, typeBaseName(ref.Name)) {
+				continue
+			}
+			c.Warningf(e, "method %q doesn't document exception %q", f.Name, e.Name)
+		}
+	})
+}
+
+// mentions reports whether doc contains name as a whole word, so that a name
+// that only appears as part of a longer one (e.g. "err" in "error") doesn't
+// count.
+func mentions(doc, name string) bool {
+	return regexp.MustCompile(`\b` + regexp.QuoteMeta(name) + `\b`).MatchString(doc)
+}
+
+// typeBaseName returns a type name without its include qualifier.
+func typeBaseName(name string) string {
+	if i := strings.LastIndex(name, "."); i >= 0 {
+		return name[i+1:]
+	}
+	return name
+}
+
+// CheckResultStructComplexity returns a thriftcheck.Check that warns when a
+// function declares more than maxExceptions exceptions in its throws clause.
+// A maxExceptions value of 0 disables the check.
+func CheckResultStructComplexity(maxExceptions int) thriftcheck.Check {
+	return newCheck("function.result.complexity", func(c *thriftcheck.C, f *ast.Function) {
+		if maxExceptions > 0 && len(f.Exceptions) > maxExceptions {
+			c.Warningf(f, "method %q declares %d exceptions (more than %d)", f.Name, len(f.Exceptions), maxExceptions)
+		}
+	})
+}
+
+// CheckServiceCQRS returns a thriftcheck.Check that warns when a service
+// contains both read and write methods, as determined by the leading verb of
+// each method's name. Empty verb lists use a default set of common verbs.
+//
+// This check only applies to services annotated with `cqrs`.
+func CheckServiceCQRS(readVerbs, writeVerbs []string) thriftcheck.Check {
+	if len(readVerbs) == 0 {
+		readVerbs = defaultReadVerbs
+	}
+	if len(writeVerbs) == 0 {
+		writeVerbs = defaultWriteVerbs
+	}
+	readRegexp := verbRegexp(readVerbs)
+	writeRegexp := verbRegexp(writeVerbs)
+
+	return newCheck("service.cqrs", func(c *thriftcheck.C, s *ast.Service) {
+		if _, ok := annotation(s, "cqrs"); !ok {
+			return
+		}
+
+		var read, write *ast.Function
+		for _, f := range s.Functions {
+			if read == nil && readRegexp.MatchString(f.Name) {
+				read = f
+			} else if write == nil && writeRegexp.MatchString(f.Name) {
+				write = f
+			}
+		}
+		if read != nil && write != nil {
+			c.Warningf(s, "service %q mixes read (%q) and write (%q) methods", s.Name, read.Name, write.Name)
+		}
+	})
+}
+
+func verbRegexp(verbs []string) *regexp.Regexp {
+	quoted := make([]string, len(verbs))
+	for i, verb := range verbs {
+		quoted[i] = regexp.QuoteMeta(verb)
+	}
+	return regexp.MustCompile(`^(` + strings.Join(quoted, "|") + `)([A-Z_]|$)`)
+}
+
+// CheckServiceResourceCohesion returns a thriftcheck.Check that warns when a
+// service's methods act on more than maxNouns distinct resources, which
+// suggests that the service should be split. Each method's resource is the
+// trailing word of its name (like "user" for both getUser and listUsers).
+// Single-word method names don't name a resource. A maxNouns value of 0
+// disables the check.
+func CheckServiceResourceCohesion(maxNouns int) thriftcheck.Check {
+	return newCheck("service.cohesion", func(c *thriftcheck.C, s *ast.Service) {
+		if maxNouns <= 0 {
+			return
+		}
+
+		nouns := make(map[string]bool)
+		for _, f := range s.Functions {
+			if noun, ok := resourceNoun(f.Name); ok {
+				nouns[noun] = true
+			}
+		}
+		if len(nouns) > maxNouns {
+			c.Warningf(s, "service %q spans %d resources (%s), more than %d; consider splitting it",
+				s.Name, len(nouns), strings.Join(slices.Sorted(maps.Keys(nouns)), ", "), maxNouns)
+		}
+	})
+}
+
+var trailingWordRegexp = regexp.MustCompile(`[A-Z]?[a-z0-9]+$|[A-Z]+$`)
+
+// resourceNoun returns the trailing word of a camelCase or snake_case method
+// name, lowercased and (naively) singularized. It reports false for names
+// that consist of a single word.
+func resourceNoun(name string) (string, bool) {
+	if i := strings.LastIndex(name, "_"); i >= 0 {
+		name = name[i+1:]
+	} else if loc := trailingWordRegexp.FindStringIndex(name); loc == nil || loc[0] == 0 {
+		return "", false
+	} else {
+		name = name[loc[0]:]
+	}
+
+	noun := strings.ToLower(name)
+	switch {
+	case noun == "":
+		return "", false
+	case strings.HasSuffix(noun, "ies"):
+		noun = strings.TrimSuffix(noun, "ies") + "y"
+	case strings.HasSuffix(noun, "s") && !strings.HasSuffix(noun, "ss"):
+		noun = strings.TrimSuffix(noun, "s")
+	}
+	return noun, true
+}
+
+// CheckDefinitionOrder returns a thriftcheck.Check that warns when a service
+// references a type (or parent service) that is defined later in the same
+// file. Forward references are legal but make files harder to read from top
+// to bottom.
+func CheckDefinitionOrder() thriftcheck.Check {
+	return newCheck("definition.order", func(c *thriftcheck.C, p *ast.Program) {
+		index := make(map[string]int, len(p.Definitions))
+		for i, def := range p.Definitions {
+			index[def.Info().Name] = i
+		}
+
+		for i, def := range p.Definitions {
+			s, ok := def.(*ast.Service)
+			if !ok {
+				continue
+			}
+
+			var names []string
+			if s.Parent != nil {
+				names = append(names, s.Parent.Name)
+			}
+			for _, f := range s.Functions {
+				types := []ast.Type{f.ReturnType}
+				for _, field := range slices.Concat(f.Parameters, f.Exceptions) {
+					types = append(types, field.Type)
+				}
+				for _, t := range types {
+					names = appendTypeRefNames(names, t)
+				}
+			}
+
+			seen := make(map[string]bool)
+			for _, name := range names {
+				if j, ok := index[name]; ok && j > i && !seen[name] {
+					seen[name] = true
+					c.Warningf(s, "service %q references %q before it is defined (line %d)",
+						s.Name, name, p.Definitions[j].Info().Line)
+				}
+			}
+		}
+	})
+}
+
+// appendTypeRefNames appends the names of all of the type references within
+// t (including those nested in containers) to names.
+func appendTypeRefNames(names []string, t ast.Type) []string {
+	switch t := t.(type) {
+	case ast.TypeReference:
+		names = append(names, t.Name)
+	case ast.ListType:
+		names = appendTypeRefNames(names, t.ValueType)
+	case ast.SetType:
+		names = appendTypeRefNames(names, t.ValueType)
+	case ast.MapType:
+		names = appendTypeRefNames(appendTypeRefNames(names, t.KeyType), t.ValueType)
+	}
+	return names
+}
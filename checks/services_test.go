@@ -0,0 +1,700 @@
+// Copyright 2026 Pinterest
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checks_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/pinterest/thriftcheck/checks"
+	"go.uber.org/thriftrw/ast"
+)
+
+func TestCheckVoidMutator(t *testing.T) {
+	tests := []Test{
+		{
+			node: &ast.Function{Name: "createUser", ReturnType: ast.TypeReference{Name: "User"}},
+			want: []string{},
+		},
+		{
+			node: &ast.Function{Name: "createUser"},
+			want: []string{
+				`t.thrift:0:1: warning: method "createUser" returns void but appears to mutate state (service.method.void.mutator)`,
+			},
+		},
+		{
+			node: &ast.Function{Name: "createUser", OneWay: true},
+			want: []string{},
+		},
+		{
+			node: &ast.Function{Name: "getUser"},
+			want: []string{},
+		},
+		{
+			node: &ast.Function{Name: "settings"},
+			want: []string{},
+		},
+	}
+
+	check := checks.CheckVoidMutator(nil)
+	RunTests(t, &check, tests)
+}
+
+func TestCheckNoBoolResult(t *testing.T) {
+	boolType := ast.BaseType{ID: ast.BoolTypeID}
+	prog := &ast.Program{Definitions: []ast.Definition{
+		&ast.Typedef{Name: "Success", Type: boolType},
+	}}
+
+	tests := []Test{
+		{
+			node: &ast.Function{Name: "deleteUser", ReturnType: boolType},
+			want: []string{
+				`t.thrift:0:1: warning: method "deleteUser" returns bool but appears to mutate state; return a result type instead (function.bool.result)`,
+			},
+		},
+		{
+			prog: prog,
+			node: &ast.Function{Name: "update_user", ReturnType: ast.TypeReference{Name: "Success"}},
+			want: []string{
+				`t.thrift:0:1: warning: method "update_user" returns bool but appears to mutate state; return a result type instead (function.bool.result)`,
+			},
+		},
+		{
+			node: &ast.Function{Name: "isActive", ReturnType: boolType},
+			want: []string{},
+		},
+		{
+			prog: prog,
+			node: &ast.Function{Name: "deleteUser", ReturnType: ast.TypeReference{Name: "DeleteUserResult"}},
+			want: []string{},
+		},
+		{
+			node: &ast.Function{Name: "deleteUser"},
+			want: []string{},
+		},
+	}
+
+	check := checks.CheckNoBoolResult(nil)
+	RunTests(t, &check, tests)
+}
+
+func TestCheckListOrderingAnnotation(t *testing.T) {
+	listType := ast.ListType{ValueType: ast.BaseType{ID: ast.I64TypeID}}
+	prog := &ast.Program{Definitions: []ast.Definition{
+		&ast.Typedef{Name: "UserIDs", Type: listType},
+		&ast.Typedef{Name: "RankedIDs", Type: ast.ListType{
+			ValueType:   ast.BaseType{ID: ast.I64TypeID},
+			Annotations: []*ast.Annotation{{Name: "ordered"}},
+		}},
+	}}
+
+	tests := []Test{
+		{
+			node: &ast.Function{Name: "listUsers", ReturnType: listType},
+			want: []string{
+				`t.thrift:0:1: warning: method "listUsers" returns a list but isn't annotated as ordered or unordered (service.list.ordering)`,
+			},
+		},
+		{
+			prog: prog,
+			node: &ast.Function{Name: "listUsers", ReturnType: ast.TypeReference{Name: "UserIDs"}},
+			want: []string{
+				`t.thrift:0:1: warning: method "listUsers" returns a list but isn't annotated as ordered or unordered (service.list.ordering)`,
+			},
+		},
+		{
+			node: &ast.Function{Name: "listUsers", ReturnType: listType, Doc: "Lists users, newest first.\n@ordered"},
+			want: []string{},
+		},
+		{
+			node: &ast.Function{Name: "listUsers", ReturnType: listType, Annotations: []*ast.Annotation{{Name: "unordered"}}},
+			want: []string{},
+		},
+		{
+			prog: prog,
+			node: &ast.Function{Name: "rankUsers", ReturnType: ast.TypeReference{Name: "RankedIDs"}},
+			want: []string{},
+		},
+		{
+			node: &ast.Function{Name: "getUserIDs", ReturnType: ast.SetType{ValueType: ast.BaseType{ID: ast.I64TypeID}}},
+			want: []string{},
+		},
+		{
+			node: &ast.Function{Name: "ping"},
+			want: []string{},
+		},
+	}
+
+	check := checks.CheckListOrderingAnnotation()
+	RunTests(t, &check, tests)
+}
+
+func TestCheckNoExceptionReturn(t *testing.T) {
+	prog := &ast.Program{Definitions: []ast.Definition{
+		&ast.Struct{Name: "User", Type: ast.StructType},
+		&ast.Struct{Name: "NotFound", Type: ast.ExceptionType},
+		&ast.Typedef{Name: "Missing", Type: ast.TypeReference{Name: "NotFound"}},
+		&ast.Typedef{Name: "Gone", Type: ast.TypeReference{Name: "Missing"}},
+	}}
+
+	tests := []Test{
+		{
+			prog: prog,
+			node: &ast.Function{Name: "getUser", ReturnType: ast.TypeReference{Name: "NotFound"}},
+			want: []string{
+				`t.thrift:0:1: warning: method "getUser" returns exception "NotFound" as its result (function.return.exception)`,
+			},
+		},
+		{
+			prog: prog,
+			node: &ast.Function{Name: "getUser", ReturnType: ast.TypeReference{Name: "Gone"}},
+			want: []string{
+				`t.thrift:0:1: warning: method "getUser" returns exception "Gone" as its result (function.return.exception)`,
+			},
+		},
+		{
+			prog: prog,
+			node: &ast.Function{Name: "getUser", ReturnType: ast.TypeReference{Name: "User"}},
+			want: []string{},
+		},
+		{
+			prog: prog,
+			node: &ast.Function{Name: "ping"},
+			want: []string{},
+		},
+	}
+
+	check := checks.CheckNoExceptionReturn()
+	RunTests(t, &check, tests)
+}
+
+func TestCheckResultStructComplexity(t *testing.T) {
+	exceptions := func(n int) []*ast.Field {
+		fields := make([]*ast.Field, n)
+		for i := range fields {
+			fields[i] = &ast.Field{ID: i + 1, Name: fmt.Sprintf("e%d", i+1)}
+		}
+		return fields
+	}
+
+	tests := []Test{
+		{
+			node: &ast.Function{Name: "getUser", Exceptions: exceptions(2)},
+			want: []string{},
+		},
+		{
+			node: &ast.Function{Name: "getUser", Exceptions: exceptions(3)},
+			want: []string{},
+		},
+		{
+			node: &ast.Function{Name: "getUser", Exceptions: exceptions(4)},
+			want: []string{
+				`t.thrift:0:1: warning: method "getUser" declares 4 exceptions (more than 3) (function.result.complexity)`,
+			},
+		},
+	}
+
+	check := checks.CheckResultStructComplexity(3)
+	RunTests(t, &check, tests)
+}
+
+func TestCheckServiceCQRS(t *testing.T) {
+	cqrs := []*ast.Annotation{{Name: "cqrs", Value: "true"}}
+	functions := func(names ...string) []*ast.Function {
+		fs := make([]*ast.Function, len(names))
+		for i, name := range names {
+			fs[i] = &ast.Function{Name: name}
+		}
+		return fs
+	}
+
+	tests := []Test{
+		{
+			node: &ast.Service{Name: "Users", Annotations: cqrs, Functions: functions("getUser", "listUsers")},
+			want: []string{},
+		},
+		{
+			node: &ast.Service{Name: "Users", Annotations: cqrs, Functions: functions("createUser", "deleteUser")},
+			want: []string{},
+		},
+		{
+			node: &ast.Service{Name: "Users", Annotations: cqrs, Functions: functions("getUser", "ping", "updateUser")},
+			want: []string{
+				`t.thrift:0:1: warning: service "Users" mixes read ("getUser") and write ("updateUser") methods (service.cqrs)`,
+			},
+		},
+		{
+			node: &ast.Service{Name: "Users", Doc: "@cqrs", Functions: functions("getUser", "updateUser")},
+			want: []string{
+				`t.thrift:0:1: warning: service "Users" mixes read ("getUser") and write ("updateUser") methods (service.cqrs)`,
+			},
+		},
+		{
+			node: &ast.Service{Name: "Users", Functions: functions("getUser", "updateUser")},
+			want: []string{},
+		},
+		{
+			node: &ast.Service{Name: "Users", Annotations: cqrs, Functions: functions("settings", "getterName")},
+			want: []string{},
+		},
+	}
+
+	check := checks.CheckServiceCQRS(nil, nil)
+	RunTests(t, &check, tests)
+
+	tests = []Test{
+		{
+			node: &ast.Service{Name: "Users", Annotations: cqrs, Functions: functions("getUser", "updateUser")},
+			want: []string{},
+		},
+		{
+			node: &ast.Service{Name: "Users", Annotations: cqrs, Functions: functions("readUser", "writeUser")},
+			want: []string{
+				`t.thrift:0:1: warning: service "Users" mixes read ("readUser") and write ("writeUser") methods (service.cqrs)`,
+			},
+		},
+	}
+
+	check = checks.CheckServiceCQRS([]string{"read"}, []string{"write"})
+	RunTests(t, &check, tests)
+}
+
+func TestCheckPublicFunctionNamedTypes(t *testing.T) {
+	service := func(doc string) *ast.Service {
+		return &ast.Service{Name: "Users", Doc: doc, Functions: []*ast.Function{
+			{Name: "getUser", Line: 2, ReturnType: ast.TypeReference{Name: "User"}, Parameters: []*ast.Field{
+				{ID: 1, Name: "request", Line: 2, Type: ast.TypeReference{Name: "GetUserRequest"}},
+			}},
+			{Name: "countUsers", Line: 3, ReturnType: ast.BaseType{ID: ast.I64TypeID}, Parameters: []*ast.Field{
+				{ID: 1, Name: "ids", Line: 3, Type: ast.ListType{ValueType: ast.BaseType{ID: ast.I64TypeID}}},
+			}},
+			{Name: "ping", Line: 4},
+		}}
+	}
+
+	tests := []Test{
+		{
+			node: &ast.Service{Name: "Users", Doc: "@public", Functions: service("").Functions[:1]},
+			want: []string{},
+		},
+		{
+			node: service("@public"),
+			want: []string{
+				`t.thrift:3:1: warning: method "countUsers" of public service "Users" takes list<i64> argument "ids"; use a named type instead (function.public.named.types)`,
+				`t.thrift:3:1: warning: method "countUsers" of public service "Users" returns i64; use a named type instead (function.public.named.types)`,
+			},
+		},
+		{
+			node: service(""),
+			want: []string{},
+		},
+	}
+
+	check := checks.CheckPublicFunctionNamedTypes("")
+	RunTests(t, &check, tests)
+}
+
+func TestCheckServiceResourceCohesion(t *testing.T) {
+	service := func(names ...string) *ast.Service {
+		s := &ast.Service{Name: "Users"}
+		for _, name := range names {
+			s.Functions = append(s.Functions, &ast.Function{Name: name})
+		}
+		return s
+	}
+
+	tests := []Test{
+		{
+			node: service("getUser", "listUsers", "delete_user", "ping", "getUserAddresses", "updateAddress"),
+			want: []string{},
+		},
+		{
+			node: service("getUser", "listPolicies", "createInvoice", "getHTTPRequest"),
+			want: []string{
+				`t.thrift:0:1: warning: service "Users" spans 4 resources (invoice, policy, request, user), more than 3; consider splitting it (service.cohesion)`,
+			},
+		},
+	}
+
+	check := checks.CheckServiceResourceCohesion(3)
+	RunTests(t, &check, tests)
+
+	tests = []Test{
+		{
+			node: service("getUser", "listPolicies", "createInvoice", "getHTTPRequest"),
+			want: []string{},
+		},
+	}
+
+	check = checks.CheckServiceResourceCohesion(0)
+	RunTests(t, &check, tests)
+}
+
+func TestCheckNoBareContainerArg(t *testing.T) {
+	prog := &ast.Program{Definitions: []ast.Definition{
+		&ast.Struct{Name: "ListUsersRequest", Type: ast.StructType},
+		&ast.Typedef{Name: "UserIDs", Type: ast.SetType{ValueType: ast.BaseType{ID: ast.I64TypeID}}},
+	}}
+
+	tests := []Test{
+		{
+			prog: prog,
+			node: &ast.Function{Name: "listUsers", Parameters: []*ast.Field{
+				{ID: 1, Name: "ids", Type: ast.ListType{ValueType: ast.BaseType{ID: ast.I64TypeID}}},
+			}},
+			want: []string{
+				`t.thrift:0:1: warning: method "listUsers" takes container argument "ids"; use a request struct instead (function.arg.container)`,
+			},
+		},
+		{
+			prog: prog,
+			node: &ast.Function{Name: "listUsers", Parameters: []*ast.Field{
+				{ID: 1, Name: "ids", Type: ast.TypeReference{Name: "UserIDs"}},
+			}},
+			want: []string{
+				`t.thrift:0:1: warning: method "listUsers" takes container argument "ids"; use a request struct instead (function.arg.container)`,
+			},
+		},
+		{
+			prog: prog,
+			node: &ast.Function{Name: "listUsers", Parameters: []*ast.Field{
+				{ID: 1, Name: "request", Type: ast.TypeReference{Name: "ListUsersRequest"}},
+			}},
+			want: []string{},
+		},
+		{
+			prog: prog,
+			node: &ast.Function{Name: "getUser", Parameters: []*ast.Field{
+				{ID: 1, Name: "id", Type: ast.BaseType{ID: ast.I64TypeID}},
+				{ID: 2, Name: "verbose", Type: ast.BaseType{ID: ast.BoolTypeID}},
+			}},
+			want: []string{},
+		},
+		{
+			prog: prog,
+			node: &ast.Function{Name: "ping"},
+			want: []string{},
+		},
+	}
+
+	check := checks.CheckNoBareContainerArg()
+	RunTests(t, &check, tests)
+}
+
+func TestCheckThrowsDocumented(t *testing.T) {
+	exceptions := []*ast.Field{
+		{ID: 1, Name: "notFound", Type: ast.TypeReference{Name: "errors.NotFoundException"}},
+		{ID: 2, Name: "invalid", Type: ast.TypeReference{Name: "InvalidRequest"}},
+	}
+
+	tests := []Test{
+		{
+			node: &ast.Function{Name: "ping"},
+			want: []string{},
+		},
+		{
+			node: &ast.Function{
+				Name:       "getUser",
+				Doc:        "Returns the user.\n\n@throws notFound if the user doesn't exist\n@throws InvalidRequest if the ID is malformed",
+				Exceptions: exceptions,
+			},
+			want: []string{},
+		},
+		{
+			node: &ast.Function{
+				Name:       "getUser",
+				Doc:        "Returns the user, or throws NotFoundException.",
+				Exceptions: exceptions,
+			},
+			want: []string{
+				`t.thrift:0:1: warning: method "getUser" doesn't document exception "invalid" (service.throws.doc)`,
+			},
+		},
+		{
+			node: &ast.Function{
+				Name:       "getUser",
+				Doc:        "Throws NotFoundExceptions when notFoundUser is missing, or an InvalidRequestError.",
+				Exceptions: exceptions,
+			},
+			want: []string{
+				`t.thrift:0:1: warning: method "getUser" doesn't document exception "notFound" (service.throws.doc)`,
+				`t.thrift:0:1: warning: method "getUser" doesn't document exception "invalid" (service.throws.doc)`,
+			},
+		},
+		{
+			node: &ast.Function{
+				Name:       "getUser",
+				Doc:        "Returns the user (throws e on error).",
+				Exceptions: []*ast.Field{{ID: 1, Name: "e", Type: ast.TypeReference{Name: "err"}}},
+			},
+			want: []string{},
+		},
+		{
+			node: &ast.Function{
+				Name:       "getUser",
+				Doc:        "Returns the user, or an error.",
+				Exceptions: []*ast.Field{{ID: 1, Name: "e", Type: ast.TypeReference{Name: "err"}}},
+			},
+			want: []string{
+				`t.thrift:0:1: warning: method "getUser" doesn't document exception "e" (service.throws.doc)`,
+			},
+		},
+		{
+			node: &ast.Function{Name: "getUser", Exceptions: exceptions},
+			want: []string{
+				`t.thrift:0:1: warning: method "getUser" doesn't document exception "notFound" (service.throws.doc)`,
+				`t.thrift:0:1: warning: method "getUser" doesn't document exception "invalid" (service.throws.doc)`,
+			},
+		},
+	}
+
+	check := checks.CheckThrowsDocumented()
+	RunTests(t, &check, tests)
+}
+
+func TestCheckDefinitionOrder(t *testing.T) {
+	user := &ast.Struct{Name: "User", Line: 1}
+	notFound := &ast.Struct{Name: "NotFound", Type: ast.ExceptionType, Line: 2}
+	base := &ast.Service{Name: "Base", Line: 3}
+	users := &ast.Service{
+		Name:   "Users",
+		Parent: &ast.ServiceReference{Name: "Base"},
+		Line:   4,
+		Functions: []*ast.Function{
+			{
+				Name:       "getUser",
+				ReturnType: ast.TypeReference{Name: "User"},
+				Parameters: []*ast.Field{{ID: 1, Name: "id", Type: ast.BaseType{ID: ast.I64TypeID}}},
+				Exceptions: []*ast.Field{{ID: 1, Name: "notFound", Type: ast.TypeReference{Name: "NotFound"}}},
+			},
+			{
+				Name:       "listUsers",
+				ReturnType: ast.ListType{ValueType: ast.TypeReference{Name: "User"}},
+				Parameters: []*ast.Field{{ID: 1, Name: "filter", Type: ast.TypeReference{Name: "shared.Filter"}}},
+			},
+		},
+	}
+
+	tests := []Test{
+		{
+			node: &ast.Program{Definitions: []ast.Definition{user, notFound, base, users}},
+			want: []string{},
+		},
+		{
+			node: &ast.Program{Definitions: []ast.Definition{users, base, user, notFound}},
+			want: []string{
+				`t.thrift:4:1: warning: service "Users" references "Base" before it is defined (line 3) (definition.order)`,
+				`t.thrift:4:1: warning: service "Users" references "User" before it is defined (line 1) (definition.order)`,
+				`t.thrift:4:1: warning: service "Users" references "NotFound" before it is defined (line 2) (definition.order)`,
+			},
+		},
+	}
+
+	check := checks.CheckDefinitionOrder()
+	RunTests(t, &check, tests)
+}
+
+func TestCheckMethodIDAnnotation(t *testing.T) {
+	methodID := func(name, id string) *ast.Function {
+		return &ast.Function{Name: name, Annotations: []*ast.Annotation{{Name: "methodId", Value: id}}}
+	}
+
+	tests := []Test{
+		{
+			node: &ast.Service{Name: "S", Functions: []*ast.Function{
+				methodID("a", "1"),
+				{Name: "b", Doc: "@methodId(2)"},
+			}},
+			want: []string{},
+		},
+		{
+			node: &ast.Service{Name: "S", Functions: []*ast.Function{
+				methodID("a", "1"),
+				{Name: "b"},
+			}},
+			want: []string{
+				`t.thrift:0:1: error: method "b" is missing a methodId annotation (service.method.id)`,
+			},
+		},
+		{
+			node: &ast.Service{Name: "S", Functions: []*ast.Function{
+				methodID("a", "1"),
+				methodID("b", "2"),
+				{Name: "c", Doc: "@methodId(1)"},
+			}},
+			want: []string{
+				`t.thrift:0:1: error: method "c" reuses methodId 1 of "a" (service.method.id)`,
+			},
+		},
+		{
+			node: &ast.Service{Name: "S", Functions: []*ast.Function{
+				methodID("a", "one"),
+				methodID("b", "-1"),
+			}},
+			want: []string{
+				`t.thrift:0:1: error: method "a" has an invalid methodId "one" (service.method.id)`,
+				`t.thrift:0:1: error: method "b" has an invalid methodId "-1" (service.method.id)`,
+			},
+		},
+	}
+
+	check := checks.CheckMethodIDAnnotation()
+	RunTests(t, &check, tests)
+}
+
+func TestCheckDeprecatedServiceMethods(t *testing.T) {
+	deprecated := []*ast.Annotation{{Name: "deprecated"}}
+
+	tests := []Test{
+		{
+			node: &ast.Service{Name: "S", Annotations: deprecated, Functions: []*ast.Function{
+				{Name: "a", Annotations: deprecated},
+				{Name: "b", Doc: "@deprecated use c"},
+			}},
+			want: []string{},
+		},
+		{
+			node: &ast.Service{Name: "S", Functions: []*ast.Function{
+				{Name: "a"},
+			}},
+			want: []string{},
+		},
+		{
+			node: &ast.Service{Name: "S", Doc: "@deprecated", Functions: []*ast.Function{
+				{Name: "a", Annotations: deprecated},
+				{Name: "b", Line: 3},
+			}},
+			want: []string{
+				`t.thrift:3:1: warning: method "b" of deprecated service "S" isn't marked deprecated (service.deprecated.methods)`,
+			},
+		},
+	}
+
+	check := checks.CheckDeprecatedServiceMethods()
+	RunTests(t, &check, tests)
+}
+
+func TestCheckServiceVisibility(t *testing.T) {
+	visibility := func(value string) []*ast.Annotation {
+		return []*ast.Annotation{{Name: "visibility", Value: value}}
+	}
+
+	tests := []Test{
+		{
+			node: &ast.Service{Name: "S", Annotations: visibility("public")},
+			want: []string{},
+		},
+		{
+			node: &ast.Service{Name: "S", Doc: `@visibility("internal")`},
+			want: []string{},
+		},
+		{
+			node: &ast.Service{Name: "S"},
+			want: []string{
+				`t.thrift:0:1: error: service "S" is missing a visibility annotation (service.visibility)`,
+			},
+		},
+		{
+			node: &ast.Service{Name: "S", Annotations: visibility("secret")},
+			want: []string{
+				`t.thrift:0:1: error: service "S" has visibility "secret", expected one of: public, internal (service.visibility)`,
+			},
+		},
+	}
+
+	check := checks.CheckServiceVisibility("", nil)
+	RunTests(t, &check, tests)
+
+	check = checks.CheckServiceVisibility("tier", []string{"partner"})
+	RunTests(t, &check, []Test{
+		{
+			node: &ast.Service{Name: "S", Doc: "@tier(partner)"},
+			want: []string{},
+		},
+		{
+			node: &ast.Service{Name: "S", Annotations: visibility("public")},
+			want: []string{
+				`t.thrift:0:1: error: service "S" is missing a tier annotation (service.visibility)`,
+			},
+		},
+	})
+}
+
+func TestCheckDuplicateServiceDefinition(t *testing.T) {
+	tests := []MultiFileTest{
+		{
+			files: map[string]string{
+				"a.thrift": "service UserService {}",
+				"b.thrift": "service AccountService {}",
+			},
+			want: []string{},
+		},
+		{
+			files: map[string]string{
+				"a.thrift": "service UserService {}",
+				"b.thrift": "\nservice UserService {}",
+				"c.thrift": "service AccountService {}",
+			},
+			want: []string{
+				`b.thrift:2:1: warning: service "UserService" is also defined in a.thrift (line 1) (service.duplicate)`,
+			},
+		},
+	}
+
+	check := checks.CheckDuplicateServiceDefinition()
+	RunMultiFileTests(t, &check, tests)
+}
+
+func TestCheckInheritedMethodCaseClash(t *testing.T) {
+	tests := []MultiFileTest{
+		{
+			files: map[string]string{
+				"a.thrift":    "include \"base.thrift\"\nservice A extends base.Base {\n  void getUser()\n  void listUsers()\n}",
+				"base.thrift": "service Base {\n  void getUser()\n}",
+			},
+			want: []string{},
+		},
+		{
+			files: map[string]string{
+				"a.thrift":    "include \"base.thrift\"\nservice A extends base.Base {\n  void getUser()\n}",
+				"base.thrift": "service Base {\n  void GetUser()\n}",
+			},
+			want: []string{
+				`a.thrift:3:3: error: method "getUser" differs only in case from "GetUser" inherited from "Base" (service.method.inherited.case)`,
+			},
+		},
+		{
+			files: map[string]string{
+				"a.thrift": "service Root {\n  void PING()\n}\nservice Middle extends Root {\n  void getUser()\n}\nservice Leaf extends Middle {\n  void ping()\n  void deleteUser()\n}",
+			},
+			want: []string{
+				`a.thrift:8:3: error: method "ping" differs only in case from "PING" inherited from "Root" (service.method.inherited.case)`,
+			},
+		},
+		{
+			files: map[string]string{
+				"a.thrift": "service A extends B {\n  void Ping()\n}\nservice B extends A {\n  void ping()\n}",
+			},
+			want: []string{
+				`a.thrift:2:3: error: method "Ping" differs only in case from "ping" inherited from "B" (service.method.inherited.case)`,
+				`a.thrift:5:3: error: method "ping" differs only in case from "Ping" inherited from "A" (service.method.inherited.case)`,
+			},
+		},
+	}
+
+	check := checks.CheckInheritedMethodCaseClash()
+	RunMultiFileTests(t, &check, tests)
+}
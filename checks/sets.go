@@ -22,7 +22,7 @@ import (
 // CheckSetValueType returns a thriftcheck.Check that checks if a `set<>` value
 // type is allowed.
 func CheckSetValueType(allowedTypes, disallowedTypes []thriftcheck.ThriftType) thriftcheck.Check {
-	return thriftcheck.NewCheck("set.value.type", func(c *thriftcheck.C, st ast.SetType) {
+	return newCheck("set.value.type", func(c *thriftcheck.C, st ast.SetType) {
 		if ok, name := c.IsTypeAllowed(st.ValueType, allowedTypes, disallowedTypes); !ok {
 			c.Errorf(st, "set value type %q is not allowed", name)
 		}
@@ -15,6 +15,8 @@
 package checks_test
 
 import (
+	"slices"
+	"strings"
 	"testing"
 
 	"github.com/pinterest/thriftcheck"
@@ -56,3 +58,82 @@ func TestCheckSetValueType(t *testing.T) {
 	check := checks.CheckSetValueType([]thriftcheck.ThriftType{enumType, stringType}, []thriftcheck.ThriftType{})
 	RunTests(t, &check, tests)
 }
+
+func TestCheckSetValueTypeReusedLinter(t *testing.T) {
+	check := checks.CheckSetValueType([]thriftcheck.ThriftType{ParseType(t, "enum")}, []thriftcheck.ThriftType{})
+	linter := thriftcheck.NewLinter(thriftcheck.Checks{check})
+
+	// The second run's symbol table must come from the new program, not the
+	// one that was linted under the same filename before.
+	for _, tt := range []struct {
+		src  string
+		want []string
+	}{
+		{"enum E { A }\nstruct S { 1: set<E> s }", []string{}},
+		{"struct E {}\nstruct S { 1: set<E> s }", []string{
+			`a.thrift:2:15: error: set value type "E" is not allowed (set.value.type)`,
+		}},
+	} {
+		msgs, err := linter.Lint(strings.NewReader(tt.src), "a.thrift")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		lines := []string{}
+		for _, m := range msgs {
+			lines = append(lines, m.String())
+		}
+		if !slices.Equal(lines, tt.want) {
+			t.Errorf("%q:\n- %v\n+ %v", tt.src, tt.want, lines)
+		}
+	}
+}
+
+func TestCheckSetValueTypeSeverity(t *testing.T) {
+	check := checks.CheckSetValueType([]thriftcheck.ThriftType{ParseType(t, "string")}, []thriftcheck.ThriftType{})
+	linter := thriftcheck.NewLinter(thriftcheck.Checks{check}, thriftcheck.WithCheckSeverities(map[string]thriftcheck.Severity{
+		"set.value.type": thriftcheck.Warning,
+	}))
+
+	msgs, err := linter.Lint(strings.NewReader("struct S { 1: set<set<string>> s }"), "t.thrift")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := `t.thrift:1:15: warning: set value type "set<string>" is not allowed (set.value.type)`
+	if len(msgs) != 1 || msgs[0].String() != want {
+		t.Errorf("expected [%s], got %v", want, msgs)
+	}
+}
+
+func TestCheckSetValueTypeIgnoreComment(t *testing.T) {
+	check := checks.CheckSetValueType([]thriftcheck.ThriftType{ParseType(t, "string")}, []thriftcheck.ThriftType{})
+	linter := thriftcheck.NewLinter(thriftcheck.Checks{check})
+
+	tests := []struct {
+		src  string
+		want int
+	}{
+		{
+			src:  "struct S {\n  1: set<set<string>> s // thriftcheck:ignore set.value.type\n}",
+			want: 0,
+		},
+		{
+			src:  "struct S {\n  // thriftcheck:ignore\n  1: set<set<string>> s\n}",
+			want: 0,
+		},
+		{
+			src:  "struct S {\n  // thriftcheck:ignore map.key.type\n  1: set<set<string>> s\n}",
+			want: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		msgs, err := linter.Lint(strings.NewReader(tt.src), "t.thrift")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(msgs) != tt.want {
+			t.Errorf("%s: expected %d messages, got %v", tt.src, tt.want, msgs)
+		}
+	}
+}
@@ -0,0 +1,444 @@
+// Copyright 2026 Pinterest
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checks
+
+import (
+	"cmp"
+	"fmt"
+	"maps"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/pinterest/thriftcheck"
+	"go.uber.org/thriftrw/ast"
+)
+
+// CheckPairedStructIDs returns a multi-file thriftcheck.Check that warns when
+// two structs whose names share a common prefix and end with the given pair
+// of suffixes (e.g. "CreateUserRequest" and "CreateUserResponse") declare
+// same-named fields with different IDs.
+func CheckPairedStructIDs(pairSuffixes [2]string) thriftcheck.Check {
+	type pairedStruct struct {
+		s      *ast.Struct
+		fields map[string]thriftcheck.Location
+	}
+	pairs := make(map[string]*[2]pairedStruct)
+
+	return newMultiFileCheck("struct.paired.ids", func(c *thriftcheck.C, s *ast.Struct) {
+		for i, suffix := range pairSuffixes {
+			if suffix == "" || !strings.HasSuffix(s.Name, suffix) {
+				continue
+			}
+			prefix := strings.TrimSuffix(s.Name, suffix)
+			if pairs[prefix] == nil {
+				pairs[prefix] = &[2]pairedStruct{}
+			}
+			fields := make(map[string]thriftcheck.Location, len(s.Fields))
+			for _, f := range s.Fields {
+				fields[f.Name] = c.Locate(f)
+			}
+			pairs[prefix][i] = pairedStruct{s: s, fields: fields}
+		}
+	}, func(c *thriftcheck.C) {
+		for _, prefix := range slices.Sorted(maps.Keys(pairs)) {
+			a, b := pairs[prefix][0], pairs[prefix][1]
+			if a.s == nil || b.s == nil {
+				continue
+			}
+			for _, f := range b.s.Fields {
+				if loc, ok := a.fields[f.Name]; ok && loc.Node.(*ast.Field).ID != f.ID {
+					c.WarningfAt(b.fields[f.Name], "field %q has ID %d in %q but ID %d in %q",
+						f.Name, f.ID, b.s.Name, loc.Node.(*ast.Field).ID, a.s.Name)
+				}
+			}
+		}
+		clear(pairs)
+	})
+}
+
+// CheckNoDefaultsInStableStructs returns a thriftcheck.Check that reports an
+// error if a field in a struct carrying the given annotation (`wire-stable`
+// by default) declares a default value.
+func CheckNoDefaultsInStableStructs(name string) thriftcheck.Check {
+	if name == "" {
+		name = "wire-stable"
+	}
+
+	return newCheck("struct.stable.no.default", func(c *thriftcheck.C, s *ast.Struct) {
+		if _, ok := annotation(s, name); !ok {
+			return
+		}
+		for _, f := range s.Fields {
+			if f.Default != nil {
+				c.Errorf(f, "field %q in %s struct %q must not declare a default value", f.Name, name, s.Name)
+			}
+		}
+	})
+}
+
+// CheckExceptionNoRequired returns a thriftcheck.Check that warns if an
+// exception has a "required" field.
+func CheckExceptionNoRequired() thriftcheck.Check {
+	return newCheck("exception.field.required", func(c *thriftcheck.C, s *ast.Struct) {
+		if s.Type != ast.ExceptionType {
+			return
+		}
+		for _, f := range s.Fields {
+			if f.Requiredness == ast.Required {
+				c.Warningf(f, `field %q (%d) in exception %q should be "optional" rather than "required"`, f.Name, f.ID, s.Name)
+			}
+		}
+	})
+}
+
+// CheckExceptionFieldDuplication returns a multi-file thriftcheck.Check that
+// warns when an exception redeclares a field (by name) that the base exception
+// already defines. The base is named by baseName, which can be qualified with
+// an include prefix (like "errors.BaseError"). It's found either among the
+// linted files or through the includes of a file being linted. An empty
+// baseName disables the check.
+func CheckExceptionFieldDuplication(baseName string) thriftcheck.Check {
+	type field struct {
+		exception string
+		name      string
+		loc       thriftcheck.Location
+	}
+	var base *ast.Struct
+	var fields []field
+
+	return newMultiFileCheck("exception.field.duplicate", func(c *thriftcheck.C, s *ast.Struct) {
+		if baseName == "" || s.Type != ast.ExceptionType {
+			return
+		}
+		if s.Name == typeBaseName(baseName) {
+			if base == nil {
+				base = s
+			}
+			return
+		}
+		if base == nil {
+			if b, ok := c.Resolve(baseName).(*ast.Struct); ok && b.Type == ast.ExceptionType {
+				base = b
+			}
+		}
+		for _, f := range s.Fields {
+			fields = append(fields, field{exception: s.Name, name: f.Name, loc: c.Locate(f)})
+		}
+	}, func(c *thriftcheck.C) {
+		defer func() { base, fields = nil, nil }()
+		if base == nil {
+			return
+		}
+
+		defined := make(map[string]bool, len(base.Fields))
+		for _, f := range base.Fields {
+			defined[f.Name] = true
+		}
+		for _, f := range fields {
+			if defined[f.name] {
+				c.WarningfAt(f.loc, "exception %q redeclares field %q from base exception %q", f.exception, f.name, base.Name)
+			}
+		}
+	})
+}
+
+// CheckUnionMigrationIDs returns a multi-file thriftcheck.Check that reports
+// an error if a union that was a struct in the baseline version of the linted
+// files doesn't preserve its fields' IDs. Baseline files are found by joining
+// baselineDir with each linted file's path, and are parsed through the run's
+// shared program cache. The struct is looked for in the union's own baseline
+// file first; if that file didn't define the name, a struct with the same
+// name in exactly one other linted file's baseline is used, so types that
+// moved between files while they were converted are checked too. An empty
+// baselineDir disables the check.
+func CheckUnionMigrationIDs(baselineDir string) thriftcheck.Check {
+	type field struct {
+		field *ast.Field
+		loc   thriftcheck.Location
+	}
+	type union struct {
+		name     string
+		filename string
+		fields   []field
+	}
+	var unions []union
+	var linted []string
+
+	return newMultiFileCheck("union.migration.ids", func(c *thriftcheck.C, n ast.Node) {
+		if baselineDir == "" {
+			return
+		}
+		switch n := n.(type) {
+		case *ast.Program:
+			linted = append(linted, filepath.Clean(c.Filename))
+		case *ast.Struct:
+			if n.Type != ast.UnionType {
+				return
+			}
+			u := union{name: n.Name, filename: filepath.Clean(c.Filename)}
+			for _, f := range n.Fields {
+				u.fields = append(u.fields, field{f, c.Locate(f)})
+			}
+			unions = append(unions, u)
+		}
+	}, func(c *thriftcheck.C) {
+		defer func() { unions, linted = nil, nil }()
+
+		// Index the baseline definitions by the file they were in, and the
+		// baseline structs by name alone. A name that was a struct in more
+		// than one file is ambiguous, so it's indexed as nil.
+		type definition struct{ filename, name string }
+		defined := make(map[definition]ast.Definition)
+		structs := make(map[string]*ast.Struct)
+		for _, filename := range linted {
+			program, _ := c.Included(filepath.Join(baselineDir, filename))
+			if program == nil {
+				continue
+			}
+			for _, def := range program.Definitions {
+				defined[definition{filename, def.Info().Name}] = def
+				if s, ok := def.(*ast.Struct); ok && s.Type == ast.StructType {
+					if _, ok := structs[s.Name]; ok {
+						structs[s.Name] = nil
+					} else {
+						structs[s.Name] = s
+					}
+				}
+			}
+		}
+
+		for _, u := range unions {
+			var baseline *ast.Struct
+			if def, ok := defined[definition{u.filename, u.name}]; ok {
+				baseline, _ = def.(*ast.Struct)
+			} else {
+				baseline = structs[u.name]
+			}
+			if baseline == nil || baseline.Type != ast.StructType {
+				continue
+			}
+
+			ids := make(map[string]int, len(baseline.Fields))
+			for _, f := range baseline.Fields {
+				ids[f.Name] = f.ID
+			}
+			for _, f := range u.fields {
+				if id, ok := ids[f.field.Name]; ok && id != f.field.ID {
+					c.ErrorfAt(f.loc, "field %q has ID %d in union %q but had ID %d when it was a struct", f.field.Name, f.field.ID, u.name, id)
+				}
+			}
+		}
+	})
+}
+
+// CheckDuplicatedFieldBlocks returns a multi-file thriftcheck.Check that
+// warns when the same ordered block of at least minFields fields appears in
+// at least minStructs structs, which suggests that they should share a
+// common struct instead. Fields are compared by name, type, and requiredness
+// but not by ID. A minFields value of 0 disables the check, and minStructs
+// values below 2 are treated as 2.
+func CheckDuplicatedFieldBlocks(minFields, minStructs int) thriftcheck.Check {
+	type fieldBlock struct {
+		s      *ast.Struct
+		fields []thriftcheck.Location
+		keys   []string
+	}
+	var structs []fieldBlock
+	minStructs = max(minStructs, 2)
+
+	return newMultiFileCheck("struct.duplicated.fields", func(c *thriftcheck.C, s *ast.Struct) {
+		if minFields <= 0 || len(s.Fields) < minFields {
+			return
+		}
+		b := fieldBlock{s: s}
+		for _, f := range s.Fields {
+			b.fields = append(b.fields, c.Locate(f))
+			b.keys = append(b.keys, fmt.Sprintf("%d %s %s", f.Requiredness, f.Type, f.Name))
+		}
+		structs = append(structs, b)
+	}, func(c *thriftcheck.C) {
+		// Count the structs that contain each block of minFields fields.
+		window := func(b fieldBlock, i int) string { return strings.Join(b.keys[i:i+minFields], "\n") }
+		counts := make(map[string]int)
+		for _, b := range structs {
+			seen := make(map[string]bool)
+			for i := 0; i+minFields <= len(b.keys); i++ {
+				if key := window(b, i); !seen[key] {
+					seen[key] = true
+					counts[key]++
+				}
+			}
+		}
+
+		// Report each run of overlapping repeated blocks once per struct.
+		for _, b := range structs {
+			for i := 0; i+minFields <= len(b.keys); {
+				n := counts[window(b, i)]
+				if n < minStructs {
+					i++
+					continue
+				}
+				end := i + minFields
+				for end < len(b.keys) && counts[window(b, end-minFields+1)] >= minStructs {
+					n = min(n, counts[window(b, end-minFields+1)])
+					end++
+				}
+				names := make([]string, 0, end-i)
+				for _, f := range b.s.Fields[i:end] {
+					names = append(names, f.Name)
+				}
+				c.WarningfAt(b.fields[i], "fields %s of struct %q are repeated in %d structs; consider a shared struct",
+					strings.Join(names, ", "), b.s.Name, n)
+				i = end
+			}
+		}
+		structs = nil
+	})
+}
+
+// CheckStructRoleSeparation returns a multi-file thriftcheck.Check that warns
+// when a struct is used both as the sole argument of a function (a request
+// struct) and as the type of a field of another struct, which couples the
+// shape of the request to the data model. References are resolved through
+// each file's includes, so the struct can be defined in any file.
+func CheckStructRoleSeparation() thriftcheck.Check {
+	type definition struct{ path, name string }
+	type argument struct {
+		loc      thriftcheck.Location
+		function string
+	}
+	type field struct{ parent, name string }
+	arguments := make(map[definition]argument)
+	fields := make(map[definition]field)
+
+	// lookup resolves a type reference to the struct that it names.
+	lookup := func(c *thriftcheck.C, t ast.Type) (definition, *ast.Struct, bool) {
+		ref, ok := t.(ast.TypeReference)
+		if !ok {
+			return definition{}, nil, false
+		}
+		sym, ok := c.Symbols().Lookup(ref.Name)
+		if !ok {
+			return definition{}, nil, false
+		}
+		s, ok := sym.Definition.(*ast.Struct)
+		if !ok {
+			return definition{}, nil, false
+		}
+		return definition{canonicalPath(sym.Filename), s.Name}, s, true
+	}
+
+	return newMultiFileCheck("struct.role.separation", func(c *thriftcheck.C, n ast.Node) {
+		switch n := n.(type) {
+		case *ast.Function:
+			if len(n.Parameters) != 1 {
+				return
+			}
+			def, s, ok := lookup(c, n.Parameters[0].Type)
+			if _, seen := arguments[def]; ok && !seen && s.Type == ast.StructType {
+				arguments[def] = argument{loc: c.Locate(n.Parameters[0]), function: n.Name}
+			}
+		case *ast.Struct:
+			self := definition{canonicalPath(c.Filename), n.Name}
+			for _, f := range n.Fields {
+				def, _, ok := lookup(c, f.Type)
+				if _, seen := fields[def]; ok && !seen && def != self {
+					fields[def] = field{parent: n.Name, name: f.Name}
+				}
+			}
+		}
+	}, func(c *thriftcheck.C) {
+		defer clear(arguments)
+		defer clear(fields)
+
+		defs := slices.SortedFunc(maps.Keys(arguments), func(a, b definition) int {
+			return cmp.Or(cmp.Compare(a.path, b.path), cmp.Compare(a.name, b.name))
+		})
+		for _, def := range defs {
+			if f, ok := fields[def]; ok {
+				arg := arguments[def]
+				c.WarningfAt(arg.loc, "struct %q is the sole argument of %q but also the type of field %q of %q; give the request its own struct",
+					def.name, arg.function, f.name, f.parent)
+			}
+		}
+	})
+}
+
+// CheckUnusedStruct returns a multi-file thriftcheck.Check that warns about
+// structs and unions that aren't referenced by any field, container, constant,
+// typedef, or function signature in the linted files or the files that they
+// include. Structs whose names match allowedRegexp, such as those that are
+// only used as top-level public API types, aren't reported.
+func CheckUnusedStruct(allowedRegexp *regexp.Regexp) thriftcheck.Check {
+	type definition struct{ path, name string }
+	defined := make(map[definition]thriftcheck.Location)
+	referenced := make(map[definition]bool)
+	linted := make(map[string]bool)
+
+	reference := func(symbols *thriftcheck.Symbols, ref ast.TypeReference) {
+		if sym, ok := symbols.Lookup(ref.Name); ok {
+			if _, ok := sym.Definition.(*ast.Struct); ok {
+				referenced[definition{canonicalPath(sym.Filename), typeBaseName(ref.Name)}] = true
+			}
+		}
+	}
+
+	return newMultiFileCheck("struct.unused", func(c *thriftcheck.C, n ast.Node) {
+		switch n := n.(type) {
+		case *ast.Program:
+			linted[filepath.Clean(c.Filename)] = true
+		case *ast.Struct:
+			if n.Type == ast.ExceptionType || (allowedRegexp != nil && allowedRegexp.MatchString(n.Name)) {
+				return
+			}
+			defined[definition{canonicalPath(c.Filename), n.Name}] = c.Locate(n)
+		case ast.TypeReference:
+			reference(c.Symbols(), n)
+		}
+	}, func(c *thriftcheck.C) {
+		defer clear(defined)
+		defer clear(referenced)
+		defer clear(linted)
+
+		// Follow the linted files' includes, so that references from included
+		// files that weren't linted themselves are counted too.
+		for _, filename := range includeClosure(c, slices.Sorted(maps.Keys(linted))...) {
+			program, _ := c.Included(filename)
+			if program == nil || linted[filename] {
+				continue
+			}
+			symbols := c.SymbolsFor(filename)
+			var visitor thriftcheck.VisitorFunc
+			visitor = func(w ast.Walker, n ast.Node) thriftcheck.VisitorFunc {
+				if ref, ok := n.(ast.TypeReference); ok {
+					reference(symbols, ref)
+				}
+				return visitor
+			}
+			ast.Walk(visitor, program)
+		}
+
+		defs := slices.SortedFunc(maps.Keys(defined), func(a, b definition) int {
+			return cmp.Or(cmp.Compare(a.path, b.path), cmp.Compare(a.name, b.name))
+		})
+		for _, def := range defs {
+			if !referenced[def] {
+				c.WarningfAt(defined[def], "struct %q isn't referenced by any field, constant, or function", def.name)
+			}
+		}
+	})
+}
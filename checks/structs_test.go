@@ -0,0 +1,461 @@
+// Copyright 2026 Pinterest
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checks_test
+
+import (
+	"maps"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/pinterest/thriftcheck"
+	"github.com/pinterest/thriftcheck/checks"
+	"go.uber.org/thriftrw/ast"
+)
+
+func TestCheckPairedStructIDs(t *testing.T) {
+	tests := []MultiFileTest{
+		{
+			files: map[string]string{
+				"a.thrift": `
+struct CreateUserRequest {
+	1: string name
+	2: i64 id
+}
+struct CreateUserResponse {
+	1: string name
+	2: i64 id
+	3: bool created
+}`,
+			},
+			want: []string{},
+		},
+		{
+			files: map[string]string{
+				"a.thrift": `
+struct CreateUserRequest {
+	1: string name
+	2: i64 id
+}`,
+				"b.thrift": `
+struct CreateUserResponse {
+	1: string name
+	3: i64 id
+}`,
+			},
+			want: []string{
+				`b.thrift:4:2: warning: field "id" has ID 3 in "CreateUserResponse" but ID 2 in "CreateUserRequest" (struct.paired.ids)`,
+			},
+		},
+		{
+			files: map[string]string{
+				"a.thrift": `
+struct CreateUserRequest {
+	1: string name
+}
+struct DeleteUserResponse {
+	2: string name
+}`,
+			},
+			want: []string{},
+		},
+	}
+
+	check := checks.CheckPairedStructIDs([2]string{"Request", "Response"})
+	RunMultiFileTests(t, &check, tests)
+}
+
+func TestCheckDuplicatedFieldBlocks(t *testing.T) {
+	tests := []MultiFileTest{
+		{
+			files: map[string]string{
+				"a.thrift": `
+struct User {
+	1: i64 id
+	2: i64 created_at
+	3: i64 updated_at
+	4: string name
+}
+struct Group {
+	1: string name
+	2: i64 id
+	3: i64 created_at
+	4: i64 updated_at
+}`,
+				"b.thrift": `
+struct Team {
+	5: i64 id
+	6: i64 created_at
+	7: i64 updated_at
+}`,
+			},
+			want: []string{
+				`a.thrift:3:2: warning: fields id, created_at, updated_at of struct "User" are repeated in 3 structs; consider a shared struct (struct.duplicated.fields)`,
+				`a.thrift:10:2: warning: fields id, created_at, updated_at of struct "Group" are repeated in 3 structs; consider a shared struct (struct.duplicated.fields)`,
+				`b.thrift:3:2: warning: fields id, created_at, updated_at of struct "Team" are repeated in 3 structs; consider a shared struct (struct.duplicated.fields)`,
+			},
+		},
+		{
+			files: map[string]string{
+				"a.thrift": `
+struct User {
+	1: i64 id
+	2: i64 created_at
+	3: i64 updated_at
+}
+struct Group {
+	1: i64 id
+	2: i64 created_at
+	3: string updated_at
+}
+struct Team {
+	1: i64 id
+	2: i64 created_at
+	3: optional i64 updated_at
+}`,
+			},
+			want: []string{},
+		},
+		{
+			files: map[string]string{
+				"a.thrift": `
+struct User {
+	1: i64 id
+	2: i64 created_at
+	3: i64 updated_at
+}
+struct Group {
+	1: i64 id
+	2: i64 created_at
+	3: i64 updated_at
+}`,
+			},
+			want: []string{},
+		},
+		{
+			files: map[string]string{
+				"a.thrift": `
+struct A {
+	1: i64 id
+	2: i64 created_at
+	3: i64 updated_at
+	4: i64 deleted_at
+}
+struct B {
+	1: i64 id
+	2: i64 created_at
+	3: i64 updated_at
+	4: i64 deleted_at
+}
+struct C {
+	1: i64 id
+	2: i64 created_at
+	3: i64 updated_at
+	4: i64 deleted_at
+}`,
+			},
+			want: []string{
+				`a.thrift:3:2: warning: fields id, created_at, updated_at, deleted_at of struct "A" are repeated in 3 structs; consider a shared struct (struct.duplicated.fields)`,
+				`a.thrift:9:2: warning: fields id, created_at, updated_at, deleted_at of struct "B" are repeated in 3 structs; consider a shared struct (struct.duplicated.fields)`,
+				`a.thrift:15:2: warning: fields id, created_at, updated_at, deleted_at of struct "C" are repeated in 3 structs; consider a shared struct (struct.duplicated.fields)`,
+			},
+		},
+	}
+
+	check := checks.CheckDuplicatedFieldBlocks(3, 3)
+	RunMultiFileTests(t, &check, tests)
+}
+
+func TestCheckExceptionNoRequired(t *testing.T) {
+	tests := []Test{
+		{
+			node: &ast.Struct{Name: "NotFound", Type: ast.ExceptionType, Fields: []*ast.Field{
+				{ID: 1, Name: "message", Requiredness: ast.Optional},
+				{ID: 2, Name: "code"},
+			}},
+			want: []string{},
+		},
+		{
+			node: &ast.Struct{Name: "NotFound", Type: ast.ExceptionType, Fields: []*ast.Field{
+				{ID: 1, Name: "message", Requiredness: ast.Optional},
+				{ID: 2, Name: "code", Requiredness: ast.Required, Line: 3},
+			}},
+			want: []string{
+				`t.thrift:3:1: warning: field "code" (2) in exception "NotFound" should be "optional" rather than "required" (exception.field.required)`,
+			},
+		},
+		{
+			node: &ast.Struct{Name: "User", Type: ast.StructType, Fields: []*ast.Field{
+				{ID: 1, Name: "id", Requiredness: ast.Required},
+			}},
+			want: []string{},
+		},
+	}
+
+	check := checks.CheckExceptionNoRequired()
+	RunTests(t, &check, tests)
+}
+
+func TestCheckExceptionFieldDuplication(t *testing.T) {
+	tests := []MultiFileTest{
+		{
+			files: map[string]string{
+				"a.thrift": "include \"b.thrift\"\nexception NotFound {\n  1: optional string resource\n}",
+				"b.thrift": "exception BaseError {\n  1: optional string message\n  2: optional i32 code\n}",
+			},
+			want: []string{},
+		},
+		{
+			files: map[string]string{
+				"a.thrift": "include \"b.thrift\"\nexception NotFound {\n  1: optional string message\n  2: optional string resource\n}",
+				"b.thrift": "exception BaseError {\n  1: optional string message\n  2: optional i32 code\n}",
+			},
+			want: []string{
+				`a.thrift:3:3: warning: exception "NotFound" redeclares field "message" from base exception "BaseError" (exception.field.duplicate)`,
+			},
+		},
+		{
+			files: map[string]string{
+				"a.thrift": "exception BaseError {\n  1: optional string message\n}\nexception Timeout {\n  1: optional string message\n}",
+			},
+			want: []string{
+				`a.thrift:5:3: warning: exception "Timeout" redeclares field "message" from base exception "BaseError" (exception.field.duplicate)`,
+			},
+		},
+	}
+
+	check := checks.CheckExceptionFieldDuplication("b.BaseError")
+	RunMultiFileTests(t, &check, tests)
+}
+
+func TestCheckNoDefaultsInStableStructs(t *testing.T) {
+	fields := func() []*ast.Field {
+		return []*ast.Field{
+			{Name: "id", Line: 2},
+			{Name: "state", Line: 3, Default: ast.ConstantInteger(1)},
+		}
+	}
+
+	tests := []Test{
+		{
+			node: &ast.Struct{Name: "Event", Doc: "@wire-stable", Fields: fields()},
+			want: []string{
+				`t.thrift:3:1: error: field "state" in wire-stable struct "Event" must not declare a default value (struct.stable.no.default)`,
+			},
+		},
+		{
+			node: &ast.Struct{Name: "Event", Doc: "@wire-stable", Fields: []*ast.Field{{Name: "id"}}},
+			want: []string{},
+		},
+		{
+			node: &ast.Struct{Name: "Event", Fields: fields()},
+			want: []string{},
+		},
+	}
+
+	check := checks.CheckNoDefaultsInStableStructs("")
+	RunTests(t, &check, tests)
+
+	tests = []Test{
+		{
+			node: &ast.Struct{Name: "Event", Annotations: []*ast.Annotation{{Name: "stable"}}, Fields: fields()},
+			want: []string{
+				`t.thrift:3:1: error: field "state" in stable struct "Event" must not declare a default value (struct.stable.no.default)`,
+			},
+		},
+		{
+			node: &ast.Struct{Name: "Event", Doc: "@wire-stable", Fields: fields()},
+			want: []string{},
+		},
+	}
+
+	check = checks.CheckNoDefaultsInStableStructs("stable")
+	RunTests(t, &check, tests)
+}
+
+func TestCheckUnionMigrationIDs(t *testing.T) {
+	// Baseline files are found by joining the baseline directory with the
+	// linted files' (absolute) paths, so they're written by hand rather than
+	// with RunMultiFileTests.
+	tests := []struct {
+		files     map[string]string
+		baselines map[string]string
+		want      []string
+	}{
+		{
+			files: map[string]string{
+				"t.thrift": "union Value {\n  1: string s\n  2: i64 i\n  3: bool b\n}",
+			},
+			baselines: map[string]string{
+				"t.thrift": "struct Value {\n  1: optional string s\n  2: optional i64 i\n}",
+			},
+			want: []string{},
+		},
+		{
+			files: map[string]string{
+				"t.thrift": "union Value {\n  1: string s\n  3: i64 i\n}\nunion Other {\n  2: string s\n}",
+			},
+			baselines: map[string]string{
+				"t.thrift": "struct Value {\n  1: optional string s\n  2: optional i64 i\n}\nunion Other {\n  1: string s\n}",
+			},
+			want: []string{
+				`t.thrift:3:3: error: field "i" has ID 3 in union "Value" but had ID 2 when it was a struct (union.migration.ids)`,
+			},
+		},
+		{
+			// The struct moved to another file while it was converted.
+			files: map[string]string{
+				"a.thrift": "struct Unrelated {}",
+				"b.thrift": "union Value {\n  3: i64 i\n}",
+			},
+			baselines: map[string]string{
+				"a.thrift": "struct Value {\n  1: optional string s\n  2: optional i64 i\n}",
+			},
+			want: []string{
+				`b.thrift:2:3: error: field "i" has ID 3 in union "Value" but had ID 2 when it was a struct (union.migration.ids)`,
+			},
+		},
+		{
+			// Files without baselines aren't checked.
+			files: map[string]string{
+				"missing.thrift": "union Value {\n  3: i64 i\n}",
+			},
+			want: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		dir := t.TempDir()
+		idl := filepath.Join(dir, "idl")
+		baselineDir := filepath.Join(dir, "baseline")
+		var filenames []string
+		for _, name := range slices.Sorted(maps.Keys(tt.files)) {
+			filenames = append(filenames, filepath.Join(idl, name))
+		}
+		write := func(filename, src string) {
+			if err := os.MkdirAll(filepath.Dir(filename), 0o755); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(filename, []byte(src), 0o644); err != nil {
+				t.Fatal(err)
+			}
+		}
+		for name, src := range tt.files {
+			write(filepath.Join(idl, name), src)
+		}
+		for name, src := range tt.baselines {
+			write(filepath.Join(baselineDir, idl, name), src)
+		}
+
+		linter := thriftcheck.NewLinter(thriftcheck.Checks{checks.CheckUnionMigrationIDs(baselineDir)})
+		msgs, err := linter.LintFiles(filenames)
+		if err != nil {
+			t.Fatal(err)
+		}
+		lines := make([]string, len(msgs))
+		for i, m := range msgs {
+			lines[i] = strings.ReplaceAll(m.String(), idl+string(filepath.Separator), "")
+		}
+		if !slices.Equal(lines, tt.want) {
+			t.Errorf("%v:\n- %v\n+ %v", tt.files, tt.want, lines)
+		}
+	}
+}
+
+func TestCheckStructRoleSeparation(t *testing.T) {
+	tests := []MultiFileTest{
+		{
+			files: map[string]string{
+				"a.thrift": "struct User {}\nstruct Team {\n  1: optional User owner\n}",
+				"b.thrift": "include \"a.thrift\"\nstruct CreateUserRequest {\n  1: optional a.User user\n}\nservice Users {\n  void createUser(1: CreateUserRequest request)\n  void addMember(1: a.User user, 2: i64 team)\n}",
+			},
+			want: []string{},
+		},
+		{
+			files: map[string]string{
+				"a.thrift": "struct User {}\nstruct Team {\n  1: optional User owner\n}",
+				"b.thrift": "include \"a.thrift\"\nservice Users {\n  void createUser(1: a.User user)\n}",
+			},
+			want: []string{
+				`b.thrift:3:19: warning: struct "User" is the sole argument of "createUser" but also the type of field "owner" of "Team"; give the request its own struct (struct.role.separation)`,
+			},
+		},
+		{
+			files: map[string]string{
+				"a.thrift": "struct Node {\n  1: optional Node child\n}\nservice Nodes {\n  void addNode(1: Node node)\n}",
+				"b.thrift": "struct User {}\nservice Users {\n  void createUser(1: User user)\n}",
+				"c.thrift": "struct User {}\nstruct Team {\n  1: optional User owner\n}",
+			},
+			want: []string{},
+		},
+	}
+
+	check := checks.CheckStructRoleSeparation()
+	RunMultiFileTests(t, &check, tests)
+}
+
+func TestCheckUnusedStruct(t *testing.T) {
+	tests := []MultiFileTest{
+		{
+			files: map[string]string{
+				"a.thrift": "struct User {}\nunion ID {\n  1: i64 number\n  2: string name\n}\nstruct Team {\n  1: optional list<User> members\n}\nexception NotFound {}",
+				"b.thrift": "include \"a.thrift\"\nconst a.ID ROOT = {\"number\": 1}\nservice Teams {\n  a.Team getTeam(1: i64 id) throws (1: a.NotFound notFound)\n}",
+			},
+			want: []string{},
+		},
+		{
+			files: map[string]string{
+				"a.thrift": "struct User {}\nstruct LegacyUser {}\nstruct PublicEvent {}",
+				"b.thrift": "include \"a.thrift\"\nstruct User {}\nservice Users {\n  a.User getUser(1: i64 id)\n}",
+			},
+			want: []string{
+				`a.thrift:2:1: warning: struct "LegacyUser" isn't referenced by any field, constant, or function (struct.unused)`,
+				`b.thrift:2:1: warning: struct "User" isn't referenced by any field, constant, or function (struct.unused)`,
+			},
+		},
+		{
+			// The library is linted without its consumer, but the consumer's
+			// references are found through an included file that uses it.
+			files: map[string]string{
+				"lib.thrift": "struct User {}\nstruct Team {}",
+				"app.thrift": "include \"api.thrift\"\nconst api.Request DEFAULT = {}",
+			},
+			unlinted: map[string]string{
+				"api.thrift": "include \"lib.thrift\"\nstruct Request {\n  1: optional lib.User user\n}",
+			},
+			want: []string{
+				`lib.thrift:2:1: warning: struct "Team" isn't referenced by any field, constant, or function (struct.unused)`,
+			},
+		},
+		{
+			// Consumers that aren't reachable from any linted file can't be seen.
+			files: map[string]string{
+				"lib.thrift": "struct User {}",
+			},
+			unlinted: map[string]string{
+				"app.thrift": "include \"lib.thrift\"\nconst lib.User DEFAULT = {}",
+			},
+			want: []string{
+				`lib.thrift:1:1: warning: struct "User" isn't referenced by any field, constant, or function (struct.unused)`,
+			},
+		},
+	}
+
+	check := checks.CheckUnusedStruct(regexp.MustCompile(`^Public`))
+	RunMultiFileTests(t, &check, tests)
+}
@@ -0,0 +1,143 @@
+// Copyright 2026 Pinterest
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checks
+
+import (
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/pinterest/thriftcheck"
+	"go.uber.org/thriftrw/ast"
+)
+
+// CheckTypedefConsistency returns a multi-file thriftcheck.Check that reports
+// an error when typedefs with the same name resolve to different target types
+// in different files that are linted together with one another: a linted
+// file and the files that it includes, directly or indirectly. Unrelated
+// files can define the same name differently, since their definitions are
+// never used together.
+func CheckTypedefConsistency() thriftcheck.Check {
+	type typedef struct {
+		name   string
+		loc    thriftcheck.Location
+		target string
+	}
+	paths := make(pathCache)
+	typedefs := make(map[string][]typedef)
+	var roots []string
+
+	return newMultiFileCheck("typedef.inconsistent", func(c *thriftcheck.C, n ast.Node) {
+		switch n := n.(type) {
+		case *ast.Program:
+			roots = append(roots, c.Filename)
+		case *ast.Typedef:
+			path := paths.canonical(c.Filename)
+			typedefs[path] = append(typedefs[path], typedef{
+				name:   n.Name,
+				loc:    c.Locate(n),
+				target: typeName(resolveType(c, n.Type)),
+			})
+		}
+	}, func(c *thriftcheck.C) {
+		defer clear(paths)
+		defer clear(typedefs)
+		defer func() { roots = nil }()
+
+		// A conflict between two files is reported once, even if they're
+		// both reachable from several roots.
+		type conflict struct{ name, a, b string }
+		reported := make(map[conflict]bool)
+		for _, root := range slices.Sorted(slices.Values(roots)) {
+			first := make(map[string]typedef)
+			for _, filename := range includeClosure(c, root) {
+				for _, t := range typedefs[paths.canonical(filename)] {
+					f, ok := first[t.name]
+					if !ok {
+						first[t.name] = t
+						continue
+					}
+					key := conflict{t.name, min(f.loc.Filename, t.loc.Filename), max(f.loc.Filename, t.loc.Filename)}
+					if t.loc.Filename != f.loc.Filename && t.target != f.target && !reported[key] {
+						reported[key] = true
+						c.ErrorfAt(t.loc, "typedef %q is defined as %q but as %q in %s (line %d)",
+							t.name, t.target, f.target, f.loc.Filename, f.loc.Pos.Line)
+					}
+				}
+			}
+		}
+	})
+}
+
+// baseTypeKeywords are the names of Thrift's base types.
+var baseTypeKeywords = []string{"binary", "bool", "byte", "double", "i16", "i32", "i64", "i8", "string"}
+
+// CheckTypedefNotKeyword returns a thriftcheck.Check that reports an error
+// when a typedef's name matches a base type keyword (such as "string" or
+// "I64"), ignoring case.
+func CheckTypedefNotKeyword() thriftcheck.Check {
+	return newCheck("typedef.name.keyword", func(c *thriftcheck.C, t *ast.Typedef) {
+		for _, keyword := range baseTypeKeywords {
+			if strings.EqualFold(t.Name, keyword) {
+				c.Errorf(t, "typedef %q collides with the base type %q", t.Name, keyword)
+				return
+			}
+		}
+	})
+}
+
+// CheckRepeatedInlineContainer returns a multi-file thriftcheck.Check that
+// warns when the same container type (e.g. map<string, list<i32>>) is used
+// inline as the type of at least minOccurrences struct fields rather than
+// being given a name with a typedef. A minOccurrences value of 0 disables the
+// check.
+func CheckRepeatedInlineContainer(minOccurrences int) thriftcheck.Check {
+	occurrences := make(map[string][]thriftcheck.Location)
+
+	return newMultiFileCheck("container.repeated.inline", func(c *thriftcheck.C, s *ast.Struct, f *ast.Field) {
+		if minOccurrences <= 0 {
+			return
+		}
+		switch f.Type.(type) {
+		case ast.MapType, ast.ListType, ast.SetType:
+			shape := f.Type.String()
+			occurrences[shape] = append(occurrences[shape], c.Locate(f))
+		}
+	}, func(c *thriftcheck.C) {
+		for _, shape := range slices.Sorted(maps.Keys(occurrences)) {
+			locs := occurrences[shape]
+			if len(locs) < minOccurrences {
+				continue
+			}
+			for _, loc := range locs {
+				c.WarningfAt(loc, "container type %q is used inline %d times; consider a typedef", shape, len(locs))
+			}
+		}
+		clear(occurrences)
+	})
+}
+
+// typeName returns a name for a (resolved) type that can be compared across
+// files.
+func typeName(n ast.Node) string {
+	switch n := n.(type) {
+	case ast.Type:
+		return n.String()
+	case ast.Definition:
+		return n.Info().Name
+	default:
+		return ""
+	}
+}
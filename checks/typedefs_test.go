@@ -0,0 +1,127 @@
+// Copyright 2026 Pinterest
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checks_test
+
+import (
+	"testing"
+
+	"github.com/pinterest/thriftcheck/checks"
+	"go.uber.org/thriftrw/ast"
+)
+
+func TestCheckTypedefConsistency(t *testing.T) {
+	tests := []MultiFileTest{
+		{
+			files: map[string]string{
+				"a.thrift": "typedef i64 Id",
+				"b.thrift": "typedef i64 Id",
+				"c.thrift": "typedef i64 UserId\ntypedef UserId Id",
+			},
+			want: []string{},
+		},
+		{
+			files: map[string]string{
+				"a.thrift": "include \"b.thrift\"\ninclude \"c.thrift\"\ntypedef i32 Id",
+				"b.thrift": "\ntypedef i64 Id",
+				"c.thrift": "typedef i32 Id",
+			},
+			want: []string{
+				`b.thrift:2:1: error: typedef "Id" is defined as "i64" but as "i32" in a.thrift (line 3) (typedef.inconsistent)`,
+			},
+		},
+		{
+			// Files that aren't included together can disagree.
+			files: map[string]string{
+				"a.thrift": "typedef i32 Id",
+				"b.thrift": "typedef i64 Id",
+			},
+			want: []string{},
+		},
+		{
+			// Files are linked through includes that weren't linted, and the
+			// conflict is only reported once, although both a.thrift and
+			// c.thrift reach it.
+			files: map[string]string{
+				"a.thrift": "include \"lib.thrift\"\ntypedef i32 Id",
+				"b.thrift": "typedef i64 Id",
+				"c.thrift": "include \"a.thrift\"",
+			},
+			unlinted: map[string]string{
+				"lib.thrift": "include \"b.thrift\"",
+			},
+			want: []string{
+				`b.thrift:1:1: error: typedef "Id" is defined as "i64" but as "i32" in a.thrift (line 2) (typedef.inconsistent)`,
+			},
+		},
+	}
+
+	check := checks.CheckTypedefConsistency()
+	RunMultiFileTests(t, &check, tests)
+}
+
+func TestCheckTypedefNotKeyword(t *testing.T) {
+	tests := []Test{
+		{
+			node: &ast.Typedef{Name: "BigInt", Type: ast.BaseType{ID: ast.I64TypeID}},
+			want: []string{},
+		},
+		{
+			node: &ast.Typedef{Name: "string", Type: ast.BaseType{ID: ast.I64TypeID}},
+			want: []string{
+				`t.thrift:0:1: error: typedef "string" collides with the base type "string" (typedef.name.keyword)`,
+			},
+		},
+		{
+			node: &ast.Typedef{Name: "I64", Type: ast.BaseType{ID: ast.I32TypeID}},
+			want: []string{
+				`t.thrift:0:1: error: typedef "I64" collides with the base type "i64" (typedef.name.keyword)`,
+			},
+		},
+	}
+
+	check := checks.CheckTypedefNotKeyword()
+	RunTests(t, &check, tests)
+}
+
+func TestCheckRepeatedInlineContainer(t *testing.T) {
+	tests := []MultiFileTest{
+		{
+			files: map[string]string{
+				"a.thrift": "struct A {\n  1: optional map<string, list<i32>> m\n}",
+				"b.thrift": "struct B {\n  1: optional map<string, list<i32>> m\n  2: optional list<string> l\n}",
+				"c.thrift": "typedef map<string, list<i32>> M\nstruct C {\n  1: optional M m\n}",
+			},
+			want: []string{},
+		},
+		{
+			files: map[string]string{
+				"a.thrift": "struct A {\n  1: optional map<string, list<i32>> m\n}",
+				"b.thrift": "struct B {\n  1: optional map<string, list<i32>> m\n  2: optional list<string> l\n}",
+				"c.thrift": "union C {\n  1: map<string, list<i32>> m\n  2: set<i32> s\n}",
+			},
+			want: []string{
+				`a.thrift:2:3: warning: container type "map<string, list<i32>>" is used inline 3 times; consider a typedef (container.repeated.inline)`,
+				`b.thrift:2:3: warning: container type "map<string, list<i32>>" is used inline 3 times; consider a typedef (container.repeated.inline)`,
+				`c.thrift:2:3: warning: container type "map<string, list<i32>>" is used inline 3 times; consider a typedef (container.repeated.inline)`,
+			},
+		},
+	}
+
+	check := checks.CheckRepeatedInlineContainer(3)
+	RunMultiFileTests(t, &check, tests)
+
+	disabled := checks.CheckRepeatedInlineContainer(0)
+	RunMultiFileTests(t, &disabled, []MultiFileTest{{files: tests[1].files, want: []string{}}})
+}
@@ -15,15 +15,241 @@
 package checks
 
 import (
+	"path/filepath"
+	"strings"
+
 	"github.com/pinterest/thriftcheck"
 	"go.uber.org/thriftrw/ast"
 )
 
 // CheckTypesDisallowed reports an error if a disallowed type is used.
 func CheckTypes(allowedTypes, disallowedTypes []thriftcheck.ThriftType) thriftcheck.Check {
-	return thriftcheck.NewCheck("types", func(c *thriftcheck.C, n ast.Node) {
+	return newCheck("types", func(c *thriftcheck.C, n ast.Node) {
 		if ok, name := c.IsTypeAllowed(n, allowedTypes, disallowedTypes); !ok {
 			c.Errorf(n, "type %q is not allowed", name)
 		}
 	})
 }
+
+// CheckTypeComplexityBudget returns a multi-file thriftcheck.Check that
+// warns when the total number of type nodes reachable from a struct (its
+// fields and their types, following references into other definitions,
+// including those in included files) exceeds maxNodes. Each definition is
+// only counted once, so recursive types are supported. References are
+// resolved through the run's shared symbol tables once every file has been
+// linted. A maxNodes value of 0 disables the check.
+func CheckTypeComplexityBudget(maxNodes int) thriftcheck.Check {
+	type root struct {
+		loc thriftcheck.Location
+		def *ast.Struct
+	}
+	var roots []root
+
+	return newMultiFileCheck("type.complexity.budget", func(c *thriftcheck.C, s *ast.Struct) {
+		if maxNodes > 0 {
+			roots = append(roots, root{c.Locate(s), s})
+		}
+	}, func(c *thriftcheck.C) {
+		defer func() { roots = nil }()
+
+		for _, r := range roots {
+			filename := filepath.Clean(r.loc.Filename)
+			counter := &complexityCounter{c: c, limit: maxNodes, seen: map[string]bool{filename + ":" + r.def.Name: true}}
+			counter.countStruct(filename, r.def)
+			if counter.nodes > maxNodes {
+				c.WarningfAt(r.loc, "%q exceeds the type complexity budget of %d nodes", r.def.Name, maxNodes)
+			}
+		}
+	})
+}
+
+// CheckTypeRecursion returns a multi-file thriftcheck.Check that reports an
+// error for each cycle of structs and typedefs that contain themselves by
+// value. Struct fields and typedefs that refer directly to another definition
+// are followed, including into included files; cycles are broken by a list,
+// set, or map, by an optional field, or by a union member, since those forms
+// are represented as references by the generated code.
+//
+// The error is reported once per cycle, at the definition that starts it, and
+// the message lists every type in the cycle.
+func CheckTypeRecursion() thriftcheck.Check {
+	graph := make(typeGraph)
+	locs := make(map[string]thriftcheck.Location)
+	names := make(map[string]string)
+
+	return newMultiFileCheck("type.recursion", func(c *thriftcheck.C, p *ast.Program) {
+		filename := canonicalPath(c.Filename)
+
+		// ref returns the vertex for a reference to a definition, resolving
+		// include-qualified names to the file that they were included from.
+		ref := func(t ast.Type) (string, bool) {
+			r, ok := t.(ast.TypeReference)
+			if !ok {
+				return "", false
+			}
+			prefix, name, ok := strings.Cut(r.Name, ".")
+			if !ok {
+				return filename + ":" + r.Name, true
+			}
+			for _, h := range p.Headers {
+				if i, ok := h.(*ast.Include); ok && includePrefix(i) == prefix {
+					if path, ok := thriftcheck.FindFile(i.Path, c.Dirs); ok {
+						return canonicalPath(path) + ":" + name, true
+					}
+					break
+				}
+			}
+			return "", false
+		}
+
+		for _, def := range p.Definitions {
+			key := filename + ":" + def.Info().Name
+			switch def := def.(type) {
+			case *ast.Struct:
+				for _, f := range def.Fields {
+					if f.Requiredness == ast.Optional || def.Type == ast.UnionType {
+						continue
+					}
+					if target, ok := ref(f.Type); ok {
+						graph[key] = append(graph[key], target)
+					}
+				}
+			case *ast.Typedef:
+				if target, ok := ref(def.Type); ok {
+					graph[key] = append(graph[key], target)
+				}
+			default:
+				continue
+			}
+			locs[key] = c.Locate(def)
+			names[key] = def.Info().Name
+		}
+	}, func(c *thriftcheck.C) {
+		defer clear(graph)
+		defer clear(locs)
+		defer clear(names)
+
+		for _, cycle := range graph.cycles() {
+			chain := make([]string, 0, len(cycle)+1)
+			for _, key := range append(cycle, cycle[0]) {
+				chain = append(chain, names[key])
+			}
+			c.ErrorfAt(locs[cycle[0]], "type %q contains itself by value: %s", names[cycle[0]], strings.Join(chain, " -> "))
+		}
+	})
+}
+
+// typeGraph maps definitions, identified by their canonical filename and
+// name, to the definitions that they contain by value.
+type typeGraph map[string][]string
+
+// cycles returns all of the distinct cycles in the graph, in the same way as
+// includeGraph.cycles.
+func (g typeGraph) cycles() [][]string {
+	return includeGraph(g).cycles()
+}
+
+// CheckTypeReferenceCycle returns a multi-file thriftcheck.Check that warns
+// about each chain of cross-file type references between the linted files
+// that leads back to the file where it started. Unlike the include graph used
+// by import.cycle.disallowed, this graph only has an edge from one file to
+// another if the first really refers to a type (such as a typedef) defined in
+// the second, so these cycles can't be broken by removing an unused include.
+// The warning is reported at the first reference that starts the chain.
+func CheckTypeReferenceCycle() thriftcheck.Check {
+	graph := make(includeGraph)
+	refs := make(map[[2]string]thriftcheck.Location)
+	names := make(map[string]string)
+	paths := make(pathCache)
+
+	return newMultiFileCheck("type.reference.cycle", func(c *thriftcheck.C, ref ast.TypeReference) {
+		sym, ok := c.Symbols().Lookup(ref.Name)
+		if !ok {
+			return
+		}
+		filename, target := paths.canonical(c.Filename), paths.canonical(sym.Filename)
+		if filename == target {
+			return
+		}
+		names[filename] = filepath.Clean(c.Filename)
+		if _, ok := names[target]; !ok {
+			names[target] = filepath.Clean(sym.Filename)
+		}
+
+		edge := [2]string{filename, target}
+		if _, ok := refs[edge]; !ok {
+			graph[filename] = append(graph[filename], target)
+			refs[edge] = c.Locate(ref)
+		}
+	}, func(c *thriftcheck.C) {
+		defer clear(graph)
+		defer clear(refs)
+		defer clear(names)
+		defer clear(paths)
+
+		for _, cycle := range graph.cycles() {
+			chain := make([]string, 0, len(cycle)+1)
+			for _, filename := range append(cycle, cycle[0]) {
+				chain = append(chain, names[filename])
+			}
+			c.WarningfAt(refs[[2]string{cycle[0], cycle[1%len(cycle)]}], "type references form a cycle: %s", strings.Join(chain, " -> "))
+		}
+	})
+}
+
+// complexityCounter counts the type nodes reachable from a struct. The
+// definitions that it has already counted are keyed by "filename:Name".
+type complexityCounter struct {
+	c     *thriftcheck.C
+	limit int
+	nodes int
+	seen  map[string]bool
+}
+
+func (cc *complexityCounter) countStruct(filename string, s *ast.Struct) {
+	cc.nodes++
+	for _, f := range s.Fields {
+		if cc.nodes > cc.limit {
+			return
+		}
+		cc.nodes++
+		cc.countType(filename, f.Type)
+	}
+}
+
+// countType counts a type that appears in filename, in whose symbol table
+// its references are resolved.
+func (cc *complexityCounter) countType(filename string, t ast.Type) {
+	cc.nodes++
+	if cc.nodes > cc.limit {
+		return
+	}
+
+	switch t := t.(type) {
+	case ast.MapType:
+		cc.countType(filename, t.KeyType)
+		cc.countType(filename, t.ValueType)
+	case ast.ListType:
+		cc.countType(filename, t.ValueType)
+	case ast.SetType:
+		cc.countType(filename, t.ValueType)
+	case ast.TypeReference:
+		sym, ok := cc.c.SymbolsFor(filename).Lookup(t.Name)
+		if !ok {
+			return
+		}
+		defFilename := filepath.Clean(sym.Filename)
+		key := defFilename + ":" + sym.Definition.Info().Name
+		if cc.seen[key] {
+			return
+		}
+		cc.seen[key] = true
+
+		switch def := sym.Definition.(type) {
+		case *ast.Struct:
+			cc.countStruct(defFilename, def)
+		case *ast.Typedef:
+			cc.countType(defFilename, def.Type)
+		}
+	}
+}
@@ -107,3 +107,113 @@ func TestCheckTypesDisallowed(t *testing.T) {
 	check = checks.CheckTypes([]thriftcheck.ThriftType{}, []thriftcheck.ThriftType{})
 	RunTests(t, &check, tests)
 }
+
+func TestCheckTypeComplexityBudget(t *testing.T) {
+	tests := []MultiFileTest{
+		{
+			files: map[string]string{
+				"a.thrift": "struct Simple {\n  1: optional string name\n  2: optional i64 id\n}",
+			},
+			want: []string{},
+		},
+		{
+			files: map[string]string{
+				"a.thrift": `include "b.thrift"
+struct Complex {
+  1: optional map<string, list<b.Item>> items
+  2: optional set<string> tags
+}`,
+				"b.thrift": "typedef i64 Id\nstruct Item {\n  1: optional Id id\n  2: optional list<string> names\n}",
+			},
+			want: []string{
+				`a.thrift:2:1: warning: "Complex" exceeds the type complexity budget of 10 nodes (type.complexity.budget)`,
+			},
+		},
+		{
+			// Included files are followed even if they aren't linted.
+			files: map[string]string{
+				"a.thrift": "include \"b.thrift\"\nstruct Complex {\n  1: optional map<string, list<b.Item>> items\n}",
+			},
+			unlinted: map[string]string{
+				"b.thrift": "typedef i64 Id\nstruct Item {\n  1: optional Id id\n  2: optional list<string> names\n}",
+			},
+			want: []string{
+				`a.thrift:2:1: warning: "Complex" exceeds the type complexity budget of 10 nodes (type.complexity.budget)`,
+			},
+		},
+		{
+			files: map[string]string{
+				"a.thrift": "struct Node {\n  1: optional Node link\n  2: optional Other other\n}\nstruct Other {\n  1: optional Node node\n}",
+			},
+			want: []string{},
+		},
+		{
+			files: map[string]string{
+				"a.thrift": "include \"b.thrift\"\nstruct A {\n  1: optional b.B b\n}",
+				"b.thrift": "include \"a.thrift\"\nstruct B {\n  1: optional a.A a\n}",
+			},
+			want: []string{},
+		},
+	}
+
+	check := checks.CheckTypeComplexityBudget(10)
+	RunMultiFileTests(t, &check, tests)
+}
+
+func TestCheckTypeRecursion(t *testing.T) {
+	tests := []MultiFileTest{
+		{
+			files: map[string]string{
+				"a.thrift": "struct Node {\n  1: required list<Node> children\n  2: optional Node parent\n}\ntypedef map<string, Tree> Forest\nstruct Tree {\n  1: required Forest forest\n}\nunion Expr {\n  1: Expr negated\n  2: i64 value\n}",
+			},
+			want: []string{},
+		},
+		{
+			files: map[string]string{
+				"a.thrift": "typedef Node Next\nstruct Node {\n  1: required Next tail\n}\nstruct Self {\n  1: Self inner\n}",
+			},
+			want: []string{
+				`a.thrift:1:1: error: type "Next" contains itself by value: Next -> Node -> Next (type.recursion)`,
+				`a.thrift:5:1: error: type "Self" contains itself by value: Self -> Self (type.recursion)`,
+			},
+		},
+		{
+			files: map[string]string{
+				"a.thrift": "include \"b.thrift\"\nstruct A {\n  1: required b.B b\n}",
+				"b.thrift": "include \"a.thrift\"\ntypedef a.A Alias\nstruct B {\n  1: required Alias a\n}",
+			},
+			want: []string{
+				`a.thrift:2:1: error: type "A" contains itself by value: A -> B -> Alias -> A (type.recursion)`,
+			},
+		},
+	}
+
+	check := checks.CheckTypeRecursion()
+	RunMultiFileTests(t, &check, tests)
+}
+
+func TestCheckTypeReferenceCycle(t *testing.T) {
+	tests := []MultiFileTest{
+		{
+			files: map[string]string{
+				"a.thrift": "include \"b.thrift\"\nstruct A {\n  1: optional b.UserID user\n}",
+				"b.thrift": "include \"c.thrift\"\ntypedef c.ID UserID",
+				"c.thrift": "include \"a.thrift\"\ntypedef i64 ID\nstruct C {\n  1: optional a.A a\n}",
+			},
+			want: []string{
+				`a.thrift:3:15: warning: type references form a cycle: a.thrift -> b.thrift -> c.thrift -> a.thrift (type.reference.cycle)`,
+			},
+		},
+		{
+			files: map[string]string{
+				"a.thrift": "include \"b.thrift\"\nstruct A {\n  1: optional b.UserID user\n}",
+				"b.thrift": "include \"c.thrift\"\ntypedef c.ID UserID",
+				"c.thrift": "include \"a.thrift\"\ntypedef i64 ID",
+			},
+			want: []string{},
+		},
+	}
+
+	check := checks.CheckTypeReferenceCycle()
+	RunMultiFileTests(t, &check, tests)
+}
@@ -0,0 +1,95 @@
+// Copyright 2026 Pinterest
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/pinterest/thriftcheck"
+)
+
+// baselineEntry is a baseline file's record of a single finding.
+type baselineEntry struct {
+	Filename    string `json:"filename"`
+	Check       string `json:"check"`
+	Fingerprint string `json:"fingerprint"`
+	Message     string `json:"message"`
+}
+
+// baseline counts the findings recorded in a baseline file by fingerprint.
+// Fingerprints don't include line numbers, so a recorded finding still
+// matches after unrelated edits move it to a different line.
+type baseline map[string]int
+
+// readBaseline reads a baseline file written by writeBaseline.
+func readBaseline(filename string) (baseline, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	var entries []baselineEntry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("%s: %w", filename, err)
+	}
+	b := make(baseline, len(entries))
+	for _, e := range entries {
+		b[e.Fingerprint]++
+	}
+	return b, nil
+}
+
+// writeBaseline records msgs in a baseline file.
+func writeBaseline(filename string, msgs thriftcheck.Messages) error {
+	entries := make([]baselineEntry, len(msgs))
+	for i, m := range msgs {
+		entries[i] = baselineEntry{
+			Filename:    filepath.ToSlash(filepath.Clean(m.Filename)),
+			Check:       m.Check,
+			Fingerprint: m.Fingerprint(),
+			Message:     m.Message,
+		}
+	}
+	slices.SortFunc(entries, func(a, b baselineEntry) int {
+		return cmp.Or(
+			cmp.Compare(a.Filename, b.Filename),
+			cmp.Compare(a.Check, b.Check),
+			cmp.Compare(a.Message, b.Message),
+		)
+	})
+	data, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filename, append(data, '\n'), 0o644)
+}
+
+// filter removes the messages that are recorded in the baseline. Each
+// recorded finding matches at most one message, so additional occurrences of
+// the same finding are still reported.
+func (b baseline) filter(msgs thriftcheck.Messages) thriftcheck.Messages {
+	return slices.DeleteFunc(msgs, func(m thriftcheck.Message) bool {
+		fp := m.Fingerprint()
+		if b[fp] > 0 {
+			b[fp]--
+			return true
+		}
+		return false
+	})
+}
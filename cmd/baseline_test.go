@@ -0,0 +1,81 @@
+// Copyright 2026 Pinterest
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pinterest/thriftcheck"
+	"github.com/pinterest/thriftcheck/checks"
+)
+
+func TestBaseline(t *testing.T) {
+	dir := t.TempDir()
+	filename := filepath.Join(dir, "a.thrift")
+	baselineFile := filepath.Join(dir, "baseline.json")
+	linter := thriftcheck.NewLinter(thriftcheck.Checks{checks.CheckFieldDocMissing()})
+
+	lintSource := func(src string) thriftcheck.Messages {
+		t.Helper()
+		if err := os.WriteFile(filename, []byte(src), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		msgs, err := linter.LintFiles([]string{filename})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return msgs
+	}
+
+	msgs := lintSource(`struct S {
+  1: optional string legacy
+}`)
+	if len(msgs) != 1 {
+		t.Fatalf("expected 1 message, got %v", msgs)
+	}
+	if err := writeBaseline(baselineFile, msgs); err != nil {
+		t.Fatal(err)
+	}
+
+	// Both the new documented fields above the baselined finding and the new
+	// undocumented field below it shift its line number.
+	msgs = lintSource(`struct S {
+  /** New. */
+  2: optional string a
+  /** New. */
+  3: optional string b
+  1: optional string legacy
+  4: optional string added
+}`)
+	base, err := readBaseline(baselineFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	msgs = base.filter(msgs)
+
+	if len(msgs) != 1 || msgs[0].Pos.Line != 7 {
+		t.Errorf("expected only the new finding on line 7, got %v", msgs)
+	}
+}
+
+func TestBaselineDuplicates(t *testing.T) {
+	m := thriftcheck.Message{Filename: "a.thrift", Check: "check", Message: "message"}
+	base := baseline{m.Fingerprint(): 1}
+	if msgs := base.filter(thriftcheck.Messages{m, m}); len(msgs) != 1 {
+		t.Errorf("expected one of the duplicate messages to remain, got %v", msgs)
+	}
+}
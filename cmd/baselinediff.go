@@ -0,0 +1,128 @@
+// Copyright 2026 Pinterest
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pinterest/thriftcheck"
+)
+
+// changedDefinitions compares each of the .thrift files found in paths with
+// its baseline version, which is found by joining baselineDir with the file's
+// path, and returns the lines of the definitions that are new or have changed.
+// Definitions are matched by name, so a renamed definition counts as new.
+// Files without a baseline version, or that can't be parsed, are considered
+// to have changed entirely, and files without any changed definitions are
+// omitted.
+func changedDefinitions(baselineDir string, paths []string) (changedLines, error) {
+	filenames, err := expandPaths(paths, *recursiveFlag)
+	if err != nil {
+		return nil, err
+	}
+
+	changed := changedLines{}
+	for _, filename := range filenames {
+		filename = filepath.Clean(filename)
+		src, err := os.ReadFile(filename)
+		if err != nil {
+			return nil, err
+		}
+		current, ok := definitionSources(src)
+		if !ok {
+			changed[filename] = nil
+			continue
+		}
+		baseSrc, err := os.ReadFile(filepath.Join(baselineDir, filename))
+		if errors.Is(err, fs.ErrNotExist) {
+			changed[filename] = nil
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		baseline, ok := definitionSources(baseSrc)
+		if !ok {
+			changed[filename] = nil
+			continue
+		}
+
+		for name, def := range current {
+			if base, ok := baseline[name]; ok && base.text == def.text {
+				continue
+			}
+			if changed[filename] == nil {
+				changed[filename] = map[int]bool{}
+			}
+			for line := def.start; line <= def.end; line++ {
+				changed[filename][line] = true
+			}
+		}
+	}
+	return changed, nil
+}
+
+// definitionSource is the (whitespace-normalized) source of a definition,
+// along with the lines that it spans.
+type definitionSource struct {
+	text       string
+	start, end int
+}
+
+// definitionSources parses a file and returns the sources of its definitions
+// keyed by name. A definition spans from its first line up to the next
+// definition, less any blank or comment lines in between (which usually
+// document the next definition). It reports false if the file can't be
+// parsed.
+func definitionSources(src []byte) (map[string]definitionSource, bool) {
+	program, _, err := thriftcheck.Parse(bytes.NewReader(src))
+	if err != nil {
+		return nil, false
+	}
+
+	lines := strings.Split(string(src), "\n")
+	defs := make(map[string]definitionSource, len(program.Definitions))
+	for i, def := range program.Definitions {
+		start, end := def.Info().Line, len(lines)
+		if i+1 < len(program.Definitions) {
+			end = program.Definitions[i+1].Info().Line - 1
+		}
+		for end > start && isBlankOrComment(lines[end-1]) {
+			end--
+		}
+		defs[def.Info().Name] = definitionSource{
+			text:  strings.Join(strings.Fields(strings.Join(lines[start-1:end], "\n")), " "),
+			start: start,
+			end:   end,
+		}
+	}
+	return defs, true
+}
+
+// isBlankOrComment reports whether a line of source is blank or only holds
+// (part of) a comment.
+func isBlankOrComment(line string) bool {
+	line = strings.TrimSpace(line)
+	for _, prefix := range []string{"//", "#", "/*", "*"} {
+		if strings.HasPrefix(line, prefix) {
+			return true
+		}
+	}
+	return line == ""
+}
@@ -0,0 +1,77 @@
+// Copyright 2026 Pinterest
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/pinterest/thriftcheck"
+	"github.com/pinterest/thriftcheck/checks"
+)
+
+func TestChangedDefinitions(t *testing.T) {
+	dir, baselineDir := t.TempDir(), t.TempDir()
+	write := func(filename, content string) {
+		t.Helper()
+		if err := os.MkdirAll(filepath.Dir(filename), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filename, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	a, b, c := filepath.Join(dir, "a.thrift"), filepath.Join(dir, "b.thrift"), filepath.Join(dir, "c.thrift")
+	write(filepath.Join(baselineDir, a), "struct A {\n  1: string a\n}\n\n// B is changed.\nstruct B {\n  1: string b\n}\n")
+	write(a, "struct A {\n    1: string a\n}\n\n// B is changed.\nstruct B {\n  1: string b\n  2: string c\n}\n\n/** C is new. */\nstruct C {\n  1: string c\n}\n")
+	write(filepath.Join(baselineDir, b), "struct B {}\n")
+	write(b, "struct B {}\n")
+	write(c, "struct C {}\n")
+
+	changed, err := changedDefinitions(baselineDir, []string{dir})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := changedLines{
+		a: {6: true, 7: true, 8: true, 9: true, 12: true, 13: true, 14: true},
+		c: nil,
+	}
+	if !reflect.DeepEqual(changed, expected) {
+		t.Errorf("expected %v, got %v", expected, changed)
+	}
+
+	var got []string
+	linter := thriftcheck.NewLinter(thriftcheck.Checks{checks.CheckFieldRequiredness()})
+	err = lint(linter, []string{a, b}, changed, nil, func(msgs thriftcheck.Messages) error {
+		for _, m := range msgs {
+			got = append(got, m.String())
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{
+		a + `:7:3: warning: field "b" (1) should be explicitly "required" or "optional" (field.requiredness)`,
+		a + `:8:3: warning: field "c" (2) should be explicitly "required" or "optional" (field.requiredness)`,
+		a + `:13:3: warning: field "c" (1) should be explicitly "required" or "optional" (field.requiredness)`,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+}
@@ -0,0 +1,89 @@
+// Copyright 2026 Pinterest
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"cmp"
+	"fmt"
+	"io"
+	"runtime"
+	"slices"
+	"time"
+
+	"github.com/pinterest/thriftcheck"
+)
+
+// benchResult is the cost of running one check over all of the inputs,
+// averaged over the runs.
+type benchResult struct {
+	name   string
+	time   time.Duration
+	allocs uint64
+	bytes  uint64
+}
+
+// bench lints filenames n times with each of the checks on its own and writes
+// a table of their average times and allocations to w, most expensive first.
+// Every run also parses the files, so a "(parse only)" row with no checks is
+// included to show how much of each check's cost is really parsing.
+func bench(w io.Writer, filenames []string, checks thriftcheck.Checks, options []thriftcheck.Option, n int) error {
+	run := func(name string, checks thriftcheck.Checks) (benchResult, error) {
+		linter := thriftcheck.NewLinter(checks, options...)
+		// An untimed run warms up the caches (and the file system) first.
+		if _, err := linter.LintFiles(filenames); err != nil {
+			return benchResult{}, err
+		}
+		var before, after runtime.MemStats
+		runtime.GC()
+		runtime.ReadMemStats(&before)
+		start := time.Now()
+		for range n {
+			if _, err := linter.LintFiles(filenames); err != nil {
+				return benchResult{}, err
+			}
+		}
+		elapsed := time.Since(start)
+		runtime.ReadMemStats(&after)
+		return benchResult{
+			name:   name,
+			time:   elapsed / time.Duration(n),
+			allocs: (after.Mallocs - before.Mallocs) / uint64(n),
+			bytes:  (after.TotalAlloc - before.TotalAlloc) / uint64(n),
+		}, nil
+	}
+
+	baseline, err := run("(parse only)", thriftcheck.Checks{})
+	if err != nil {
+		return err
+	}
+	results := make([]benchResult, 0, len(checks))
+	for _, check := range checks {
+		r, err := run(check.Name, thriftcheck.Checks{check})
+		if err != nil {
+			return err
+		}
+		results = append(results, r)
+	}
+	slices.SortFunc(results, func(a, b benchResult) int {
+		return cmp.Or(cmp.Compare(b.time, a.time), cmp.Compare(a.name, b.name))
+	})
+
+	fmt.Fprintf(w, "%-40s %12s %12s %12s\n", "check", "time/run", "allocs/run", "bytes/run")
+	for _, r := range append(results, baseline) {
+		fmt.Fprintf(w, "%-40s %12s %12d %12d\n", r.name, r.time.Round(time.Microsecond), r.allocs, r.bytes)
+	}
+	fmt.Fprintf(w, "\n%s over %s, %s each\n", plural(len(checks), "check"), plural(len(filenames), "file"), plural(n, "run"))
+	return nil
+}
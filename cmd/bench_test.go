@@ -0,0 +1,63 @@
+// Copyright 2026 Pinterest
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/pinterest/thriftcheck"
+	"github.com/pinterest/thriftcheck/checks"
+)
+
+func TestBench(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "a.thrift")
+	if err := os.WriteFile(filename, []byte("struct S {\n  1: optional i32 a\n}"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	enabled := thriftcheck.Checks{checks.CheckFieldIDMissing(), checks.CheckFieldOptional(), checks.CheckOrphanFiles(nil)}
+	if err := bench(&buf, []string{filename}, enabled, nil, 2); err != nil {
+		t.Fatal(err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != len(enabled)+4 {
+		t.Fatalf("expected a header, a row per check, a parse row, and a summary, got:\n%s", buf.String())
+	}
+	if fields := strings.Fields(lines[0]); len(fields) != 4 || fields[0] != "check" {
+		t.Errorf("unexpected header %q", lines[0])
+	}
+	var names []string
+	for _, line := range lines[1 : len(enabled)+1] {
+		names = append(names, strings.Fields(line)[0])
+	}
+	for _, check := range enabled {
+		if !slices.Contains(names, check.Name) {
+			t.Errorf("missing a row for %s in %v", check.Name, names)
+		}
+	}
+	if !strings.HasPrefix(lines[len(enabled)+1], "(parse only)") {
+		t.Errorf("expected the parse row last, got %q", lines[len(enabled)+1])
+	}
+	if want := "3 checks over 1 file, 2 runs each"; lines[len(lines)-1] != want {
+		t.Errorf("expected summary %q, got %q", want, lines[len(lines)-1])
+	}
+}
@@ -0,0 +1,233 @@
+// Copyright 2026 Pinterest
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/pinterest/thriftcheck"
+)
+
+// diffContext is the number of unchanged lines shown around each change.
+const diffContext = 3
+
+// diffFormatter writes the suggested fixes of the messages as a unified diff,
+// without modifying any files. All of a file's fixes in a batch are applied
+// together, so each file gets a single set of hunks, and messages without
+// fixes aren't written. Fixes are relative to the original source, so fixes
+// for a file that already has a diff are skipped.
+type diffFormatter struct {
+	w       io.Writer
+	written map[string]bool
+}
+
+func newDiffFormatter(w io.Writer) *diffFormatter {
+	return &diffFormatter{w: w, written: make(map[string]bool)}
+}
+
+func (f *diffFormatter) write(msgs thriftcheck.Messages) error {
+	var filenames []string
+	byFile := make(map[string]thriftcheck.Messages)
+	for _, m := range msgs {
+		if m.Fix == nil || f.written[m.Filename] {
+			continue
+		}
+		if _, ok := byFile[m.Filename]; !ok {
+			filenames = append(filenames, m.Filename)
+		}
+		byFile[m.Filename] = append(byFile[m.Filename], m)
+	}
+
+	for _, filename := range filenames {
+		src, err := os.ReadFile(filename)
+		if err != nil {
+			return err
+		}
+		fixed, _ := thriftcheck.ApplyFixes(src, byFile[filename])
+		if _, err := fmt.Fprint(f.w, unifiedDiff(diffPath(filename), string(src), string(fixed))); err != nil {
+			return err
+		}
+		f.written[filename] = true
+	}
+	return nil
+}
+
+func (f *diffFormatter) close() error {
+	return nil
+}
+
+// diffPath returns the path used for a file in a diff's headers. Files below
+// the current directory are named relative to it, so the diff can be applied
+// from there; other files keep their absolute paths.
+func diffPath(filename string) string {
+	if filepath.IsAbs(filename) {
+		if wd, err := os.Getwd(); err == nil {
+			if rel, err := filepath.Rel(wd, filename); err == nil && filepath.IsLocal(rel) {
+				filename = rel
+			}
+		}
+	}
+	return filepath.ToSlash(filepath.Clean(filename))
+}
+
+// unifiedDiff returns the unified diff between the old and new contents of a
+// file, or an empty string if they're the same. The path is given a/ and b/
+// prefixes, as git does.
+func unifiedDiff(path, old, new string) string {
+	path = strings.TrimPrefix(path, "/")
+	ops := diffLines(splitLines(old), splitLines(new))
+
+	var b strings.Builder
+	for i := 0; i < len(ops); {
+		if ops[i].kind == ' ' {
+			i++
+			continue
+		}
+
+		// Extend the hunk through any changes that are close enough for
+		// their context lines to overlap.
+		start, end := max(0, i-diffContext), i
+		for j := i; j < len(ops) && j-end <= 2*diffContext; j++ {
+			if ops[j].kind != ' ' {
+				end = j
+			}
+		}
+		stop := min(len(ops), end+diffContext+1)
+
+		if b.Len() == 0 {
+			fmt.Fprintf(&b, "--- a/%s\n+++ b/%s\n", path, path)
+		}
+		var oldCount, newCount int
+		for _, op := range ops[start:stop] {
+			if op.kind != '+' {
+				oldCount++
+			}
+			if op.kind != '-' {
+				newCount++
+			}
+		}
+		fmt.Fprintf(&b, "@@ -%s +%s @@\n", hunkRange(ops[start].old, oldCount), hunkRange(ops[start].new, newCount))
+		for _, op := range ops[start:stop] {
+			b.WriteByte(op.kind)
+			b.WriteString(op.line)
+			if !strings.HasSuffix(op.line, "\n") {
+				b.WriteString("\n\\ No newline at end of file\n")
+			}
+		}
+		i = stop
+	}
+	return b.String()
+}
+
+// hunkRange formats the range of lines covered by a hunk, given the index of
+// its first line, in the form used by hunk headers.
+func hunkRange(start, count int) string {
+	switch count {
+	case 0:
+		return fmt.Sprintf("%d,0", start)
+	case 1:
+		return fmt.Sprintf("%d", start+1)
+	default:
+		return fmt.Sprintf("%d,%d", start+1, count)
+	}
+}
+
+// splitLines splits s into lines, each of which keeps its trailing newline.
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	lines := strings.SplitAfter(s, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// diffOp is a line of a diff: one that's unchanged (' '), removed ('-'), or
+// added ('+'), along with the indexes of the old and new lines before it.
+type diffOp struct {
+	kind     byte
+	line     string
+	old, new int
+}
+
+// diffLines returns the shortest sequence of operations that turns a into b,
+// using Myers' algorithm.
+func diffLines(a, b []string) []diffOp {
+	n, m := len(a), len(b)
+	offset := n + m + 1
+	v := make([]int, 2*offset+1)
+	var trace [][]int
+
+search:
+	for d := 0; d <= n+m; d++ {
+		trace = append(trace, slices.Clone(v))
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+				x = v[offset+k+1]
+			} else {
+				x = v[offset+k-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			v[offset+k] = x
+			if x >= n && y >= m {
+				break search
+			}
+		}
+	}
+
+	// Walk back through the search to recover the operations, which are
+	// found in reverse order.
+	var ops []diffOp
+	x, y := n, m
+	for d := len(trace) - 1; d >= 0; d-- {
+		v := trace[d]
+		k := x - y
+		prev := k - 1
+		if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+			prev = k + 1
+		}
+		prevX := v[offset+prev]
+		prevY := prevX - prev
+		for x > prevX && y > prevY {
+			x--
+			y--
+			ops = append(ops, diffOp{kind: ' ', line: a[x], old: x, new: y})
+		}
+		if d == 0 {
+			break
+		}
+		if x == prevX {
+			y--
+			ops = append(ops, diffOp{kind: '+', line: b[y], old: x, new: y})
+		} else {
+			x--
+			ops = append(ops, diffOp{kind: '-', line: a[x], old: x, new: y})
+		}
+	}
+	slices.Reverse(ops)
+	return ops
+}
@@ -0,0 +1,90 @@
+// Copyright 2026 Pinterest
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pinterest/thriftcheck"
+)
+
+func TestDiffFormatter(t *testing.T) {
+	filename := filepath.Join("testdata", "reorder.thrift")
+	src, err := os.ReadFile(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// reorder returns a message with a fix that swaps two adjacent lines,
+	// as a check that orders fields by ID would suggest.
+	reorder := func(first, second string) thriftcheck.Message {
+		start := strings.Index(string(src), first)
+		end := start + len(first) + len(second)
+		return thriftcheck.Message{
+			Filename: filename,
+			Check:    "field.order",
+			Message:  "fields are out of order",
+			Fix:      &thriftcheck.Edit{Start: start, End: end, Text: second + first},
+		}
+	}
+	msgs := thriftcheck.Messages{
+		reorder("  2: optional string name\n", "  1: optional i64 id\n"),
+		{Filename: filename, Check: "field.doc.missing", Message: "no fix"},
+		reorder("  10: optional bool admin\n", "  9: optional bool active\n"),
+	}
+
+	var b bytes.Buffer
+	f := newDiffFormatter(&b)
+	if err := f.write(msgs); err != nil {
+		t.Fatal(err)
+	}
+	// Fixes are relative to the original source, so a file only gets one
+	// diff.
+	if err := f.write(msgs); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.close(); err != nil {
+		t.Fatal(err)
+	}
+
+	expected, err := os.ReadFile(filepath.Join("testdata", "reorder.diff"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b.String() != string(expected) {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, b.String())
+	}
+}
+
+func TestUnifiedDiff(t *testing.T) {
+	tests := []struct {
+		old, new string
+		want     string
+	}{
+		{"a\nb\n", "a\nb\n", ""},
+		{"", "a\n", "--- a/t.thrift\n+++ b/t.thrift\n@@ -0,0 +1 @@\n+a\n"},
+		{"a\nb", "a\nc", "--- a/t.thrift\n+++ b/t.thrift\n@@ -1,2 +1,2 @@\n a\n-b\n\\ No newline at end of file\n+c\n\\ No newline at end of file\n"},
+	}
+
+	for _, tt := range tests {
+		if got := unifiedDiff("t.thrift", tt.old, tt.new); got != tt.want {
+			t.Errorf("unifiedDiff(%q, %q):\n- %q\n+ %q", tt.old, tt.new, tt.want, got)
+		}
+	}
+}
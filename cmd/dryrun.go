@@ -0,0 +1,57 @@
+// Copyright 2026 Pinterest
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+
+	"github.com/pinterest/thriftcheck"
+)
+
+// dryRun writes the files that would be linted for paths (along with the
+// files that would be skipped, and why) and the names of the active checks
+// to w, without linting anything.
+func dryRun(w io.Writer, paths []string, changed changedLines, checks thriftcheck.Checks) error {
+	var candidates []candidate
+	if len(paths) == 1 && paths[0] == "-" {
+		candidates = []candidate{{path: *stdinFilename}}
+	} else {
+		var err error
+		if candidates, err = findCandidates(paths, *recursiveFlag); err != nil {
+			return err
+		}
+	}
+
+	for _, c := range candidates {
+		if c.skip == "" && changed != nil {
+			if _, ok := changed[filepath.Clean(c.path)]; !ok {
+				c.skip = "no changed lines"
+			}
+		}
+		if c.skip == "" {
+			fmt.Fprintf(w, "lint %s\n", c.path)
+		} else {
+			fmt.Fprintf(w, "skip %s (%s)\n", c.path, c.skip)
+		}
+	}
+
+	fmt.Fprintf(w, "\n%s:\n", plural(len(checks), "check"))
+	for _, name := range checks.SortedNames() {
+		fmt.Fprintf(w, "  %s\n", name)
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+// Copyright 2026 Pinterest
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pinterest/thriftcheck"
+	"github.com/pinterest/thriftcheck/checks"
+)
+
+func TestDryRun(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"a.thrift":           "",
+		"b.gen.thrift":       "",
+		"notes.txt":          "",
+		"vendor/c.thrift":    "",
+		".thriftcheckignore": "*.gen.thrift\nvendor/\n",
+	})
+
+	var buf bytes.Buffer
+	enabled := thriftcheck.Checks{checks.CheckIncludePath(), checks.CheckFieldIDMissing()}
+	if err := dryRun(&buf, []string{dir}, nil, enabled); err != nil {
+		t.Fatal(err)
+	}
+
+	ignoreFile := filepath.Join(dir, ".thriftcheckignore")
+	want := strings.Join([]string{
+		"lint " + filepath.Join(dir, "a.thrift"),
+		"skip " + filepath.Join(dir, "b.gen.thrift") + " (ignored by " + ignoreFile + ": *.gen.thrift)",
+		"skip " + filepath.Join(dir, "notes.txt") + " (not a .thrift file)",
+		"skip " + filepath.Join(dir, "vendor") + " (ignored by " + ignoreFile + ": vendor)",
+		"",
+		"2 checks:",
+		"  field.id.missing",
+		"  include.path",
+		"",
+	}, "\n")
+	if got := buf.String(); got != want {
+		t.Errorf("expected:\n%s\ngot:\n%s", want, got)
+	}
+
+	buf.Reset()
+	changed := changedLines{filepath.Join(dir, "a.thrift"): {1: true}}
+	if err := dryRun(&buf, []string{filepath.Join(dir, "a.thrift"), filepath.Join(dir, "b.gen.thrift")}, changed, nil); err != nil {
+		t.Fatal(err)
+	}
+	want = strings.Join([]string{
+		"lint " + filepath.Join(dir, "a.thrift"),
+		"skip " + filepath.Join(dir, "b.gen.thrift") + " (no changed lines)",
+		"",
+		"0 checks:",
+		"",
+	}, "\n")
+	if got := buf.String(); got != want {
+		t.Errorf("expected:\n%s\ngot:\n%s", want, got)
+	}
+}
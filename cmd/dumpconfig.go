@@ -0,0 +1,117 @@
+// Copyright 2026 Pinterest
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"unicode"
+
+	"github.com/pinterest/thriftcheck"
+)
+
+type checkStatus struct {
+	Name     string `json:"name"`
+	Enabled  bool   `json:"enabled"`
+	Severity string `json:"severity"`
+}
+
+// dumpConfig writes the effective configuration to w as JSON, along with
+// the status of every available check, including the severity of its
+// messages once the configured check severities have been applied.
+func dumpConfig(w io.Writer, cfg *Config, allChecks, enabledChecks thriftcheck.Checks) error {
+	enabled := make(map[string]bool, len(enabledChecks))
+	for _, check := range enabledChecks {
+		enabled[check.Name] = true
+	}
+
+	statuses := make([]checkStatus, 0, len(allChecks))
+	for _, name := range allChecks.SortedNames() {
+		for _, check := range allChecks {
+			if check.Name == name {
+				statuses = append(statuses, checkStatus{
+					Name:     name,
+					Enabled:  enabled[name],
+					Severity: effectiveSeverity(cfg, check),
+				})
+				break
+			}
+		}
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(struct {
+		Config any           `json:"config"`
+		Checks []checkStatus `json:"checks"`
+	}{configValue(reflect.ValueOf(cfg)), statuses})
+}
+
+// configValue converts a configuration value into a JSON-friendly form. Struct
+// fields are keyed by their configuration file names and values that have a
+// string representation (regular expressions, types, and severities) are
+// written as strings.
+func configValue(v reflect.Value) any {
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return nil
+		}
+	}
+	if s, ok := v.Interface().(fmt.Stringer); ok {
+		return s.String()
+	}
+
+	switch v.Kind() {
+	case reflect.Pointer:
+		return configValue(v.Elem())
+	case reflect.Struct:
+		m := make(map[string]any, v.NumField())
+		for i := range v.NumField() {
+			m[configKey(v.Type().Field(i))] = configValue(v.Field(i))
+		}
+		return m
+	case reflect.Slice:
+		s := make([]any, v.Len())
+		for i := range s {
+			s[i] = configValue(v.Index(i))
+		}
+		return s
+	case reflect.Map:
+		m := make(map[string]any, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			m[fmt.Sprint(iter.Key().Interface())] = configValue(iter.Value())
+		}
+		return m
+	default:
+		return v.Interface()
+	}
+}
+
+func configKey(f reflect.StructField) string {
+	if name, _, _ := strings.Cut(f.Tag.Get("fig"), ","); name != "" {
+		return name
+	}
+	// Lowercase the leading run of uppercase letters: "AllowedTypes" becomes
+	// "allowedTypes" and "IDs" becomes "ids".
+	i := strings.IndexFunc(f.Name, func(r rune) bool { return !unicode.IsUpper(r) })
+	if i < 0 {
+		i = len(f.Name)
+	}
+	return strings.ToLower(f.Name[:i]) + f.Name[i:]
+}
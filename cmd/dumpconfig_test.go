@@ -0,0 +1,118 @@
+// Copyright 2026 Pinterest
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/pinterest/thriftcheck"
+	"github.com/pinterest/thriftcheck/checks"
+)
+
+func TestDumpConfig(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "thriftcheck.toml")
+	err := os.WriteFile(filename, []byte(`
+includes = ["from-file"]
+
+[checks]
+disabled = ["field.id.zero"]
+
+[checks.map.key.value.same]
+names = "_ids$"
+
+[checks.severity]
+"field" = "ignore"
+"field.id.missing" = "error"
+"field.id.negative" = "ignore"
+
+[[severities]]
+path = "legacy/*"
+severity = "warning"
+`), 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	oldIncludes := includes
+	defer func() { includes = oldIncludes }()
+	includes = Strings{"from-flag"}
+
+	var cfg Config
+	if err := loadConfig(&cfg, filename); err != nil {
+		t.Fatal(err)
+	}
+	applyFlags(&cfg)
+
+	all := thriftcheck.Checks{
+		checks.CheckFieldIDMissing(),
+		checks.CheckFieldIDNegative(),
+		checks.CheckFieldIDZero(),
+	}
+
+	var b bytes.Buffer
+	if err := dumpConfig(&b, &cfg, all, selectChecks(&cfg, all)); err != nil {
+		t.Fatal(err)
+	}
+
+	var dump struct {
+		Config struct {
+			Includes   []string
+			Severities []map[string]string
+			Checks     struct {
+				Disabled []string
+				Map      struct {
+					Key struct {
+						Value struct {
+							Same struct {
+								Names string
+							}
+						}
+					}
+				}
+			}
+		}
+		Checks []checkStatus
+	}
+	if err := json.Unmarshal(b.Bytes(), &dump); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, b.String())
+	}
+
+	if expected := []string{"from-flag"}; !reflect.DeepEqual(dump.Config.Includes, expected) {
+		t.Errorf("expected includes %v, got %v", expected, dump.Config.Includes)
+	}
+	if expected := []string{"field.id.zero"}; !reflect.DeepEqual(dump.Config.Checks.Disabled, expected) {
+		t.Errorf("expected disabled checks %v, got %v", expected, dump.Config.Checks.Disabled)
+	}
+	if expected := "_ids$"; dump.Config.Checks.Map.Key.Value.Same.Names != expected {
+		t.Errorf("expected names %q, got %q", expected, dump.Config.Checks.Map.Key.Value.Same.Names)
+	}
+	if expected := []map[string]string{{"path": "legacy/*", "severity": "warning"}}; !reflect.DeepEqual(dump.Config.Severities, expected) {
+		t.Errorf("expected severities %v, got %v", expected, dump.Config.Severities)
+	}
+
+	expected := []checkStatus{
+		{Name: "field.id.missing", Enabled: true, Severity: "error"},
+		{Name: "field.id.negative", Enabled: false, Severity: "ignore"},
+		{Name: "field.id.zero", Enabled: false, Severity: "ignore"},
+	}
+	if !reflect.DeepEqual(dump.Checks, expected) {
+		t.Errorf("expected checks %v, got %v", expected, dump.Checks)
+	}
+}
@@ -0,0 +1,58 @@
+// Copyright 2026 Pinterest
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/pinterest/thriftcheck"
+)
+
+// explain writes a description of the named check to w.
+func explain(w io.Writer, checks thriftcheck.Checks, name string) error {
+	for _, check := range checks {
+		if check.Name != name {
+			continue
+		}
+
+		info := check.Info
+		fmt.Fprintf(w, "%s (default severity: %s)\n", check.Name, info.Severity)
+		if info.Description != "" {
+			fmt.Fprintf(w, "\n%s\n", info.Description)
+		}
+		if info.Rationale != "" {
+			fmt.Fprintf(w, "\nRationale:\n%s\n", indent(info.Rationale))
+		}
+		if info.Bad != "" {
+			fmt.Fprintf(w, "\nViolating example:\n%s\n", indent(info.Bad))
+		}
+		if info.Good != "" {
+			fmt.Fprintf(w, "\nCompliant example:\n%s\n", indent(info.Good))
+		}
+		return nil
+	}
+
+	return fmt.Errorf("unknown check: %s", name)
+}
+
+func indent(s string) string {
+	lines := strings.Split(strings.TrimSpace(s), "\n")
+	for i, line := range lines {
+		lines[i] = "    " + line
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,51 @@
+// Copyright 2026 Pinterest
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/pinterest/thriftcheck"
+	"github.com/pinterest/thriftcheck/checks"
+)
+
+func TestExplain(t *testing.T) {
+	all := thriftcheck.Checks{
+		checks.CheckFieldIDMissing(),
+		checks.CheckFieldIDZero(),
+	}
+
+	var b bytes.Buffer
+	if err := explain(&b, all, "field.id.zero"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	out := b.String()
+	for _, want := range []string{
+		"field.id.zero (default severity: error)",
+		"Reports an error if a field's ID is explicitly zero.",
+		"Violating example:\n    struct User {\n        0: optional string name\n    }",
+		"Compliant example:\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected output to contain %q:\n%s", want, out)
+		}
+	}
+
+	if err := explain(&b, all, "field.id.unknown"); err == nil {
+		t.Errorf("expected an error for an unknown check")
+	}
+}
@@ -0,0 +1,56 @@
+// Copyright 2026 Pinterest
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/pinterest/thriftcheck"
+	"github.com/pinterest/thriftcheck/checks"
+)
+
+func TestMissingFindings(t *testing.T) {
+	linter := thriftcheck.NewLinter(thriftcheck.Checks{
+		checks.CheckFieldIDZero(),
+		checks.CheckFieldOptional(),
+	})
+	messages, err := linter.Lint(strings.NewReader("struct S {\n  0: optional string s\n}"), "t.thrift")
+	if err != nil {
+		t.Fatal(err)
+	}
+	reported := make(map[string]bool)
+	for _, m := range messages {
+		reported[m.Check] = true
+	}
+
+	tests := []struct {
+		required []string
+		want     []string
+	}{
+		{nil, nil},
+		{[]string{"field.id.zero"}, nil},
+		{[]string{"field"}, nil},
+		{[]string{"field.optional"}, []string{"field.optional"}},
+		{[]string{"field.id.zero", "field.optional", "enum"}, []string{"field.optional", "enum"}},
+	}
+
+	for _, tt := range tests {
+		if got := missingFindings(reported, tt.required); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%v:\n- %v\n+ %v", tt.required, tt.want, got)
+		}
+	}
+}
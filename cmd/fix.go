@@ -0,0 +1,82 @@
+// Copyright 2026 Pinterest
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"os"
+
+	"github.com/pinterest/thriftcheck"
+)
+
+// fixer applies the linter's suggested fixes to the linted files.
+type fixer struct {
+	// rewritten records the files that have already been fixed. Fixes are
+	// relative to the original source, so later fixes for the same file
+	// can't be applied.
+	rewritten map[string]bool
+	count     int
+}
+
+func newFixer() *fixer {
+	return &fixer{rewritten: make(map[string]bool)}
+}
+
+// apply applies the suggested fixes in msgs to their files and returns the
+// messages that weren't fixed.
+func (f *fixer) apply(msgs thriftcheck.Messages) (thriftcheck.Messages, error) {
+	var filenames []string
+	byFile := make(map[string]thriftcheck.Messages)
+	for _, m := range msgs {
+		if _, ok := byFile[m.Filename]; !ok {
+			filenames = append(filenames, m.Filename)
+		}
+		byFile[m.Filename] = append(byFile[m.Filename], m)
+	}
+
+	var remaining thriftcheck.Messages
+	for _, filename := range filenames {
+		fileMsgs := byFile[filename]
+		if f.rewritten[filename] || !hasFix(fileMsgs) {
+			remaining = append(remaining, fileMsgs...)
+			continue
+		}
+
+		info, err := os.Stat(filename)
+		if err != nil {
+			return nil, err
+		}
+		src, err := os.ReadFile(filename)
+		if err != nil {
+			return nil, err
+		}
+		fixed, unfixed := thriftcheck.ApplyFixes(src, fileMsgs)
+		if err := os.WriteFile(filename, fixed, info.Mode().Perm()); err != nil {
+			return nil, err
+		}
+		f.rewritten[filename] = true
+		f.count += len(fileMsgs) - len(unfixed)
+		remaining = append(remaining, unfixed...)
+	}
+	return remaining, nil
+}
+
+func hasFix(msgs thriftcheck.Messages) bool {
+	for _, m := range msgs {
+		if m.Fix != nil {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,62 @@
+// Copyright 2026 Pinterest
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pinterest/thriftcheck"
+	"github.com/pinterest/thriftcheck/checks"
+)
+
+func TestFixer(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "a.thrift")
+	src := "struct S {\n  1: optional string a\n  optional string b\n}\n"
+	if err := os.WriteFile(filename, []byte(src), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	linter := thriftcheck.NewLinter(thriftcheck.Checks{checks.CheckFieldIDMissing(), checks.CheckFieldDocMissing()})
+	msgs, err := linter.LintFiles([]string{filename})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fixes := newFixer()
+	remaining, err := fixes.apply(msgs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fixes.count != 1 || len(remaining) != len(msgs)-1 {
+		t.Errorf("expected 1 fixed message, got %d (remaining: %v)", fixes.count, remaining)
+	}
+
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "struct S {\n  1: optional string a\n  2: optional string b\n}\n"
+	if string(data) != want {
+		t.Errorf("expected:\n%s\ngot:\n%s", want, data)
+	}
+
+	// Fixes are relative to the original source, so they aren't applied to
+	// a file that has already been rewritten.
+	if remaining, err := fixes.apply(msgs); err != nil || len(remaining) != len(msgs) {
+		t.Errorf("expected no fixes for a rewritten file, got %v (%v)", remaining, err)
+	}
+}
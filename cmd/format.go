@@ -0,0 +1,221 @@
+// Copyright 2026 Pinterest
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pinterest/thriftcheck"
+)
+
+// formatter writes messages to an output using a specific format. Messages
+// are written in batches (e.g. one per linted file) so that output can be
+// streamed, and close finishes the output once all of them are written.
+type formatter interface {
+	write(msgs thriftcheck.Messages) error
+	close() error
+}
+
+// formatters maps format names to functions that create formatters writing
+// to w. Source snippets are only written by formatters that support them,
+// and only when src is non-nil. Some formats also describe the checks that
+// reported messages, which are looked up in checks.
+var formatters = map[string]func(w io.Writer, src sources, checks thriftcheck.Checks) formatter{
+	"text": func(w io.Writer, src sources, _ thriftcheck.Checks) formatter {
+		return &textFormatter{w: w, src: src}
+	},
+	"diff": func(w io.Writer, _ sources, _ thriftcheck.Checks) formatter {
+		return newDiffFormatter(w)
+	},
+	"github-review": func(w io.Writer, _ sources, _ thriftcheck.Checks) formatter {
+		return newGitHubReviewFormatter(w)
+	},
+	"json": func(w io.Writer, _ sources, _ thriftcheck.Checks) formatter {
+		return &jsonArrayFormatter{w: w, item: func(m thriftcheck.Message) any { return m }}
+	},
+	"gitlab": func(w io.Writer, _ sources, _ thriftcheck.Checks) formatter {
+		return newGitLabFormatter(w)
+	},
+	"sarif": func(w io.Writer, _ sources, checks thriftcheck.Checks) formatter {
+		dir, err := os.Getwd()
+		if err != nil {
+			return errorFormatter{fmt.Errorf("--format sarif: %w", err)}
+		}
+		return newSARIFFormatter(w, sarifRoot(*sarifRootFlag, dir), dir, checks)
+	},
+}
+
+// errorFormatter is a formatter that couldn't be created. Writing to it or
+// closing it returns the error that prevented its creation.
+type errorFormatter struct{ err error }
+
+func (f errorFormatter) write(thriftcheck.Messages) error { return f.err }
+func (f errorFormatter) close() error                     { return f.err }
+
+type textFormatter struct {
+	w   io.Writer
+	src sources
+}
+
+func (f *textFormatter) write(msgs thriftcheck.Messages) error {
+	return formatText(f.w, msgs, f.src)
+}
+
+func (f *textFormatter) close() error {
+	return nil
+}
+
+// formatText writes messages using the familiar file:line:col text format,
+// followed by their owners (if they have any).
+func formatText(w io.Writer, msgs thriftcheck.Messages, src sources) error {
+	for _, m := range msgs {
+		if m.Owner != "" {
+			fmt.Fprintf(w, "%s (owner: %s)\n", m, m.Owner)
+		} else {
+			fmt.Fprintln(w, m)
+		}
+		if src != nil {
+			if snippet, ok := src.snippet(m); ok {
+				fmt.Fprintln(w, snippet)
+			}
+		}
+	}
+	return nil
+}
+
+// jsonArrayFormatter writes messages as the elements of a JSON array, using
+// item to convert each message to its JSON representation (or nil to leave it
+// out). The array's opening bracket is written along with its first element,
+// and the closing bracket isn't written until the formatter is closed.
+type jsonArrayFormatter struct {
+	w     io.Writer
+	item  func(m thriftcheck.Message) any
+	count int
+}
+
+func (f *jsonArrayFormatter) write(msgs thriftcheck.Messages) error {
+	for _, m := range msgs {
+		item := f.item(m)
+		if item == nil {
+			continue
+		}
+		b, err := json.MarshalIndent(item, "  ", "  ")
+		if err != nil {
+			return err
+		}
+
+		sep := ",\n  "
+		if f.count == 0 {
+			sep = "[\n  "
+		}
+		if _, err := fmt.Fprintf(f.w, "%s%s", sep, b); err != nil {
+			return err
+		}
+		f.count++
+	}
+	return nil
+}
+
+func (f *jsonArrayFormatter) close() error {
+	end := "\n]\n"
+	if f.count == 0 {
+		end = "[]\n"
+	}
+	_, err := io.WriteString(f.w, end)
+	return err
+}
+
+type gitHubReviewComment struct {
+	Path string `json:"path"`
+	Line int    `json:"line"`
+	Side string `json:"side"`
+	Body string `json:"body"`
+}
+
+func newGitHubReviewFormatter(w io.Writer) *jsonArrayFormatter {
+	return &jsonArrayFormatter{w: w, item: func(m thriftcheck.Message) any {
+		// Review comments must be attached to a file.
+		if m.Filename == "" {
+			return nil
+		}
+		severity := m.Severity.String()
+		return gitHubReviewComment{
+			Path: filepath.ToSlash(filepath.Clean(m.Filename)),
+			Line: max(m.Pos.Line, 1),
+			Side: "RIGHT",
+			Body: fmt.Sprintf("**%s%s:** %s (`%s`)", strings.ToUpper(severity[:1]), severity[1:], m.Message, m.Check),
+		}
+	}}
+}
+
+// formatGitHubReview writes messages as a JSON array of objects that can be
+// posted as pull request review comments using GitHub's API.
+func formatGitHubReview(w io.Writer, msgs thriftcheck.Messages, _ sources) error {
+	f := newGitHubReviewFormatter(w)
+	if err := f.write(msgs); err != nil {
+		return err
+	}
+	return f.close()
+}
+
+type gitLabIssue struct {
+	Description string         `json:"description"`
+	CheckName   string         `json:"check_name"`
+	Fingerprint string         `json:"fingerprint"`
+	Severity    string         `json:"severity"`
+	Location    gitLabLocation `json:"location"`
+}
+
+type gitLabLocation struct {
+	Path  string `json:"path"`
+	Lines struct {
+		Begin int `json:"begin"`
+	} `json:"lines"`
+}
+
+// gitLabSeverities maps message severities to Code Quality severities.
+var gitLabSeverities = map[thriftcheck.Severity]string{
+	thriftcheck.Warning: "minor",
+	thriftcheck.Error:   "major",
+}
+
+func newGitLabFormatter(w io.Writer) *jsonArrayFormatter {
+	return &jsonArrayFormatter{w: w, item: func(m thriftcheck.Message) any {
+		issue := gitLabIssue{
+			Description: m.Message,
+			CheckName:   m.Check,
+			Fingerprint: m.Fingerprint(),
+			Severity:    gitLabSeverities[m.Severity],
+		}
+		issue.Location.Path = filepath.ToSlash(filepath.Clean(m.Filename))
+		issue.Location.Lines.Begin = max(m.Pos.Line, 1)
+		return issue
+	}}
+}
+
+// formatGitLab writes messages as a GitLab Code Quality report, which is
+// a JSON array of issue objects.
+func formatGitLab(w io.Writer, msgs thriftcheck.Messages, _ sources) error {
+	f := newGitLabFormatter(w)
+	if err := f.write(msgs); err != nil {
+		return err
+	}
+	return f.close()
+}
@@ -0,0 +1,291 @@
+// Copyright 2026 Pinterest
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/pinterest/thriftcheck"
+	"github.com/pinterest/thriftcheck/checks"
+	"go.uber.org/thriftrw/ast"
+)
+
+var updateGolden = flag.Bool("update", false, "update golden files")
+
+var testMessages = thriftcheck.Messages{
+	{
+		Filename: "./idl/a.thrift",
+		Pos:      ast.Position{Line: 3, Column: 5},
+		Check:    "field.id.zero",
+		Severity: thriftcheck.Error,
+		Message:  `field ID for "name" is zero`,
+	},
+	{
+		Filename: "idl/b.thrift",
+		Check:    "file.orphan",
+		Severity: thriftcheck.Warning,
+		Message:  "file is not reachable from any root file",
+	},
+}
+
+func TestFormatText(t *testing.T) {
+	var b bytes.Buffer
+	if err := formatText(&b, testMessages, nil); err != nil {
+		t.Fatal(err)
+	}
+	expected := `./idl/a.thrift:3:5: error: field ID for "name" is zero (field.id.zero)
+idl/b.thrift:0:1: warning: file is not reachable from any root file (file.orphan)
+`
+	if b.String() != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, b.String())
+	}
+}
+
+func TestFormatGitHubReview(t *testing.T) {
+	var b bytes.Buffer
+	// Messages without a file can't be attached to the review.
+	msgs := append(slices.Clone(testMessages), thriftcheck.Message{Check: "limit.truncated", Message: "(2+ more from field.id.zero)"})
+	if err := formatGitHubReview(&b, msgs, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	var comments []map[string]any
+	if err := json.Unmarshal(b.Bytes(), &comments); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, b.String())
+	}
+	expected := []map[string]any{
+		{
+			"path": "idl/a.thrift",
+			"line": 3.0,
+			"side": "RIGHT",
+			"body": "**Error:** field ID for \"name\" is zero (`field.id.zero`)",
+		},
+		{
+			"path": "idl/b.thrift",
+			"line": 1.0,
+			"side": "RIGHT",
+			"body": "**Warning:** file is not reachable from any root file (`file.orphan`)",
+		},
+	}
+	if !reflect.DeepEqual(comments, expected) {
+		t.Errorf("expected %v, got %v", expected, comments)
+	}
+}
+
+// flushWriter records the output that has been written (or "flushed") so far.
+type flushWriter struct {
+	bytes.Buffer
+	flushes int
+}
+
+func (w *flushWriter) Write(p []byte) (int, error) {
+	w.flushes++
+	return w.Buffer.Write(p)
+}
+
+func TestFormatStream(t *testing.T) {
+	dir := t.TempDir()
+	var paths []string
+	for _, name := range []string{"a.thrift", "b.thrift"} {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte("struct S {\n  0: string s\n  string t\n}\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		paths = append(paths, path)
+	}
+	// field.id.missing suggests a fix, so that the diff format has something
+	// to write.
+	linter := thriftcheck.NewLinter(thriftcheck.Checks{checks.CheckFieldIDZero(), checks.CheckFieldIDMissing()})
+
+	for name, newFormatter := range formatters {
+		t.Run(name, func(t *testing.T) {
+			var w flushWriter
+			out := newFormatter(&w, nil, nil)
+
+			// Each file's messages are written before the next file is linted.
+			var written []string
+			err := lint(linter, paths, nil, nil, func(msgs thriftcheck.Messages) error {
+				flushes := w.flushes
+				if err := out.write(msgs); err != nil {
+					return err
+				}
+				if len(msgs) > 0 && w.flushes == flushes {
+					t.Errorf("expected %d messages to be written immediately", len(msgs))
+				}
+				written = append(written, w.String())
+				return nil
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(written) != 3 {
+				t.Fatalf("expected 3 batches, got %d", len(written))
+			}
+			if !strings.Contains(written[0], "a.thrift") || strings.Contains(written[0], "b.thrift") {
+				t.Errorf("expected only a.thrift's output after the first file, got:\n%s", written[0])
+			}
+			if !strings.Contains(written[1], "b.thrift") {
+				t.Errorf("expected b.thrift's output after the second file, got:\n%s", written[1])
+			}
+			if err := out.close(); err != nil {
+				t.Fatal(err)
+			}
+
+			// The streamed output is identical to the output written in one batch.
+			msgs, err := linter.LintFiles(paths)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var b bytes.Buffer
+			batch := newFormatter(&b, nil, nil)
+			if err := batch.write(msgs); err != nil {
+				t.Fatal(err)
+			}
+			if err := batch.close(); err != nil {
+				t.Fatal(err)
+			}
+			if w.String() != b.String() {
+				t.Errorf("expected:\n%s\ngot:\n%s", b.String(), w.String())
+			}
+		})
+	}
+}
+
+func TestFormatGitLab(t *testing.T) {
+	var b bytes.Buffer
+	if err := formatGitLab(&b, testMessages, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	golden := filepath.Join("testdata", "gitlab.json")
+	if *updateGolden {
+		if err := os.WriteFile(golden, b.Bytes(), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	expected, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b.String() != string(expected) {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, b.String())
+	}
+}
+
+func TestFormatJSONFingerprints(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"a.thrift": "struct A {\n  0: optional string name\n}",
+		"b.thrift": "struct B {\n  1: optional string a\n  0: optional string b\n}",
+	})
+	filenames := []string{filepath.Join(dir, "a.thrift"), filepath.Join(dir, "b.thrift")}
+
+	linter := thriftcheck.NewLinter(thriftcheck.Checks{checks.CheckFieldIDZero()})
+	fingerprints := func(filenames ...string) []string {
+		t.Helper()
+		msgs, err := linter.LintFiles(filenames)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var b bytes.Buffer
+		f := formatters["json"](&b, nil, nil)
+		if err := f.write(msgs); err != nil {
+			t.Fatal(err)
+		}
+		if err := f.close(); err != nil {
+			t.Fatal(err)
+		}
+		var findings []struct {
+			Check       string `json:"check"`
+			Fingerprint string `json:"fingerprint"`
+		}
+		if err := json.Unmarshal(b.Bytes(), &findings); err != nil {
+			t.Fatal(err)
+		}
+		var lines []string
+		for _, f := range findings {
+			lines = append(lines, f.Fingerprint+" "+f.Check)
+		}
+		slices.Sort(lines)
+		return lines
+	}
+
+	got := fingerprints(filenames[0], filenames[1])
+	if len(got) != 2 || got[0] == got[1] {
+		t.Fatalf("expected 2 distinct fingerprints, got %q", got)
+	}
+	if reordered := fingerprints(filenames[1], filenames[0]); !slices.Equal(got, reordered) {
+		t.Errorf("expected the same fingerprints for reordered files:\n%q\n%q", got, reordered)
+	}
+}
+
+func TestFormatJSON(t *testing.T) {
+	for _, tt := range []struct {
+		msgs     thriftcheck.Messages
+		expected string
+	}{
+		{nil, "[]\n"},
+		{testMessages, `[
+  {
+    "filename": "./idl/a.thrift",
+    "line": 3,
+    "column": 5,
+    "severity": "error",
+    "check": "field.id.zero",
+    "message": "field ID for \"name\" is zero",
+    "fingerprint": "6d05194abb418ce3fe0512538b656fb1d04647d23ff43997584e282ea8009b70"
+  },
+  {
+    "filename": "idl/b.thrift",
+    "line": 0,
+    "column": 1,
+    "severity": "warning",
+    "check": "file.orphan",
+    "message": "file is not reachable from any root file",
+    "fingerprint": "d127bcc26b4710508696241831c0a83cf80bd64b034a9c4fa74e4a5e32c6c759"
+  }
+]
+`},
+	} {
+		var b bytes.Buffer
+		f := formatters["json"](&b, nil, nil)
+		if err := f.write(tt.msgs); err != nil {
+			t.Fatal(err)
+		}
+		if err := f.close(); err != nil {
+			t.Fatal(err)
+		}
+		if b.String() != tt.expected {
+			t.Errorf("expected:\n%s\ngot:\n%s", tt.expected, b.String())
+		}
+	}
+}
+
+func TestFormatGitHubReviewEmpty(t *testing.T) {
+	var b bytes.Buffer
+	if err := formatGitHubReview(&b, nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	if b.String() != "[]\n" {
+		t.Errorf("expected an empty array, got %q", b.String())
+	}
+}
@@ -0,0 +1,104 @@
+// Copyright 2026 Pinterest
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"maps"
+	"path/filepath"
+	"slices"
+
+	"github.com/pinterest/thriftcheck/checks"
+)
+
+// graphFormats maps the graph subcommand's output formats to the functions
+// that write them.
+var graphFormats = map[string]func(w io.Writer, g checks.IncludeGraph) error{
+	"dot": writeDOT,
+}
+
+// graph implements the graph subcommand: it parses its own flags from args,
+// then writes the include graph reachable from the remaining (root) paths to
+// w. Directories are expanded like they are for linting. Files that can't be
+// read are still included in the graph, and are reported to warn.
+func graph(w, warn io.Writer, args []string, dirs []string) error {
+	fs := flag.NewFlagSet("graph", flag.ContinueOnError)
+	fs.SetOutput(warn)
+	format := fs.String("format", "dot", "output format: dot")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	write, ok := graphFormats[*format]
+	if !ok {
+		return fmt.Errorf("unknown graph format: %s, valid formats are: %v", *format, slices.Sorted(maps.Keys(graphFormats)))
+	}
+	if fs.NArg() == 0 {
+		return errors.New("graph: no root files given")
+	}
+
+	roots, err := expandPaths(fs.Args(), *recursiveFlag)
+	if err != nil {
+		return err
+	}
+	g := checks.NewIncludeGraph(roots, dirs)
+	for _, filename := range slices.Sorted(maps.Keys(g.Failed)) {
+		fmt.Fprintf(warn, "graph: %v\n", g.Failed[filename])
+	}
+	return write(w, g)
+}
+
+// writeDOT writes an include graph in Graphviz's DOT language. Files are
+// nodes and includes are edges, with the edges that are part of cycles (those
+// within a strongly connected component) drawn in red and files that couldn't
+// be read drawn dashed.
+func writeDOT(w io.Writer, g checks.IncludeGraph) error {
+	component := make(map[string]int)
+	for i, files := range g.Components {
+		for _, filename := range files {
+			component[filename] = i + 1
+		}
+	}
+
+	nodes := make(map[string]bool)
+	for filename, includes := range g.Includes {
+		nodes[filename] = true
+		for _, path := range includes {
+			nodes[path] = true
+		}
+	}
+
+	fmt.Fprintln(w, "digraph includes {")
+	for _, filename := range slices.Sorted(maps.Keys(nodes)) {
+		attrs := ""
+		if _, ok := g.Failed[filename]; ok {
+			attrs = " [style=dashed]"
+		}
+		fmt.Fprintf(w, "  %q%s;\n", filepath.ToSlash(filename), attrs)
+	}
+	for _, filename := range slices.Sorted(maps.Keys(g.Includes)) {
+		for _, path := range slices.Compact(slices.Sorted(slices.Values(g.Includes[filename]))) {
+			attrs := ""
+			if c := component[filename]; c != 0 && c == component[path] {
+				attrs = " [color=red]"
+			}
+			fmt.Fprintf(w, "  %q -> %q%s;\n", filepath.ToSlash(filename), filepath.ToSlash(path), attrs)
+		}
+	}
+	_, err := fmt.Fprintln(w, "}")
+	return err
+}
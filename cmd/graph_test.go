@@ -0,0 +1,79 @@
+// Copyright 2026 Pinterest
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestGraph(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"a.thrift": "include \"b.thrift\"\ninclude \"c.thrift\"",
+		"b.thrift": `include "c.thrift"`,
+		"c.thrift": `include "b.thrift"`,
+		"d.thrift": "include \"e.thrift\"\ninclude \"missing.thrift\"",
+		"e.thrift": "struct {",
+	})
+	path := func(name string) string { return filepath.ToSlash(filepath.Join(dir, name)) }
+
+	var out, warn bytes.Buffer
+	if err := graph(&out, &warn, []string{"--format", "dot", filepath.Join(dir, "a.thrift")}, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	want := strings.Join([]string{
+		`digraph includes {`,
+		`  "` + path("a.thrift") + `";`,
+		`  "` + path("b.thrift") + `";`,
+		`  "` + path("c.thrift") + `";`,
+		`  "` + path("a.thrift") + `" -> "` + path("b.thrift") + `";`,
+		`  "` + path("a.thrift") + `" -> "` + path("c.thrift") + `";`,
+		`  "` + path("b.thrift") + `" -> "` + path("c.thrift") + `" [color=red];`,
+		`  "` + path("c.thrift") + `" -> "` + path("b.thrift") + `" [color=red];`,
+		`}`,
+	}, "\n") + "\n"
+	if out.String() != want {
+		t.Errorf("expected:\n%s\ngot:\n%s", want, out.String())
+	}
+	if warn.Len() != 0 {
+		t.Errorf("unexpected warnings: %s", warn.String())
+	}
+
+	// Unreadable files are drawn dashed and reported, and missing includes
+	// are left out.
+	out.Reset()
+	if err := graph(&out, &warn, []string{filepath.Join(dir, "d.thrift")}, nil); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out.String(), `  "`+path("e.thrift")+`" [style=dashed];`) || strings.Contains(out.String(), "missing.thrift") {
+		t.Errorf("unexpected graph:\n%s", out.String())
+	}
+	if !strings.HasPrefix(warn.String(), "graph: ") {
+		t.Errorf("expected a warning about e.thrift, got %q", warn.String())
+	}
+}
+
+func TestGraphErrors(t *testing.T) {
+	var out, warn bytes.Buffer
+	if err := graph(&out, &warn, []string{"--format", "svg", "a.thrift"}, nil); err == nil || !strings.Contains(err.Error(), "unknown graph format: svg") {
+		t.Errorf("expected an unknown format error, got %v", err)
+	}
+	if err := graph(&out, &warn, nil, nil); err == nil {
+		t.Error("expected an error without any root files")
+	}
+}
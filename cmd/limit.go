@@ -0,0 +1,62 @@
+// Copyright 2026 Pinterest
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/pinterest/thriftcheck"
+)
+
+// checkLimits caps the number of messages that are reported for each check
+// so that a single noisy check doesn't drown out the others.
+type checkLimits struct {
+	max    int
+	counts map[string]int
+}
+
+func newCheckLimits(max int) *checkLimits {
+	return &checkLimits{max: max, counts: make(map[string]int)}
+}
+
+// filter removes the messages from checks that have already reported the
+// maximum number of messages. Counts are kept across calls, so the limit
+// applies to the whole run when messages are reported in batches.
+func (l *checkLimits) filter(msgs thriftcheck.Messages) thriftcheck.Messages {
+	return slices.DeleteFunc(msgs, func(m thriftcheck.Message) bool {
+		l.counts[m.Check]++
+		return l.counts[m.Check] > l.max
+	})
+}
+
+// truncated returns a note for each check that went over the limit, saying
+// how many of its messages were left out. The notes are reported as
+// informational limit.truncated warnings, so that they're written in the same
+// format as the other messages.
+func (l *checkLimits) truncated() thriftcheck.Messages {
+	var notes thriftcheck.Messages
+	for _, check := range slices.Sorted(maps.Keys(l.counts)) {
+		if n := l.counts[check] - l.max; n > 0 {
+			notes = append(notes, thriftcheck.Message{
+				Check:    "limit.truncated",
+				Severity: thriftcheck.Warning,
+				Message:  fmt.Sprintf("(%d+ more from %s)", n, check),
+			})
+		}
+	}
+	return notes
+}
@@ -0,0 +1,44 @@
+// Copyright 2026 Pinterest
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/pinterest/thriftcheck"
+)
+
+func TestCheckLimits(t *testing.T) {
+	var msgs thriftcheck.Messages
+	for i := range 5 {
+		msgs = append(msgs, thriftcheck.Message{Check: "noisy", Message: fmt.Sprint(i)})
+	}
+	msgs = append(msgs, thriftcheck.Message{Check: "quiet", Message: "0"})
+
+	limits := newCheckLimits(2)
+	got := limits.filter(slices.Clone(msgs[:3]))
+	got = append(got, limits.filter(slices.Clone(msgs[3:]))...)
+
+	want := thriftcheck.Messages{msgs[0], msgs[1], msgs[5]}
+	if !slices.Equal(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+	notes := limits.truncated()
+	if len(notes) != 1 || notes[0].Message != "(3+ more from noisy)" || notes[0].Check != "limit.truncated" {
+		t.Errorf("expected a (3+ more from noisy) note, got %v", notes)
+	}
+}
@@ -18,36 +18,90 @@ Thriftcheck lints Thrift IDL files.
 Usage:
 
 	thriftcheck [options] [path ...]
+	thriftcheck [options] explain check
+	thriftcheck [options] graph [--format dot] path ...
+	thriftcheck selftest
 
 Options:
 
 	-I, --include value
 		include path (can be specified multiple times)
-	-c, --config string
-		configuration file path (default ".thriftcheck.toml")
+	--baseline string
+		suppress the findings recorded in the given baseline file
+	--baseline-diff string
+		only report findings on definitions that are new or changed since the versions in the given baseline directory
+	--bench int
+		lint the inputs this many times with each check on its own and report the checks' average costs
+	-c, --config value
+		configuration file path (can be specified multiple times, with later files taking precedence) (default ".thriftcheck.toml")
+	--dry-run
+		print the files that would be linted (or skipped) and the active checks, then exit
+	--dump-config
+		print the effective configuration as JSON and exit
 	--errors-only
 		only report errors (not warnings)
+	--fail-fast
+		stop linting at the first error
+	--fix
+		apply suggested fixes to the linted files
+	--format string
+		output format: text, json, diff, github-review, gitlab, or sarif (default "text")
 	-h, --help
 		show command help
+	-j, --jobs int
+		number of files to lint concurrently (default: the number of CPUs)
 	-l, --list
 		list all available checks with their status and exit
+	-o, --output string
+		write the formatted output to the given file instead of stdout
+	--owners string
+		annotate messages with their files' owners from the given CODEOWNERS-style file
+	--per-check-limit int
+		report at most this many messages from each check (default: no limit)
+	--recursive
+		expand directories to the .thrift files in all of their subdirectories too (default true)
+	--require-findings value
+		fail if the named check reports no findings (can be specified multiple times)
+	--run string
+		run only the named check, regardless of the configuration
+	--sarif-root string
+		source root for relative URIs in --format sarif output (default: the git work tree's top level or the current directory)
+	--show-source
+		print the source line and column of each message
+	--since string
+		only lint lines that have changed since the given git ref
+	--skip-multifile-on-unresolved
+		skip multi-file checks for files with includes that can't be found
 	--stdin-filename string
+		alias for --stdin-filepath (default "stdin")
+	--stdin-filepath string
 		filename used when piping from stdin (default "stdin")
+	--stream
+		write each file's messages as soon as it has been linted
 	-v, --verbose
 		enable verbose (debugging) output
 	--version
 		print the version and exit
+	--webhook string
+		also POST each batch of findings as JSON to the given URL
+	--write-baseline
+		record the current findings in the --baseline file and exit
 */
 package main
 
 import (
+	"bytes"
 	"errors"
 	"flag"
 	"fmt"
+	"io"
 	"log"
+	"maps"
 	"os"
 	"path/filepath"
+	"reflect"
 	"regexp"
+	"slices"
 	"strings"
 
 	"github.com/kkyr/fig"
@@ -58,26 +112,165 @@ import (
 
 // Config represents all of the configurable values.
 type Config struct {
-	Includes []string `fig:"includes"`
-	Checks   struct {
-		Enabled  []string `fig:"enabled"`
-		Disabled []string `fix:"disabled"`
+	Extends      string                     `fig:"extends"`
+	Includes     []string                   `fig:"includes"`
+	Rules        []string                   `fig:"rules"`
+	Rulesets     []string                   `fig:"rulesets"`
+	Severities   []thriftcheck.PathSeverity `fig:"severities"`
+	Suppressions []thriftcheck.Suppression  `fig:"suppressions"`
+	Checks       struct {
+		Enabled  []string                 `fig:"enabled"`
+		Disabled []string                 `fix:"disabled"`
+		Severity map[string]checkSeverity `fig:"severity"`
+
+		MessageTemplates map[string]string `fig:"messageTemplates"`
+
+		Annotation struct {
+			Conflicts [][2]string `fig:"conflicts"`
+		}
+
+		Const struct {
+			Divergence struct {
+				Names *regexp.Regexp `fig:"names"`
+			}
+			Unit struct {
+				Typedef struct {
+					Names    *regexp.Regexp `fig:"names"`
+					Typedefs []string       `fig:"typedefs"`
+				}
+			}
+		}
+
+		Container struct {
+			Repeated struct {
+				Inline struct {
+					MinOccurrences int `fig:"minOccurrences"`
+				}
+			}
+		}
 
 		Enum struct {
+			Location struct {
+				Shared string `fig:"shared"`
+			}
+			Members struct {
+				Max int `fig:"max"`
+			}
 			Size struct {
 				Warning int `fig:"warning"`
 				Error   int `fig:"error"`
 			}
+			Stability struct {
+				Baseline string `fig:"baseline"`
+			}
+		}
+
+		Exception struct {
+			Field struct {
+				Duplicate struct {
+					Base string `fig:"base"`
+				}
+			}
+		}
+
+		File struct {
+			Orphan struct {
+				Roots []string `fig:"roots"`
+			}
+		}
+
+		Function struct {
+			Bool struct {
+				Result struct {
+					Verbs *regexp.Regexp `fig:"verbs"`
+				}
+			}
+			Public struct {
+				Annotation string `fig:"annotation"`
+			}
+			Result struct {
+				Complexity struct {
+					MaxExceptions int `fig:"maxExceptions"`
+				}
+			}
+		}
+
+		Field struct {
+			Bool struct {
+				Naming struct {
+					Prefixes []string `fig:"prefixes"`
+				}
+			}
+			Doc struct {
+				Type struct {
+					Mismatch struct {
+						Rules []checks.DocTypeRule `fig:"rules"`
+					}
+				}
+			}
+			ID struct {
+				First struct {
+					Contiguous bool `fig:"contiguous"`
+				}
+				Gap struct {
+					Step int `fig:"step"`
+				}
+				Length struct {
+					Bound struct {
+						Names *regexp.Regexp `fig:"names"`
+						Key   string         `fig:"key"`
+					}
+				}
+				Width struct {
+					Consistent struct {
+						Names *regexp.Regexp `fig:"names"`
+					}
+				}
+			}
+			PII struct {
+				Names *regexp.Regexp `fig:"names"`
+			}
+			Required struct {
+				Added struct {
+					Baseline string `fig:"baseline"`
+				}
+			}
+			Reserved struct {
+				Prefix struct {
+					Prefixes []string `fig:"prefixes"`
+				}
+			}
+			Small struct {
+				Int struct {
+					Allow string `fig:"allow"`
+				}
+			}
 		}
 
 		Include struct {
 			Restricted map[string]*regexp.Regexp `fig:"restricted"`
 		}
 
+		Limits struct {
+			Size struct {
+				MaxFields int `fig:"maxFields"`
+			}
+		}
+
 		Map struct {
 			Key struct {
 				AllowedTypes    []thriftcheck.ThriftType `fig:"allowedTypes"`
 				DisallowedTypes []thriftcheck.ThriftType `fig:"disallowedTypes"`
+				Value           struct {
+					Same struct {
+						Names *regexp.Regexp `fig:"names"`
+					}
+				}
+				Width struct {
+					Consistent struct {
+						Names *regexp.Regexp `fig:"names"`
+					}
+				}
 			}
 			Value struct {
 				AllowedTypes    []thriftcheck.ThriftType `fig:"allowedTypes"`
@@ -85,6 +278,53 @@ type Config struct {
 			}
 		}
 
+		Reference struct {
+			Qualification struct {
+				Depth struct {
+					Max int `fig:"max"`
+				}
+			}
+		}
+
+		Service struct {
+			Cohesion struct {
+				MaxNouns int `fig:"maxNouns"`
+			}
+			CQRS struct {
+				ReadVerbs  []string `fig:"readVerbs"`
+				WriteVerbs []string `fig:"writeVerbs"`
+			}
+			Method struct {
+				Void struct {
+					Mutator *regexp.Regexp `fig:"mutator"`
+				}
+			}
+			Visibility struct {
+				Key     string   `fig:"key"`
+				Allowed []string `fig:"allowed"`
+			}
+		}
+
+		Struct struct {
+			Duplicated struct {
+				Fields struct {
+					MinFields  int `fig:"minFields"`
+					MinStructs int `fig:"minStructs"`
+				}
+			}
+			Stable struct {
+				Annotation string `fig:"annotation"`
+			}
+			Paired struct {
+				IDs struct {
+					Suffixes []string `fig:"suffixes"`
+				}
+			}
+			Unused struct {
+				Allowed *regexp.Regexp `fig:"allowed"`
+			}
+		}
+
 		Set struct {
 			AllowedTypes    []thriftcheck.ThriftType `fig:"allowedTypes"`
 			DisallowedTypes []thriftcheck.ThriftType `fig:"disallowedTypes"`
@@ -94,14 +334,44 @@ type Config struct {
 			Reserved []string `fig:"reserved"`
 		}
 
+		Naming struct {
+			Convention map[string]*regexp.Regexp `fig:"convention"`
+			Reserved   struct {
+				Languages []string `fig:"languages"`
+			}
+		}
+
 		Namespace struct {
+			Include struct {
+				Consistency struct {
+					Languages []string `fig:"languages"`
+				}
+			}
+			Path struct {
+				Match map[string]string `fig:"match"`
+			}
 			Patterns map[string]*regexp.Regexp `fig:"patterns"`
+			Required []string                  `fig:"required"`
+		}
+
+		Type struct {
+			Complexity struct {
+				Budget struct {
+					MaxNodes int `fig:"maxNodes"`
+				}
+			}
 		}
 
 		Types struct {
 			AllowedTypes    []thriftcheck.ThriftType `fig:"allowedTypes"`
 			DisallowedTypes []thriftcheck.ThriftType `fig:"disallowedTypes"`
 		}
+
+		Union struct {
+			Migration struct {
+				Baseline string `fig:"baseline"`
+			}
+		}
 	}
 }
 
@@ -121,27 +391,57 @@ func (i *Strings) Set(value string) error {
 var (
 	version       = "dev"
 	revision      = "dev"
+	configFiles   Strings
 	includes      Strings
-	configFile    = flag.String("c", ".thriftcheck.toml", "configuration file path")
+	required      Strings
+	baselineFile  = flag.String("baseline", "", "suppress the findings recorded in the given baseline file")
+	baselineDiff  = flag.String("baseline-diff", "", "only report findings on definitions that are new or changed since the versions in the given baseline directory")
+	benchFlag     = flag.Int("bench", 0, "lint the inputs this many times with each check on its own and report the checks' average costs")
+	dryRunFlag    = flag.Bool("dry-run", false, "print the files that would be linted (or skipped) and the active checks, then exit")
+	dumpFlag      = flag.Bool("dump-config", false, "print the effective configuration as JSON and exit")
 	errorsOnly    = flag.Bool("errors-only", false, "only report errors (not warnings)")
+	failFast      = flag.Bool("fail-fast", false, "stop linting at the first error")
+	fixFlag       = flag.Bool("fix", false, "apply suggested fixes to the linted files")
+	formatFlag    = flag.String("format", "text", "output format: text, json, diff, github-review, gitlab, or sarif")
 	helpFlag      = flag.Bool("h", false, "show command help")
+	jobsFlag      = flag.Int("j", 0, "number of files to lint concurrently (default: the number of CPUs)")
 	listFlag      = flag.Bool("l", false, "list all available checks with their status and exit")
-	stdinFilename = flag.String("stdin-filename", "stdin", "filename used when piping from stdin")
+	outputFile    = flag.String("o", "", "write the formatted output to the given file instead of stdout")
+	ownersFile    = flag.String("owners", "", "annotate messages with their files' owners from the given CODEOWNERS-style file")
+	perCheckLimit = flag.Int("per-check-limit", 0, "report at most this many messages from each check (default: no limit)")
+	recursiveFlag = flag.Bool("recursive", true, "expand directories to the .thrift files in all of their subdirectories too")
+	runFlag       = flag.String("run", "", "run only the named check, regardless of the configuration")
+	sarifRootFlag = flag.String("sarif-root", "", "source root for relative URIs in --format sarif output (default: the git work tree's top level or the current directory)")
+	showSource    = flag.Bool("show-source", false, "print the source line and column of each message")
+	since         = flag.String("since", "", "only lint lines that have changed since the given git ref")
+	skipMultiFile = flag.Bool("skip-multifile-on-unresolved", false, "skip multi-file checks for files with includes that can't be found")
+	streamFlag    = flag.Bool("stream", false, "write each file's messages as soon as it has been linted")
+	stdinFilename = flag.String("stdin-filepath", "stdin", "filename used when piping from stdin")
 	verboseFlag   = flag.Bool("v", false, "enable verbose (debugging) output")
 	versionFlag   = flag.Bool("version", false, "print the version and exit")
+	webhookURL    = flag.String("webhook", "", "also POST each batch of findings as JSON to the given URL")
+	writeBaseFlag = flag.Bool("write-baseline", false, "record the current findings in the --baseline file and exit")
 )
 
 func init() {
 	flag.Var(&includes, "I", "include path (can be specified multiple times)")
+	flag.Var(&configFiles, "c", "configuration file path (can be specified multiple times, with later files taking precedence) (default \".thriftcheck.toml\")")
+	flag.Var(&required, "require-findings", "fail if the named check reports no findings (can be specified multiple times)")
+	flag.StringVar(stdinFilename, "stdin-filename", "stdin", "alias for --stdin-filepath")
 	flag.Usage = func() {
 		fmt.Fprintf(flag.CommandLine.Output(), "usage: thriftcheck [options] [path ...]\n")
+		fmt.Fprintf(flag.CommandLine.Output(), "       thriftcheck [options] explain check\n")
+		fmt.Fprintf(flag.CommandLine.Output(), "       thriftcheck [options] graph [--format dot] path ...\n")
+		fmt.Fprintf(flag.CommandLine.Output(), "       thriftcheck selftest\n")
 		getopt.PrintDefaults()
 	}
 	getopt.Aliases(
 		"I", "include",
 		"c", "config",
 		"h", "help",
+		"j", "jobs",
 		"l", "list",
+		"o", "output",
 		"v", "verbose")
 }
 
@@ -155,58 +455,272 @@ func isFlagSet(name string) bool {
 	return set
 }
 
-func loadConfig(cfg *Config) error {
-	if err := fig.Load(cfg, fig.UseStrict(), fig.File(*configFile)); err != nil {
-		// Ignore FileNotFound when we're using the default configuration file.
-		if errors.Is(err, fig.ErrFileNotFound) && !isFlagSet("c") {
-			return nil
+// loadConfig loads the configuration files on top of each other, so that the
+// values in later files take precedence over those in earlier ones.
+func loadConfig(cfg *Config, filenames ...string) error {
+	load := func(cfg *Config) error {
+		for _, filename := range filenames {
+			if err := loadConfigChain(cfg, filename, nil); err != nil {
+				return err
+			}
 		}
+		return nil
+	}
+	if err := load(cfg); err != nil || len(cfg.Rulesets) == 0 {
+		return err
+	}
+
+	// Reload the configuration files on top of the rulesets' presets so that
+	// their values take precedence over them.
+	var preset Config
+	if err := applyRulesets(&preset, cfg.Rulesets); err != nil {
+		return err
+	}
+	if err := load(&preset); err != nil {
 		return err
 	}
+	*cfg = preset
 	return nil
 }
 
-func lint(l *thriftcheck.Linter, paths []string) (thriftcheck.Messages, error) {
-	if len(paths) == 1 && paths[0] == "-" {
-		return l.Lint(os.Stdin, *stdinFilename)
-	}
-	paths, err := expandPaths(paths)
+// loadConfigChain loads a configuration file on top of cfg, after first
+// loading the file that it extends (if any). Relative extends paths are
+// relative to the extending file's directory. chain holds the files that are
+// already being loaded, which is used to detect cycles.
+func loadConfigChain(cfg *Config, filename string, chain []string) error {
+	path, err := filepath.Abs(filename)
 	if err != nil {
-		return nil, err
+		return err
+	}
+	if i := slices.Index(chain, path); i >= 0 {
+		return fmt.Errorf("%s: cyclic extends: %s", filename, strings.Join(append(chain[i:], path), " -> "))
 	}
-	return l.LintFiles(paths)
-}
 
-func expandPaths(paths []string) ([]string, error) {
-	var filenames []string
-	for _, path := range paths {
-		info, err := os.Stat(path)
-		if err != nil {
-			return nil, err
+	var own Config
+	if err := loadConfigFile(&own, filename); err != nil {
+		return err
+	}
+	if own.Extends != "" {
+		base := own.Extends
+		if !filepath.IsAbs(base) {
+			base = filepath.Join(filepath.Dir(filename), base)
 		}
-
-		if !info.IsDir() {
-			filenames = append(filenames, path)
-			continue
+		if _, err := os.Stat(base); err != nil {
+			return fmt.Errorf("%s: extends: %w", filename, err)
+		}
+		if err := loadConfigChain(cfg, base, append(chain, path)); err != nil {
+			return err
 		}
+	}
 
-		err = filepath.Walk(path, func(path string, info os.FileInfo, err error) error {
-			if err != nil {
-				return err
-			}
+	if err := loadConfigFile(cfg, filename); err != nil {
+		return err
+	}
+	replaceLists(reflect.ValueOf(cfg).Elem(), reflect.ValueOf(&own).Elem())
+	return nil
+}
 
-			if !info.IsDir() && filepath.Ext(path) == ".thrift" {
-				filenames = append(filenames, path)
+// replaceLists replaces each of dst's slices with the corresponding slice in
+// src, if it's set. Loading a file on top of existing values merges lists
+// element by element (leaving any extra existing elements in place), but a
+// list in a later file should replace the earlier one entirely.
+func replaceLists(dst, src reflect.Value) {
+	for i := range dst.NumField() {
+		switch f := dst.Field(i); f.Kind() {
+		case reflect.Slice:
+			if !src.Field(i).IsNil() {
+				f.Set(src.Field(i))
 			}
+		case reflect.Struct:
+			replaceLists(f, src.Field(i))
+		}
+	}
+}
 
+func loadConfigFile(cfg *Config, filename string) error {
+	if err := fig.Load(cfg, fig.UseStrict(), fig.File(filepath.Base(filename)), fig.Dirs(filepath.Dir(filename))); err != nil {
+		// Ignore FileNotFound when we're using the default configuration file.
+		if errors.Is(err, fig.ErrFileNotFound) && !isFlagSet("c") {
 			return nil
-		})
+		}
+		return err
+	}
+	return nil
+}
+
+// applyFlags merges command line flag values into the loaded configuration.
+func applyFlags(cfg *Config) {
+	if len(includes) > 0 {
+		cfg.Includes = includes
+	}
+}
+
+// buildChecks returns all of the available checks, configured by cfg.
+func buildChecks(cfg *Config) (thriftcheck.Checks, error) {
+	pairSuffixes := [2]string{"Request", "Response"}
+	if suffixes := cfg.Checks.Struct.Paired.IDs.Suffixes; len(suffixes) > 0 {
+		if len(suffixes) != 2 {
+			return nil, errors.New("checks.struct.paired.ids.suffixes must contain exactly two suffixes")
+		}
+		copy(pairSuffixes[:], suffixes)
+	}
+	qualificationDepth := 1
+	if max := cfg.Checks.Reference.Qualification.Depth.Max; max > 0 {
+		qualificationDepth = max
+	}
+	for _, language := range cfg.Checks.Naming.Reserved.Languages {
+		if _, ok := checks.ReservedWords[language]; !ok {
+			return nil, fmt.Errorf("checks.naming.reserved.languages: unknown language %q, valid languages are: %v",
+				language, slices.Sorted(maps.Keys(checks.ReservedWords)))
+		}
+	}
+
+	allChecks := thriftcheck.Checks{
+		checks.CheckConflictingAnnotations(cfg.Checks.Annotation.Conflicts),
+		checks.CheckConstDivergence(cfg.Checks.Const.Divergence.Names),
+		checks.CheckConstUnitTypedef(cfg.Checks.Const.Unit.Typedef.Names, cfg.Checks.Const.Unit.Typedef.Typedefs),
+		checks.CheckQualifiedEnumConst(),
+		checks.CheckInt64JSUnsafe(),
+		checks.CheckNoStructConst(),
+		checks.CheckConstantRef(),
+		checks.CheckRepeatedInlineContainer(cfg.Checks.Container.Repeated.Inline.MinOccurrences),
+		checks.CheckDefinitionOrder(),
+		checks.CheckEnumAliasAnnotation(),
+		checks.CheckFlagEnumPowersOfTwo(),
+		checks.CheckSharedEnumLocation(cfg.Checks.Enum.Location.Shared),
+		checks.CheckEnumSize(cfg.Checks.Enum.Size.Warning, cfg.Checks.Enum.Size.Error),
+		checks.CheckEnumStability(cfg.Checks.Enum.Stability.Baseline),
+		checks.CheckUnusedEnumMember(),
+		checks.CheckMaxEnumMembers(cfg.Checks.Enum.Members.Max),
+		checks.CheckExceptionFieldDuplication(cfg.Checks.Exception.Field.Duplicate.Base),
+		checks.CheckExceptionNoRequired(),
+		checks.CheckFieldIDDuplicate(),
+		checks.CheckFieldIDMissing(),
+		checks.CheckIDFieldLengthBound(cfg.Checks.Field.ID.Length.Bound.Names, cfg.Checks.Field.ID.Length.Bound.Key),
+		checks.CheckFieldIDNegative(),
+		checks.CheckConsistentIDWidth(cfg.Checks.Field.ID.Width.Consistent.Names),
+		checks.CheckFieldIDZero(),
+		checks.CheckCaseInsensitiveFieldCollision(),
+		checks.CheckNoTypeInFieldName(),
+		checks.CheckBoolFieldNaming(cfg.Checks.Field.Bool.Naming.Prefixes),
+		checks.CheckContainerDefaultEmpty(),
+		checks.CheckDefaultConstDefined(),
+		checks.CheckContainerFieldOptional(),
+		checks.CheckFieldOptional(),
+		checks.CheckOptionalDoc(),
+		checks.CheckPIIAnnotation(cfg.Checks.Field.PII.Names),
+		checks.CheckNoNewRequiredField(cfg.Checks.Field.Required.Added.Baseline),
+		checks.CheckFieldRequiredness(),
+		checks.CheckReservedFieldPrefix(cfg.Checks.Field.Reserved.Prefix.Prefixes),
+		checks.CheckNoSmallInts(cfg.Checks.Field.Small.Int.Allow),
+		checks.CheckFieldDocMissing(),
+		checks.CheckDocTypeConsistency(cfg.Checks.Field.Doc.Type.Mismatch.Rules),
+		checks.CheckFirstFieldIDIsOne(cfg.Checks.Field.ID.First.Contiguous),
+		checks.CheckFieldIDGap(cfg.Checks.Field.ID.Gap.Step),
+		checks.CheckOrphanFiles(cfg.Checks.File.Orphan.Roots),
+		checks.CheckNoBareContainerArg(),
+		checks.CheckNoBoolResult(cfg.Checks.Function.Bool.Result.Verbs),
+		checks.CheckNoExceptionReturn(),
+		checks.CheckResultStructComplexity(cfg.Checks.Function.Result.Complexity.MaxExceptions),
+		checks.CheckPublicFunctionNamedTypes(cfg.Checks.Function.Public.Annotation),
+		checks.CheckCircularImport(),
+		checks.CheckNarrowerInclude(),
+		checks.CheckIncludePath(),
+		checks.CheckIncludeRestricted(cfg.Checks.Include.Restricted),
+		checks.CheckIncludeSeparator(),
+		checks.CheckUnusedInclude(),
+		checks.CheckInteger64bit(),
+		checks.CheckSizeLimits(cfg.Checks.Limits.Size.MaxFields),
+		checks.CheckMapKeyDocConsistency(),
+		checks.CheckMapKeyType(cfg.Checks.Map.Key.AllowedTypes, cfg.Checks.Map.Key.DisallowedTypes),
+		checks.CheckMapSameKeyValueType(cfg.Checks.Map.Key.Value.Same.Names),
+		checks.CheckConsistentMapKeyWidth(cfg.Checks.Map.Key.Width.Consistent.Names),
+		checks.CheckMapValueType(cfg.Checks.Map.Value.AllowedTypes, cfg.Checks.Map.Value.DisallowedTypes),
+		checks.CheckNamesReserved(cfg.Checks.Names.Reserved),
+		checks.CheckNamingConvention(cfg.Checks.Naming.Convention),
+		checks.CheckLanguageReservedWords(cfg.Checks.Naming.Reserved.Languages),
+		checks.CheckDuplicateNamespaceLanguage(),
+		checks.CheckNamespaceConsistencyAcrossIncludes(cfg.Checks.Namespace.Include.Consistency.Languages),
+		checks.CheckNamespaceMatchesPath(cfg.Checks.Namespace.Path.Match),
+		checks.CheckNamespacePattern(cfg.Checks.Namespace.Patterns),
+		checks.CheckRequiredNamespaces(cfg.Checks.Namespace.Required),
+		checks.CheckNoWildcardNamespace(),
+		checks.CheckPairedStructIDs(pairSuffixes),
+		checks.CheckStructRoleSeparation(),
+		checks.CheckUnusedStruct(cfg.Checks.Struct.Unused.Allowed),
+		checks.CheckDuplicatedFieldBlocks(cfg.Checks.Struct.Duplicated.Fields.MinFields, cfg.Checks.Struct.Duplicated.Fields.MinStructs),
+		checks.CheckNoDefaultsInStableStructs(cfg.Checks.Struct.Stable.Annotation),
+		checks.CheckQualifiedReferenceDepth(qualificationDepth),
+		checks.CheckQualifyIncludedRefs(),
+		checks.CheckServiceResourceCohesion(cfg.Checks.Service.Cohesion.MaxNouns),
+		checks.CheckServiceCQRS(cfg.Checks.Service.CQRS.ReadVerbs, cfg.Checks.Service.CQRS.WriteVerbs),
+		checks.CheckDeprecatedServiceMethods(),
+		checks.CheckListOrderingAnnotation(),
+		checks.CheckDuplicateServiceDefinition(),
+		checks.CheckMethodIDAnnotation(),
+		checks.CheckInheritedMethodCaseClash(),
+		checks.CheckThrowsDocumented(),
+		checks.CheckServiceVisibility(cfg.Checks.Service.Visibility.Key, cfg.Checks.Service.Visibility.Allowed),
+		checks.CheckSetValueType(cfg.Checks.Set.AllowedTypes, cfg.Checks.Set.DisallowedTypes),
+		checks.CheckTypeComplexityBudget(cfg.Checks.Type.Complexity.Budget.MaxNodes),
+		checks.CheckTypeRecursion(),
+		checks.CheckTypeReferenceCycle(),
+		checks.CheckTypedefConsistency(),
+		checks.CheckTypedefNotKeyword(),
+		checks.CheckTypes(cfg.Checks.Types.AllowedTypes, cfg.Checks.Types.DisallowedTypes),
+		checks.CheckUnionMigrationIDs(cfg.Checks.Union.Migration.Baseline),
+		checks.CheckVoidMutator(cfg.Checks.Service.Method.Void.Mutator),
+	}
+
+	for _, filename := range cfg.Rules {
+		rules, err := checks.LoadRules(filename)
 		if err != nil {
 			return nil, err
 		}
+		allChecks = append(allChecks, rules...)
+	}
+
+	return allChecks, nil
+}
+
+// lint lints the given paths and calls report with each batch of messages
+// (one for each file, followed by one for any multi-file checks).
+func lint(l *thriftcheck.Linter, paths []string, changed changedLines, src sources, report func(thriftcheck.Messages) error) error {
+	if len(paths) == 1 && paths[0] == "-" {
+		data, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return err
+		}
+		if src != nil {
+			src.add(*stdinFilename, data)
+		}
+		msgs, err := l.Lint(bytes.NewReader(data), *stdinFilename)
+		if err != nil {
+			return err
+		}
+		return report(msgs)
+	}
+	paths, err := expandPaths(paths, *recursiveFlag)
+	if err != nil {
+		return err
+	}
+	if changed != nil {
+		paths = slices.DeleteFunc(paths, func(path string) bool {
+			_, ok := changed[filepath.Clean(path)]
+			return !ok
+		})
+		return l.LintFilesFunc(paths, func(msgs thriftcheck.Messages) error {
+			return report(changed.filter(msgs))
+		})
 	}
+	return l.LintFilesFunc(paths, report)
+}
 
-	return filenames, nil
+// informationalChecks names the checks whose messages are reported without
+// affecting the exit status.
+var informationalChecks = map[string]bool{
+	"limit.truncated":   true,
+	"multifile.skipped": true,
 }
 
 func main() {
@@ -223,44 +737,105 @@ func main() {
 		os.Exit(0)
 	}
 
+	newFormatter, ok := formatters[*formatFlag]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "unknown format: %s, valid formats are: %v\n", *formatFlag, slices.Sorted(maps.Keys(formatters)))
+		os.Exit(1 << uint(thriftcheck.Error))
+	}
+
+	if *fixFlag && slices.Equal(flag.Args(), []string{"-"}) {
+		fmt.Fprintln(os.Stderr, "--fix can't be used with stdin")
+		os.Exit(1 << uint(thriftcheck.Error))
+	}
+
+	if *formatFlag == "diff" && (*fixFlag || slices.Equal(flag.Args(), []string{"-"})) {
+		fmt.Fprintln(os.Stderr, "--format diff can't be used with --fix or stdin")
+		os.Exit(1 << uint(thriftcheck.Error))
+	}
+
+	if *baselineDiff != "" && slices.Equal(flag.Args(), []string{"-"}) {
+		fmt.Fprintln(os.Stderr, "--baseline-diff can't be used with stdin")
+		os.Exit(1 << uint(thriftcheck.Error))
+	}
+
+	if *baselineDiff != "" && *since != "" {
+		fmt.Fprintln(os.Stderr, "--baseline-diff can't be used with --since")
+		os.Exit(1 << uint(thriftcheck.Error))
+	}
+
+	if *benchFlag > 0 && slices.Equal(flag.Args(), []string{"-"}) {
+		fmt.Fprintln(os.Stderr, "--bench can't be used with stdin")
+		os.Exit(1 << uint(thriftcheck.Error))
+	}
+
+	if *writeBaseFlag && *baselineFile == "" {
+		fmt.Fprintln(os.Stderr, "--write-baseline requires --baseline")
+		os.Exit(1 << uint(thriftcheck.Error))
+	}
+
 	// Load the (optional) configuration file
 	var cfg Config
-	if err := loadConfig(&cfg); err != nil {
+	if len(configFiles) == 0 {
+		configFiles = Strings{".thriftcheck.toml"}
+	}
+	if err := loadConfig(&cfg, configFiles...); err != nil {
 		fmt.Fprintln(os.Stderr, err)
 		os.Exit(1 << uint(thriftcheck.Error))
 	}
 
-	if len(includes) > 0 {
-		cfg.Includes = includes
-	}
+	applyFlags(&cfg)
 
 	// Build the set of checks we'll use for the linter
-	allChecks := thriftcheck.Checks{
-		checks.CheckConstantRef(),
-		checks.CheckEnumSize(cfg.Checks.Enum.Size.Warning, cfg.Checks.Enum.Size.Error),
-		checks.CheckFieldIDMissing(),
-		checks.CheckFieldIDNegative(),
-		checks.CheckFieldIDZero(),
-		checks.CheckFieldOptional(),
-		checks.CheckFieldRequiredness(),
-		checks.CheckFieldDocMissing(),
-		checks.CheckIncludePath(),
-		checks.CheckIncludeRestricted(cfg.Checks.Include.Restricted),
-		checks.CheckInteger64bit(),
-		checks.CheckMapKeyType(cfg.Checks.Map.Key.AllowedTypes, cfg.Checks.Map.Key.DisallowedTypes),
-		checks.CheckMapValueType(cfg.Checks.Map.Value.AllowedTypes, cfg.Checks.Map.Value.DisallowedTypes),
-		checks.CheckNamesReserved(cfg.Checks.Names.Reserved),
-		checks.CheckNamespacePattern(cfg.Checks.Namespace.Patterns),
-		checks.CheckSetValueType(cfg.Checks.Set.AllowedTypes, cfg.Checks.Set.DisallowedTypes),
-		checks.CheckTypes(cfg.Checks.Types.AllowedTypes, cfg.Checks.Types.DisallowedTypes),
+	allChecks, err := buildChecks(&cfg)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1 << uint(thriftcheck.Error))
 	}
 
-	checks := allChecks
-	if len(cfg.Checks.Disabled) > 0 {
-		checks = checks.Without(cfg.Checks.Disabled)
+	if args := flag.Args(); len(args) == 1 && args[0] == "selftest" {
+		if err := selftest(os.Stdout); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1 << uint(thriftcheck.Error))
+		}
+		os.Exit(0)
+	}
+
+	if args := flag.Args(); len(args) > 0 && args[0] == "explain" {
+		if len(args) != 2 {
+			flag.Usage()
+			os.Exit(1 << uint(thriftcheck.Error))
+		}
+		if err := explain(os.Stdout, allChecks, args[1]); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1 << uint(thriftcheck.Error))
+		}
+		os.Exit(0)
+	}
+
+	if args := flag.Args(); len(args) > 0 && args[0] == "graph" {
+		if err := graph(os.Stdout, os.Stderr, args[1:], cfg.Includes); err != nil {
+			if errors.Is(err, flag.ErrHelp) {
+				os.Exit(0)
+			}
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1 << uint(thriftcheck.Error))
+		}
+		os.Exit(0)
+	}
+
+	checks := selectChecks(&cfg, allChecks)
+	if *runFlag != "" {
+		if checks, err = runCheck(allChecks, *runFlag); err != nil {
+			fmt.Fprintf(os.Stderr, "--run: %v\n", err)
+			os.Exit(1 << uint(thriftcheck.Error))
+		}
 	}
-	if len(cfg.Checks.Enabled) > 0 {
-		checks = checks.With(cfg.Checks.Enabled)
+	if *dumpFlag {
+		if err := dumpConfig(os.Stdout, &cfg, allChecks, checks); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1 << uint(thriftcheck.Error))
+		}
+		os.Exit(0)
 	}
 	if *listFlag {
 		enabledNames := make(map[string]bool, len(checks))
@@ -278,8 +853,21 @@ func main() {
 	}
 
 	// Build the set of linter options
+	severities := checkSeverities(&cfg)
+	templates, err := messageTemplates(&cfg)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1 << uint(thriftcheck.Error))
+	}
 	options := []thriftcheck.Option{
 		thriftcheck.WithIncludes(cfg.Includes),
+		thriftcheck.WithPathSeverities(cfg.Severities),
+		thriftcheck.WithSuppressions(cfg.Suppressions),
+		thriftcheck.WithCheckSeverities(severities),
+		thriftcheck.WithMessageTemplates(templates),
+		thriftcheck.WithSkipMultiFileOnUnresolved(*skipMultiFile),
+		thriftcheck.WithFailFast(*failFast),
+		thriftcheck.WithJobs(*jobsFlag),
 	}
 	if *verboseFlag {
 		logger := log.New(os.Stderr, "", log.Ltime|log.Lmicroseconds|log.Lshortfile)
@@ -287,27 +875,206 @@ func main() {
 	}
 
 	paths := flag.Args()
+
+	// Determine which lines have changed since the given git ref
+	var changed changedLines
+	if *since != "" {
+		var err error
+		if changed, err = gitChangedLines(".", *since); err != nil {
+			fmt.Fprintf(os.Stderr, "--since: %v\n", err)
+			os.Exit(1 << uint(thriftcheck.Error))
+		}
+		if len(paths) == 0 {
+			if paths = changed.files(); len(paths) == 0 {
+				os.Exit(0)
+			}
+		}
+	}
+
 	if len(paths) == 0 {
 		flag.Usage()
 		os.Exit(0)
 	}
 
+	// Determine which definitions have changed since the baseline
+	if *baselineDiff != "" {
+		var err error
+		if changed, err = changedDefinitions(*baselineDiff, paths); err != nil {
+			fmt.Fprintf(os.Stderr, "--baseline-diff: %v\n", err)
+			os.Exit(1 << uint(thriftcheck.Error))
+		}
+	}
+
+	if *dryRunFlag {
+		if err := dryRun(os.Stdout, paths, changed, checks); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1 << uint(thriftcheck.Error))
+		}
+		os.Exit(0)
+	}
+
+	if *benchFlag > 0 {
+		filenames, err := expandPaths(paths, *recursiveFlag)
+		if err == nil {
+			err = bench(os.Stderr, filenames, checks, options, *benchFlag)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "--bench: %v\n", err)
+			os.Exit(1 << uint(thriftcheck.Error))
+		}
+		os.Exit(0)
+	}
+
+	// Load the baseline of existing findings
+	var base baseline
+	if *baselineFile != "" && !*writeBaseFlag {
+		var err error
+		if base, err = readBaseline(*baselineFile); err != nil {
+			fmt.Fprintf(os.Stderr, "--baseline: %v\n", err)
+			os.Exit(1 << uint(thriftcheck.Error))
+		}
+	}
+
+	// Load the owners of the linted files
+	var owns owners
+	if *ownersFile != "" {
+		var err error
+		if owns, err = readOwners(*ownersFile); err != nil {
+			fmt.Fprintf(os.Stderr, "--owners: %v\n", err)
+			os.Exit(1 << uint(thriftcheck.Error))
+		}
+	}
+
 	// Create the linter and run it over the input files
+	var src sources
+	if *showSource {
+		src = sources{}
+	}
 	linter := thriftcheck.NewLinter(checks, options...)
-	messages, err := lint(linter, paths)
+	var w io.Writer = os.Stdout
+	var outFile *os.File
+	if *outputFile != "" {
+		if outFile, err = createOutput(*outputFile); err != nil {
+			fmt.Fprintf(os.Stderr, "--output: %v\n", err)
+			os.Exit(1 << uint(thriftcheck.Error))
+		}
+		w = outFile
+	}
+	out := newFormatter(w, src, checks)
+	var limits *checkLimits
+	if *perCheckLimit > 0 {
+		limits = newCheckLimits(*perCheckLimit)
+	}
+	var fixes *fixer
+	if *fixFlag {
+		fixes = newFixer()
+	}
+	var hook *webhook
+	if *webhookURL != "" {
+		hook = newWebhook(*webhookURL, os.Stderr)
+	}
+
+	// Report the linter's messages. When streaming, each batch is written as
+	// soon as it's available. Otherwise, all of the messages are collected
+	// and written at the end of the run.
+	status := 0
+	counts := make(map[thriftcheck.Severity]int)
+	reported := make(map[string]bool)
+	report := func(messages thriftcheck.Messages) error {
+		if base != nil {
+			messages = base.filter(messages)
+		}
+		for _, m := range messages {
+			reported[m.Check] = true
+		}
+		if *errorsOnly {
+			messages = slices.DeleteFunc(messages, func(m thriftcheck.Message) bool {
+				return m.Severity != thriftcheck.Error
+			})
+		}
+		// Fixed messages are no longer reported.
+		if fixes != nil {
+			var err error
+			if messages, err = fixes.apply(messages); err != nil {
+				return err
+			}
+		}
+		for _, m := range messages {
+			if !informationalChecks[m.Check] {
+				status |= 1 << uint(m.Severity)
+			}
+		}
+		// Limited messages still count towards the exit status.
+		if limits != nil {
+			messages = limits.filter(messages)
+		}
+		for _, m := range messages {
+			counts[m.Severity]++
+		}
+		if owns != nil {
+			owns.assign(messages)
+		}
+		if hook != nil {
+			hook.send(messages)
+		}
+		return out.write(messages)
+	}
+
+	var messages thriftcheck.Messages
+	collect := func(m thriftcheck.Messages) error {
+		messages = append(messages, m...)
+		return nil
+	}
+	if *writeBaseFlag {
+		err = lint(linter, paths, changed, src, collect)
+		if err == nil {
+			err = writeBaseline(*baselineFile, messages)
+		}
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1 << uint(thriftcheck.Error))
+		}
+		fmt.Fprintf(os.Stderr, "wrote %d findings to %s\n", len(messages), *baselineFile)
+		os.Exit(0)
+	}
+	if *streamFlag {
+		collect = report
+	}
+
+	err = lint(linter, paths, changed, src, collect)
+	if err == nil && !*streamFlag {
+		err = report(messages)
+	}
+	// Notes about the checks that went over the limit follow their messages.
+	if err == nil && limits != nil {
+		err = out.write(limits.truncated())
+	}
+	// Files always get a complete document, even if linting failed.
+	if err == nil || *streamFlag || outFile != nil {
+		if cerr := out.close(); err == nil {
+			err = cerr
+		}
+	}
+	if outFile != nil {
+		if cerr := outFile.Close(); err == nil {
+			err = cerr
+		}
+	}
 	if err != nil {
 		fmt.Fprintln(os.Stderr, err)
 		os.Exit(1 << uint(thriftcheck.Error))
 	}
 
-	// Print any messages reported by the linter
-	status := 0
-	for _, m := range messages {
-		if *errorsOnly && m.Severity != thriftcheck.Error {
-			continue
-		}
-		fmt.Println(m)
-		status |= 1 << uint(m.Severity)
+	// Fail if any of the required checks didn't report any findings
+	for _, name := range missingFindings(reported, required) {
+		fmt.Fprintf(os.Stderr, "--require-findings: %s reported no findings\n", name)
+		status |= 1 << uint(thriftcheck.Error)
+	}
+	if fixes != nil && fixes.count > 0 {
+		fmt.Fprintf(os.Stderr, "fixed %s\n", plural(fixes.count, "issue"))
+	}
+	if outFile != nil {
+		fmt.Fprintln(os.Stderr, summary(counts[thriftcheck.Error], counts[thriftcheck.Warning], *outputFile))
 	}
 	os.Exit(status)
 }
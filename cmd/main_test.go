@@ -0,0 +1,220 @@
+// Copyright 2026 Pinterest
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/pinterest/thriftcheck"
+	"github.com/pinterest/thriftcheck/checks"
+)
+
+func TestLintStdin(t *testing.T) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.WriteString("include \"missing.thrift\"\nstruct S {\n  1: set<set<string>> s\n}"); err != nil {
+		t.Fatal(err)
+	}
+	w.Close()
+
+	stdin, filename := os.Stdin, *stdinFilename
+	defer func() { os.Stdin, *stdinFilename = stdin, filename }()
+	os.Stdin = r
+	if err := flag.Set("stdin-filepath", "unsaved/buffer.thrift"); err != nil {
+		t.Fatal(err)
+	}
+
+	var base thriftcheck.ThriftType
+	if err := base.UnmarshalString("base"); err != nil {
+		t.Fatal(err)
+	}
+
+	// The synthetic filename doesn't exist on disk, which the checks that
+	// resolve includes relative to it must tolerate.
+	linter := thriftcheck.NewLinter(thriftcheck.Checks{
+		checks.CheckSetValueType([]thriftcheck.ThriftType{base}, []thriftcheck.ThriftType{}),
+		checks.CheckCircularImport(),
+	})
+	var msgs thriftcheck.Messages
+	err = lint(linter, []string{"-"}, nil, nil, func(m thriftcheck.Messages) error {
+		msgs = append(msgs, m...)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := `unsaved/buffer.thrift:3:6: error: set value type "set<string>" is not allowed (set.value.type)`
+	if len(msgs) != 1 || msgs[0].String() != want {
+		t.Errorf("expected [%s], got %v", want, msgs)
+	}
+}
+
+func TestStdinFilenameAlias(t *testing.T) {
+	filename := *stdinFilename
+	defer func() { *stdinFilename = filename }()
+
+	if err := flag.Set("stdin-filename", "unsaved/alias.thrift"); err != nil {
+		t.Fatal(err)
+	}
+	if *stdinFilename != "unsaved/alias.thrift" {
+		t.Errorf("expected --stdin-filename to set the --stdin-filepath value, got %q", *stdinFilename)
+	}
+}
+
+// writeFiles writes files (keyed by their slash-separated paths) to a new
+// temporary directory, creating any subdirectories, and returns the
+// directory.
+func writeFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestLoadConfigMerge(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"org.toml": `
+includes = ["shared", "vendor", "legacy"]
+[checks]
+disabled = ["field.id.zero"]
+[checks.enum.size]
+warning = 100
+error = 200
+`,
+		"local.toml": `
+includes = ["idl"]
+[checks.enum.size]
+warning = 50
+`,
+	})
+
+	var cfg Config
+	if err := loadConfig(&cfg, filepath.Join(dir, "org.toml"), filepath.Join(dir, "local.toml")); err != nil {
+		t.Fatal(err)
+	}
+	if expected := []string{"idl"}; !reflect.DeepEqual(cfg.Includes, expected) {
+		t.Errorf("expected includes %v, got %v", expected, cfg.Includes)
+	}
+	if expected := []string{"field.id.zero"}; !reflect.DeepEqual(cfg.Checks.Disabled, expected) {
+		t.Errorf("expected disabled checks %v, got %v", expected, cfg.Checks.Disabled)
+	}
+	if size := cfg.Checks.Enum.Size; size.Warning != 50 || size.Error != 200 {
+		t.Errorf("expected enum size limits 50 and 200, got %d and %d", size.Warning, size.Error)
+	}
+}
+
+func TestLoadConfigExtends(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"base.toml": `
+includes = ["base"]
+[checks.enum.size]
+warning = 100
+error = 200
+`,
+		"team.toml": `
+extends = "base.toml"
+[checks.enum.size]
+error = 150
+`,
+		"repo.toml": `
+extends = "team.toml"
+[checks.enum.size]
+warning = 50
+`,
+	})
+
+	var cfg Config
+	if err := loadConfig(&cfg, filepath.Join(dir, "repo.toml")); err != nil {
+		t.Fatal(err)
+	}
+	if expected := []string{"base"}; !reflect.DeepEqual(cfg.Includes, expected) {
+		t.Errorf("expected includes %v, got %v", expected, cfg.Includes)
+	}
+	if size := cfg.Checks.Enum.Size; size.Warning != 50 || size.Error != 150 {
+		t.Errorf("expected enum size limits 50 and 150, got %d and %d", size.Warning, size.Error)
+	}
+
+	dir = writeFiles(t, map[string]string{
+		"repo.toml": `extends = "missing.toml"`,
+	})
+	if err := loadConfig(&cfg, filepath.Join(dir, "repo.toml")); err == nil || !strings.Contains(err.Error(), "extends") {
+		t.Errorf("expected an extends error, got %v", err)
+	}
+}
+
+func TestLoadConfigExtendsCycle(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"a.toml": `extends = "b.toml"`,
+		"b.toml": `extends = "c.toml"`,
+		"c.toml": `extends = "a.toml"`,
+	})
+
+	var cfg Config
+	err := loadConfig(&cfg, filepath.Join(dir, "a.toml"))
+	if err == nil {
+		t.Fatal("expected a cycle error")
+	}
+	a, b, c := filepath.Join(dir, "a.toml"), filepath.Join(dir, "b.toml"), filepath.Join(dir, "c.toml")
+	if expected := a + ": cyclic extends: " + strings.Join([]string{a, b, c, a}, " -> "); err.Error() != expected {
+		t.Errorf("expected %q, got %q", expected, err)
+	}
+}
+
+func TestBuildChecksDefaults(t *testing.T) {
+	var cfg Config
+	all, err := buildChecks(&cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		check string
+		src   string
+		want  int
+	}{
+		{"reference.qualification.depth", "struct S {\n  1: optional foo.Bar b\n}", 0},
+		{"reference.qualification.depth", "struct S {\n  1: optional foo.bar.Baz b\n}", 1},
+	}
+	for _, tt := range tests {
+		check, err := runCheck(all, tt.check)
+		if err != nil {
+			t.Fatal(err)
+		}
+		msgs, err := thriftcheck.NewLinter(check).Lint(strings.NewReader(tt.src), "t.thrift")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(msgs) != tt.want {
+			t.Errorf("%s: expected %d messages for %q, got %v", tt.check, tt.want, tt.src, msgs)
+		}
+	}
+}
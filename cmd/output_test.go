@@ -0,0 +1,75 @@
+// Copyright 2026 Pinterest
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCreateOutput(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "reports", "thriftcheck.json")
+	f, err := createOutput(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := formatters["json"](f, nil, nil)
+	if err := out.write(testMessages); err != nil {
+		t.Fatal(err)
+	}
+	if err := out.close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []struct {
+		Filename string `json:"filename"`
+		Check    string `json:"check"`
+	}
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, data)
+	}
+	if len(got) != len(testMessages) {
+		t.Fatalf("expected %d messages, got %d", len(testMessages), len(got))
+	}
+	for i, m := range testMessages {
+		if got[i].Filename != m.Filename || got[i].Check != m.Check {
+			t.Errorf("message %d: expected %s (%s), got %s (%s)", i, m.Filename, m.Check, got[i].Filename, got[i].Check)
+		}
+	}
+}
+
+func TestSummary(t *testing.T) {
+	for _, tt := range []struct {
+		errors, warnings int
+		want             string
+	}{
+		{0, 0, "0 errors and 0 warnings written to out.json"},
+		{1, 2, "1 error and 2 warnings written to out.json"},
+		{3, 1, "3 errors and 1 warning written to out.json"},
+	} {
+		if got := summary(tt.errors, tt.warnings, "out.json"); got != tt.want {
+			t.Errorf("expected %q, got %q", tt.want, got)
+		}
+	}
+}
@@ -0,0 +1,97 @@
+// Copyright 2026 Pinterest
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pinterest/thriftcheck"
+)
+
+// ownerRule assigns owners to the files matched by a pattern.
+type ownerRule struct {
+	pattern ignorePattern
+	owners  string
+}
+
+// owners maps files to their owners using the rules from a CODEOWNERS-style
+// file. When several rules match a file, the last one wins.
+type owners []ownerRule
+
+// readOwners reads a CODEOWNERS-style file. Each line is a pattern followed
+// by the owners of the files that it matches, and patterns are matched like
+// those in .thriftcheckignore files, relative to the file's directory (or to
+// its parent, for files in a .github or docs directory). A pattern also
+// matches everything below the directories that it matches. Blank lines and
+// lines starting with "#" are skipped.
+func readOwners(filename string) (owners, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	dir, err := filepath.Abs(filepath.Dir(filename))
+	if err != nil {
+		return nil, err
+	}
+	if base := filepath.Base(dir); base == ".github" || base == "docs" {
+		dir = filepath.Dir(dir)
+	}
+
+	var o owners
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Fields(line)
+		o = append(o, ownerRule{
+			pattern: ignorePattern{dir: dir, pattern: strings.TrimSuffix(fields[0], "/")},
+			owners:  strings.Join(fields[1:], " "),
+		})
+	}
+	return o, scanner.Err()
+}
+
+// lookup returns the owners of a file, or an empty string if it has none.
+func (o owners) lookup(filename string) string {
+	path, err := filepath.Abs(filename)
+	if err != nil {
+		return ""
+	}
+	for i := len(o) - 1; i >= 0; i-- {
+		for p := path; ; p = filepath.Dir(p) {
+			if p == o[i].pattern.dir || p == filepath.Dir(p) {
+				break
+			}
+			if o[i].pattern.match(p) {
+				return o[i].owners
+			}
+		}
+	}
+	return ""
+}
+
+// assign sets the Owner of each message to the owners of its file.
+func (o owners) assign(msgs thriftcheck.Messages) {
+	for i := range msgs {
+		msgs[i].Owner = o.lookup(msgs[i].Filename)
+	}
+}
@@ -0,0 +1,91 @@
+// Copyright 2026 Pinterest
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pinterest/thriftcheck"
+)
+
+func TestOwners(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.Mkdir(filepath.Join(dir, ".github"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	codeowners := "# Owners\n*.thrift @platform\n/idl/users/ @identity\n\n/idl/billing/ @payments @finance\n/idl/billing/legacy.thrift\n"
+	filename := filepath.Join(dir, ".github", "CODEOWNERS")
+	if err := os.WriteFile(filename, []byte(codeowners), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	o, err := readOwners(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]string{
+		"idl/users/user.thrift":        "@identity",
+		"idl/users/nested/more.thrift": "@identity",
+		"idl/billing/invoice.thrift":   "@payments @finance",
+		"idl/billing/legacy.thrift":    "",
+		"idl/common.thrift":            "@platform",
+		"idl/users.txt":                "",
+	}
+	for name, want := range tests {
+		if got := o.lookup(filepath.Join(dir, name)); got != want {
+			t.Errorf("%s: expected owner %q, got %q", name, want, got)
+		}
+	}
+
+	msgs := thriftcheck.Messages{
+		{Filename: filepath.Join(dir, "idl/users/user.thrift"), Check: "check", Message: "users"},
+		{Filename: filepath.Join(dir, "idl/billing/invoice.thrift"), Check: "check", Message: "billing"},
+		{Filename: filepath.Join(dir, "idl/users.txt"), Check: "check", Message: "unowned"},
+	}
+	o.assign(msgs)
+
+	var text strings.Builder
+	if err := formatText(&text, msgs, nil); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{
+		"idl/users/user.thrift:0:1: warning: users (check) (owner: @identity)",
+		"idl/billing/invoice.thrift:0:1: warning: billing (check) (owner: @payments @finance)",
+		"idl/users.txt:0:1: warning: unowned (check)",
+	}
+	if got := strings.ReplaceAll(text.String(), dir+string(filepath.Separator), ""); got != strings.Join(want, "\n")+"\n" {
+		t.Errorf("expected:\n%s\ngot:\n%s", strings.Join(want, "\n"), got)
+	}
+
+	var decoded []map[string]any
+	b, err := json.Marshal(msgs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(b, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if decoded[0]["owner"] != "@identity" || decoded[1]["owner"] != "@payments @finance" {
+		t.Errorf("expected owners in the JSON output, got %s", b)
+	}
+	if _, ok := decoded[2]["owner"]; ok {
+		t.Errorf("expected no owner for an unowned file, got %s", b)
+	}
+}
@@ -0,0 +1,159 @@
+// Copyright 2026 Pinterest
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/danwakefield/fnmatch"
+)
+
+// ignoreFilename is the name of the files that list paths to exclude when
+// expanding directories.
+const ignoreFilename = ".thriftcheckignore"
+
+// ignorePattern is a glob pattern read from the ignore file in dir.
+type ignorePattern struct {
+	dir     string
+	pattern string
+}
+
+// match reports whether path (which is below the pattern's directory) is
+// matched by the pattern. Patterns without a slash match file or directory
+// names at any depth; other patterns match the path relative to dir.
+func (p ignorePattern) match(path string) bool {
+	rel, err := filepath.Rel(p.dir, path)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return false
+	}
+	if !strings.Contains(p.pattern, "/") {
+		return fnmatch.Match(p.pattern, filepath.Base(path), fnmatch.FNM_NOESCAPE)
+	}
+	return fnmatch.Match(strings.TrimPrefix(p.pattern, "/"), filepath.ToSlash(rel), fnmatch.FNM_NOESCAPE|fnmatch.FNM_PATHNAME)
+}
+
+// readIgnoreFile reads the patterns from dir's ignore file, if it has one.
+// Blank lines and lines starting with "#" are skipped.
+func readIgnoreFile(dir string) ([]ignorePattern, error) {
+	f, err := os.Open(filepath.Join(dir, ignoreFilename))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var patterns []ignorePattern
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		patterns = append(patterns, ignorePattern{dir: dir, pattern: strings.TrimSuffix(line, "/")})
+	}
+	return patterns, scanner.Err()
+}
+
+// candidate is a file that was found while expanding paths. skip is the
+// reason the file won't be linted, or empty if it will be.
+type candidate struct {
+	path string
+	skip string
+}
+
+// expandPaths expands any directories in paths to all of the nested .thrift
+// files, skipping those excluded by .thriftcheckignore files. Unless
+// recursive is set, only the files directly in each directory are included.
+// Paths that name files are always included.
+func expandPaths(paths []string, recursive bool) ([]string, error) {
+	candidates, err := findCandidates(paths, recursive)
+	if err != nil {
+		return nil, err
+	}
+	var filenames []string
+	for _, c := range candidates {
+		if c.skip == "" {
+			filenames = append(filenames, c.path)
+		}
+	}
+	return filenames, nil
+}
+
+// findCandidates walks paths like expandPaths, but also returns the files
+// and directories that it skips along with the reason for skipping them.
+// The contents of skipped directories aren't listed.
+func findCandidates(paths []string, recursive bool) ([]candidate, error) {
+	var candidates []candidate
+	for _, root := range paths {
+		info, err := os.Stat(root)
+		if err != nil {
+			return nil, err
+		}
+
+		if !info.IsDir() {
+			candidates = append(candidates, candidate{path: root})
+			continue
+		}
+
+		var ignored []ignorePattern
+		err = filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+
+			for _, p := range ignored {
+				if p.match(path) {
+					skip := fmt.Sprintf("ignored by %s: %s", filepath.Join(p.dir, ignoreFilename), p.pattern)
+					candidates = append(candidates, candidate{path: path, skip: skip})
+					if d.IsDir() {
+						return filepath.SkipDir
+					}
+					return nil
+				}
+			}
+
+			if d.IsDir() && path != root && !recursive {
+				candidates = append(candidates, candidate{path: path, skip: "subdirectories aren't expanded with --recursive=false"})
+				return filepath.SkipDir
+			}
+
+			if d.IsDir() {
+				patterns, err := readIgnoreFile(path)
+				if err != nil {
+					return err
+				}
+				ignored = append(ignored, patterns...)
+			} else if filepath.Ext(path) == ".thrift" {
+				candidates = append(candidates, candidate{path: path})
+			} else if d.Name() != ignoreFilename {
+				candidates = append(candidates, candidate{path: path, skip: "not a .thrift file"})
+			}
+
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return candidates, nil
+}
@@ -0,0 +1,66 @@
+// Copyright 2026 Pinterest
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestExpandPaths(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"a.thrift":                     "",
+		"README.md":                    "",
+		".thriftcheckignore":           "# generated files\n*.gen.thrift\n\nvendor/\n",
+		"b.gen.thrift":                 "",
+		"vendor/c.thrift":              "",
+		"sub/d.thrift":                 "",
+		"sub/e.gen.thrift":             "",
+		"sub/.thriftcheckignore":       "/old/*.thrift\n",
+		"sub/old/f.thrift":             "",
+		"sub/new/old/g.thrift":         "",
+		"other/.thriftcheckignore.bak": "",
+		"other/h.thrift":               "",
+	})
+
+	got, err := expandPaths([]string{dir, filepath.Join(dir, "b.gen.thrift")}, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var want []string
+	for _, name := range []string{"a.thrift", "other/h.thrift", "sub/d.thrift", "sub/new/old/g.thrift", "b.gen.thrift"} {
+		want = append(want, filepath.Join(dir, name))
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+
+	// Without recursion, only the files directly in the directory are found.
+	got, err = expandPaths([]string{dir, filepath.Join(dir, "sub/d.thrift")}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want = []string{filepath.Join(dir, "a.thrift"), filepath.Join(dir, "sub/d.thrift")}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+}
+
+func TestExpandPathsMissing(t *testing.T) {
+	if _, err := expandPaths([]string{filepath.Join(t.TempDir(), "missing")}, true); err == nil {
+		t.Error("expected an error")
+	}
+}
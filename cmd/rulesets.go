@@ -0,0 +1,134 @@
+// Copyright 2026 Pinterest
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/pinterest/thriftcheck"
+)
+
+// ruleset is a named group of checks with preset parameters.
+type ruleset struct {
+	// Checks lists the names (or name prefixes) of the ruleset's checks.
+	Checks []string
+	// Preset sets the ruleset's parameters. Values from the configuration
+	// file take precedence over them.
+	Preset func(cfg *Config)
+}
+
+var rulesets = map[string]ruleset{
+	"naming": {
+		Checks: []string{
+			"field.name.case.collision",
+			"names.reserved",
+			"namespace.duplicate.language",
+			"namespace.wildcard",
+		},
+	},
+	"strict": {
+		Checks: []string{
+			"const.struct.type",
+			"constant.ref",
+			"enum",
+			"field",
+			"function",
+			"include.path",
+			"map.key.value.same",
+			"reference",
+			"struct.paired.ids",
+		},
+		Preset: func(cfg *Config) {
+			cfg.Checks.Enum.Size.Warning = 100
+			cfg.Checks.Enum.Size.Error = 500
+			cfg.Checks.Field.ID.First.Contiguous = true
+			cfg.Checks.Function.Result.Complexity.MaxExceptions = 5
+			cfg.Checks.Reference.Qualification.Depth.Max = 1
+		},
+	},
+}
+
+// applyRulesets applies the presets of the named rulesets to cfg.
+func applyRulesets(cfg *Config, names []string) error {
+	for _, name := range names {
+		rs, ok := rulesets[name]
+		if !ok {
+			return fmt.Errorf("unknown ruleset: %s, valid rulesets are: %v", name, slices.Sorted(maps.Keys(rulesets)))
+		}
+		if rs.Preset != nil {
+			rs.Preset(cfg)
+		}
+	}
+	return nil
+}
+
+// optInChecks lists checks that only run when they're explicitly enabled
+// (by name or prefix) by cfg.Checks.Enabled or a ruleset.
+var optInChecks = []string{
+	"definition.order",
+	"enum.member.unused",
+	"field.optional.doc",
+	"include.narrower",
+	"naming.convention",
+	"service.cohesion",
+	"service.list.ordering",
+	"service.method.id",
+	"service.visibility",
+	"struct.role.separation",
+	"struct.unused",
+}
+
+// selectChecks returns the subset of checks that are enabled by cfg. Checks
+// from any rulesets are enabled in addition to cfg.Checks.Enabled, and
+// cfg.Checks.Disabled (along with any checks whose effective severity is
+// "ignore") takes precedence over both.
+func selectChecks(cfg *Config, checks thriftcheck.Checks) thriftcheck.Checks {
+	var enabled []string
+	for _, name := range cfg.Rulesets {
+		enabled = append(enabled, rulesets[name].Checks...)
+	}
+	enabled = append(enabled, cfg.Checks.Enabled...)
+
+	explicit := checks.With(enabled)
+	for _, name := range optInChecks {
+		if !slices.ContainsFunc(explicit, func(c thriftcheck.Check) bool { return c.Name == name }) {
+			checks = checks.Without([]string{name})
+		}
+	}
+
+	if len(cfg.Checks.Disabled) > 0 {
+		checks = checks.Without(cfg.Checks.Disabled)
+	}
+	checks = slices.DeleteFunc(slices.Clone(checks), func(c thriftcheck.Check) bool {
+		return effectiveSeverity(cfg, c) == "ignore"
+	})
+	if len(enabled) > 0 {
+		checks = checks.With(enabled)
+	}
+	return checks
+}
+
+// runCheck returns the single check with exactly the given name, regardless
+// of whether the configuration enables it.
+func runCheck(checks thriftcheck.Checks, name string) (thriftcheck.Checks, error) {
+	for _, check := range checks {
+		if check.Name == name {
+			return thriftcheck.Checks{check}, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown check: %s", name)
+}
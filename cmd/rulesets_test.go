@@ -0,0 +1,194 @@
+// Copyright 2026 Pinterest
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/pinterest/thriftcheck"
+	"github.com/pinterest/thriftcheck/checks"
+)
+
+func loadTestConfig(t *testing.T, content string) (cfg Config) {
+	t.Helper()
+
+	filename := filepath.Join(t.TempDir(), "thriftcheck.toml")
+	if err := os.WriteFile(filename, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := loadConfig(&cfg, filename); err != nil {
+		t.Fatal(err)
+	}
+	return
+}
+
+func TestRulesets(t *testing.T) {
+	all := thriftcheck.Checks{
+		checks.CheckEnumSize(0, 0),
+		checks.CheckFieldIDMissing(),
+		checks.CheckFieldIDZero(),
+		checks.CheckIncludePath(),
+		checks.CheckInteger64bit(),
+		checks.CheckNamesReserved(nil),
+		checks.CheckNoWildcardNamespace(),
+	}
+
+	tests := []struct {
+		config string
+		want   []string
+	}{
+		{
+			config: `rulesets = ["naming"]`,
+			want:   []string{"names.reserved", "namespace.wildcard"},
+		},
+		{
+			config: `rulesets = ["strict"]`,
+			want:   []string{"enum.size", "field.id.missing", "field.id.zero", "include.path"},
+		},
+		{
+			config: `
+rulesets = ["strict", "naming"]
+[checks]
+enabled = ["int.64bit"]
+disabled = ["field.id.zero", "names"]
+`,
+			want: []string{"enum.size", "field.id.missing", "include.path", "int.64bit", "namespace.wildcard"},
+		},
+		{
+			config: `
+rulesets = ["naming"]
+[checks.severity]
+"names" = "ignore"
+"namespace.wildcard" = "warning"
+`,
+			want: []string{"namespace.wildcard"},
+		},
+		{
+			config: `
+rulesets = ["strict"]
+[checks.severity]
+"field" = "ignore"
+"field.id.missing" = "error"
+`,
+			want: []string{"enum.size", "field.id.missing", "include.path"},
+		},
+	}
+
+	for _, tt := range tests {
+		cfg := loadTestConfig(t, tt.config)
+		if got := selectChecks(&cfg, all).SortedNames(); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s:\n- %v\n+ %v", tt.config, tt.want, got)
+		}
+	}
+}
+
+func TestRulesetPresets(t *testing.T) {
+	cfg := loadTestConfig(t, `
+rulesets = ["strict"]
+[checks.enum.size]
+warning = 50
+`)
+
+	if cfg.Checks.Enum.Size.Warning != 50 {
+		t.Errorf("expected the configuration file's warning limit (50), got %d", cfg.Checks.Enum.Size.Warning)
+	}
+	if cfg.Checks.Enum.Size.Error != 500 {
+		t.Errorf("expected the preset error limit (500), got %d", cfg.Checks.Enum.Size.Error)
+	}
+	if !cfg.Checks.Field.ID.First.Contiguous {
+		t.Errorf("expected the preset contiguous field IDs setting")
+	}
+}
+
+func TestRulesetUnknown(t *testing.T) {
+	var cfg Config
+	if err := applyRulesets(&cfg, []string{"unknown"}); err == nil {
+		t.Errorf("expected an error for an unknown ruleset")
+	}
+}
+
+func TestOptInChecks(t *testing.T) {
+	all := thriftcheck.Checks{
+		checks.CheckDefinitionOrder(),
+		checks.CheckIncludePath(),
+	}
+
+	tests := []struct {
+		config string
+		want   []string
+	}{
+		{
+			config: ``,
+			want:   []string{"include.path"},
+		},
+		{
+			config: "[checks]\nenabled = [\"definition.order\"]",
+			want:   []string{"definition.order"},
+		},
+		{
+			config: "[checks]\nenabled = [\"definition\", \"include\"]",
+			want:   []string{"definition.order", "include.path"},
+		},
+		{
+			config: "[checks]\nenabled = [\"definition.order\"]\ndisabled = [\"definition\"]",
+			want:   []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		cfg := loadTestConfig(t, tt.config)
+		if got := selectChecks(&cfg, all).SortedNames(); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s:\n- %v\n+ %v", tt.config, tt.want, got)
+		}
+	}
+}
+
+func TestRunCheck(t *testing.T) {
+	all := thriftcheck.Checks{
+		checks.CheckDefinitionOrder(),
+		checks.CheckFieldIDMissing(),
+		checks.CheckFieldIDZero(),
+		checks.CheckFieldRequiredness(),
+	}
+
+	run, err := runCheck(all, "field.id.zero")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := run.SortedNames(); !reflect.DeepEqual(got, []string{"field.id.zero"}) {
+		t.Errorf("expected [field.id.zero], got %v", got)
+	}
+
+	linter := thriftcheck.NewLinter(run)
+	msgs, err := linter.Lint(strings.NewReader("struct S {\n  0: string a\n  1: string b\n}"), "t.thrift")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := `t.thrift:2:3: error: field ID for "a" is zero (field.id.zero)`
+	if len(msgs) != 1 || msgs[0].String() != want {
+		t.Errorf("expected [%s], got %v", want, msgs)
+	}
+
+	for _, name := range []string{"field", "field.id.*", "unknown"} {
+		if _, err := runCheck(all, name); err == nil {
+			t.Errorf("expected an error for %q", name)
+		}
+	}
+}
@@ -0,0 +1,200 @@
+// Copyright 2026 Pinterest
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/pinterest/thriftcheck"
+)
+
+const sarifSchema = "https://json.schemastore.org/sarif-2.1.0.json"
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	Version        string      `json:"version"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID               string     `json:"id"`
+	ShortDescription *sarifText `json:"shortDescription,omitempty"`
+}
+
+type sarifText struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	RuleIndex int             `json:"ruleIndex"`
+	Level     string          `json:"level"`
+	Message   sarifText       `json:"message"`
+	Locations []sarifLocation `json:"locations,omitempty"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation struct {
+		ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+		Region           struct {
+			StartLine   int `json:"startLine"`
+			StartColumn int `json:"startColumn"`
+		} `json:"region"`
+	} `json:"physicalLocation"`
+}
+
+type sarifArtifactLocation struct {
+	URI       string `json:"uri"`
+	URIBaseID string `json:"uriBaseId,omitempty"`
+}
+
+// sarifFormatter writes messages as a SARIF 2.1.0 log with a single run.
+// Each check that reports a message becomes one of the run's rules.
+//
+// A run's results are written as they're received. Because the tool's rules
+// aren't known until then, the tool is written after the results when the
+// formatter is closed.
+type sarifFormatter struct {
+	w      io.Writer
+	root   string
+	dir    string
+	checks thriftcheck.Checks
+	rules  []sarifRule
+	index  map[string]int
+	count  int
+}
+
+// newSARIFFormatter returns a formatter that gives files below root URIs
+// relative to the source root. Relative filenames are relative to dir.
+func newSARIFFormatter(w io.Writer, root, dir string, checks thriftcheck.Checks) *sarifFormatter {
+	return &sarifFormatter{w: w, root: root, dir: dir, checks: checks, index: make(map[string]int)}
+}
+
+// sarifRoot returns the source root that SARIF URIs are relative to:
+// flagRoot (relative to dir), if it's set, or else the top level of the git
+// work tree that contains dir, or else dir itself.
+func sarifRoot(flagRoot, dir string) string {
+	switch {
+	case flagRoot != "":
+		if filepath.IsAbs(flagRoot) {
+			return filepath.Clean(flagRoot)
+		}
+		return filepath.Join(dir, flagRoot)
+	default:
+		if cdup, err := git(dir, "rev-parse", "--show-cdup"); err == nil {
+			return filepath.Join(dir, strings.TrimSpace(string(cdup)))
+		}
+		return dir
+	}
+}
+
+func (f *sarifFormatter) header() string {
+	return fmt.Sprintf("{\n  \"$schema\": %q,\n  \"version\": \"2.1.0\",\n  \"runs\": [\n    {\n      \"results\": [", sarifSchema)
+}
+
+func (f *sarifFormatter) write(msgs thriftcheck.Messages) error {
+	for _, m := range msgs {
+		b, err := json.MarshalIndent(f.result(m), "        ", "  ")
+		if err != nil {
+			return err
+		}
+
+		sep := ",\n        "
+		if f.count == 0 {
+			sep = f.header() + "\n        "
+		}
+		if _, err := fmt.Fprintf(f.w, "%s%s", sep, b); err != nil {
+			return err
+		}
+		f.count++
+	}
+	return nil
+}
+
+func (f *sarifFormatter) close() error {
+	tool, err := json.MarshalIndent(sarifTool{Driver: sarifDriver{
+		Name:           "thriftcheck",
+		Version:        version,
+		InformationURI: "https://github.com/pinterest/thriftcheck",
+		Rules:          append([]sarifRule{}, f.rules...),
+	}}, "      ", "  ")
+	if err != nil {
+		return err
+	}
+
+	end := "\n      ],"
+	if f.count == 0 {
+		end = f.header() + "],"
+	}
+	_, err = fmt.Fprintf(f.w, "%s\n      \"tool\": %s\n    }\n  ]\n}\n", end, tool)
+	return err
+}
+
+// result converts a message to a SARIF result, adding its check to the
+// rules the first time that it's seen.
+func (f *sarifFormatter) result(m thriftcheck.Message) sarifResult {
+	index, ok := f.index[m.Check]
+	if !ok {
+		rule := sarifRule{ID: m.Check}
+		if i := slices.IndexFunc(f.checks, func(c thriftcheck.Check) bool { return c.Name == m.Check }); i >= 0 {
+			if description := f.checks[i].Info.Description; description != "" {
+				rule.ShortDescription = &sarifText{Text: description}
+			}
+		}
+		index = len(f.rules)
+		f.index[m.Check] = index
+		f.rules = append(f.rules, rule)
+	}
+
+	result := sarifResult{
+		RuleID:    m.Check,
+		RuleIndex: index,
+		Level:     m.Severity.String(),
+		Message:   sarifText{Text: m.Message},
+	}
+	// Messages about the run as a whole, rather than a file, have no location.
+	if m.Filename != "" {
+		var loc sarifLocation
+		loc.PhysicalLocation.ArtifactLocation = sarifArtifact(f.root, f.dir, m.Filename)
+		loc.PhysicalLocation.Region.StartLine = max(m.Pos.Line, 1)
+		loc.PhysicalLocation.Region.StartColumn = max(m.Pos.Column, 1)
+		result.Locations = []sarifLocation{loc}
+	}
+	return result
+}
+
+// sarifArtifact returns the location of a file. Files below root are given
+// URIs relative to the source root (%SRCROOT%); other files are given
+// absolute file URIs.
+func sarifArtifact(root, dir, filename string) sarifArtifactLocation {
+	if !filepath.IsAbs(filename) {
+		filename = filepath.Join(dir, filename)
+	}
+	if rel, err := filepath.Rel(root, filename); err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return sarifArtifactLocation{URI: (&url.URL{Path: filepath.ToSlash(rel)}).String(), URIBaseID: "%SRCROOT%"}
+	}
+	return sarifArtifactLocation{URI: (&url.URL{Scheme: "file", Path: filepath.ToSlash(filename)}).String()}
+}
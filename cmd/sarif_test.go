@@ -0,0 +1,121 @@
+// Copyright 2026 Pinterest
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pinterest/thriftcheck"
+	"go.uber.org/thriftrw/ast"
+)
+
+func TestFormatSARIF(t *testing.T) {
+	root := filepath.FromSlash("/repo")
+	msgs := append(thriftcheck.Messages{}, testMessages...)
+	msgs = append(msgs, thriftcheck.Message{
+		Filename: filepath.FromSlash("/other/c d.thrift"),
+		Pos:      ast.Position{Line: 7, Column: 2},
+		Check:    "field.id.zero",
+		Severity: thriftcheck.Error,
+		Message:  `field ID for "id" is zero`,
+	}, thriftcheck.Message{
+		Check:    "limit.truncated",
+		Severity: thriftcheck.Warning,
+		Message:  "(2+ more from field.id.zero)",
+	})
+	checks := thriftcheck.Checks{
+		{Name: "field.id.zero", Info: thriftcheck.CheckInfo{Description: "Reports an error if a field's ID is zero."}},
+	}
+
+	var b bytes.Buffer
+	f := newSARIFFormatter(&b, root, root, checks)
+	if err := f.write(msgs[:1]); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.write(msgs[1:]); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.close(); err != nil {
+		t.Fatal(err)
+	}
+	if !json.Valid(b.Bytes()) {
+		t.Fatalf("invalid JSON:\n%s", b.String())
+	}
+
+	golden := filepath.Join("testdata", "sarif.json")
+	if *updateGolden {
+		if err := os.WriteFile(golden, b.Bytes(), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	expected, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b.String() != string(expected) {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, b.String())
+	}
+}
+
+func TestFormatSARIFEmpty(t *testing.T) {
+	var b bytes.Buffer
+	f := newSARIFFormatter(&b, "", "", nil)
+	if err := f.close(); err != nil {
+		t.Fatal(err)
+	}
+
+	var log struct {
+		Version string `json:"version"`
+		Runs    []struct {
+			Results []sarifResult `json:"results"`
+			Tool    sarifTool     `json:"tool"`
+		} `json:"runs"`
+	}
+	if err := json.Unmarshal(b.Bytes(), &log); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, b.String())
+	}
+	if log.Version != "2.1.0" || len(log.Runs) != 1 || log.Runs[0].Results == nil || len(log.Runs[0].Results) != 0 {
+		t.Errorf("expected a single run with no results, got:\n%s", b.String())
+	}
+}
+
+func TestSARIFRoot(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("GIT_CEILING_DIRECTORIES", filepath.Dir(dir))
+	nested := filepath.Join(dir, "idl", "nested")
+	if err := os.MkdirAll(nested, 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	if root := sarifRoot("", nested); root != nested {
+		t.Errorf("expected the directory outside of a work tree, got %s", root)
+	}
+	if _, err := git(dir, "init", "-q"); err != nil {
+		t.Fatal(err)
+	}
+	if root := sarifRoot("", nested); root != dir {
+		t.Errorf("expected the work tree's top level %s, got %s", dir, root)
+	}
+	if root := sarifRoot("..", nested); root != filepath.Join(dir, "idl") {
+		t.Errorf("expected the flag's root relative to the directory, got %s", root)
+	}
+	if abs := filepath.FromSlash("/src"); sarifRoot(abs, nested) != abs {
+		t.Errorf("expected the flag's absolute root %s, got %s", abs, sarifRoot(abs, nested))
+	}
+}
@@ -0,0 +1,173 @@
+// Copyright 2026 Pinterest
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	_ "embed"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/pinterest/thriftcheck"
+)
+
+// exampleConfig is the configuration that the checks' examples assume.
+//
+//go:embed example.toml
+var exampleConfig []byte
+
+var exampleFileRegexp = regexp.MustCompile(`(?m)^// ([\w./-]+\.thrift)\n`)
+
+// unexampledChecks lists the checks that can't have a violating example
+// under the example configuration, along with the reason why. Every other
+// check must have one, so that selftest confirms that it reports a finding.
+var unexampledChecks = map[string]string{
+	"enum.size":              "its limits are too large for an example",
+	"enum.stability":         "the example configuration doesn't set a baseline directory",
+	"field.id.gap":           "the example configuration disables it",
+	"field.required.added":   "the example configuration doesn't set a baseline directory",
+	"file.orphan":            "its roots are matched against paths relative to the working directory",
+	"limits.size":            "the example configuration disables it",
+	"type.complexity.budget": "its budget is too large for an example",
+	"union.migration.ids":    "the example configuration doesn't set a baseline directory",
+}
+
+// selftest runs every built-in check against its own violating and compliant
+// examples (using the example configuration) and reports whether each check
+// produced the expected findings. Checks without a violating example are
+// reported as skipped rather than ok. It returns an error if any check
+// failed.
+func selftest(w io.Writer) error {
+	dir, err := os.MkdirTemp("", "thriftcheck-selftest")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+
+	filename := filepath.Join(dir, "thriftcheck.toml")
+	if err := os.WriteFile(filename, exampleConfig, 0o644); err != nil {
+		return err
+	}
+	var cfg Config
+	if err := loadConfig(&cfg, filename); err != nil {
+		return err
+	}
+	allChecks, err := buildChecks(&cfg)
+	if err != nil {
+		return err
+	}
+
+	failed := 0
+	for _, name := range allChecks.SortedNames() {
+		checks, err := runCheck(allChecks, name)
+		if err != nil {
+			return err
+		}
+		check := checks[0]
+		reason, unexampled := unexampledChecks[name]
+		if check.Info.Bad == "" && !unexampled {
+			err = errors.New("no violating example")
+		}
+
+		if err == nil {
+			err = runExample(check, check.Info.Bad, true)
+		}
+		if err == nil {
+			err = runExample(check, check.Info.Good, false)
+		}
+		switch {
+		case err != nil:
+			fmt.Fprintf(w, "FAIL %s: %v\n", name, err)
+			failed++
+		case check.Info.Bad == "":
+			// The compliant example (if any) passed, but the check was never
+			// seen to report anything.
+			fmt.Fprintf(w, "skip %s (no violating example: %s)\n", name, reason)
+		default:
+			fmt.Fprintf(w, "ok   %s\n", name)
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d check(s) failed", failed)
+	}
+	return nil
+}
+
+// runExample lints an example using a single check and verifies that the
+// check did (or didn't) report any messages. Examples can span multiple files
+// by introducing each one with a "// filename.thrift" line.
+func runExample(check thriftcheck.Check, example string, violating bool) error {
+	if example == "" {
+		return nil
+	}
+
+	dir, err := os.MkdirTemp("", "thriftcheck-example")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+
+	var filenames []string
+	write := func(name, content string) error {
+		filename := filepath.Join(dir, name)
+		filenames = append(filenames, filename)
+		if err := os.MkdirAll(filepath.Dir(filename), 0o755); err != nil {
+			return err
+		}
+		return os.WriteFile(filename, []byte(content), 0o644)
+	}
+
+	locs := exampleFileRegexp.FindAllStringSubmatchIndex(example, -1)
+	if len(locs) == 0 || locs[0][0] != 0 {
+		if err := write("example.thrift", example); err != nil {
+			return err
+		}
+	}
+	for i, loc := range locs {
+		end := len(example)
+		if i+1 < len(locs) {
+			end = locs[i+1][0]
+		}
+		if err := write(example[loc[2]:loc[3]], example[loc[1]:end]); err != nil {
+			return err
+		}
+	}
+
+	linter := thriftcheck.NewLinter(thriftcheck.Checks{check}, thriftcheck.WithIncludes([]string{dir}))
+	msgs, err := linter.LintFiles(filenames)
+	if err != nil {
+		return err
+	}
+
+	var found []string
+	for _, m := range msgs {
+		if m.Check == "parse" {
+			return fmt.Errorf("example failed to parse: %s", m.Message)
+		}
+		found = append(found, m.Message)
+	}
+	switch {
+	case violating && len(found) == 0:
+		return fmt.Errorf("violating example produced no messages")
+	case !violating && len(found) > 0:
+		return fmt.Errorf("compliant example produced messages: %s", strings.Join(found, "; "))
+	}
+	return nil
+}
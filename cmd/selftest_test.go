@@ -0,0 +1,81 @@
+// Copyright 2026 Pinterest
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"maps"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/pinterest/thriftcheck/checks"
+)
+
+func TestSelftest(t *testing.T) {
+	var b bytes.Buffer
+	if err := selftest(&b); err != nil {
+		t.Fatalf("selftest failed: %v\n%s", err, b.String())
+	}
+
+	var cfg Config
+	all, err := buildChecks(&cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(b.String()), "\n")
+	if len(lines) != len(all) {
+		t.Errorf("expected %d results, got %d:\n%s", len(all), len(lines), b.String())
+	}
+	for _, name := range all.SortedNames() {
+		if !strings.Contains(b.String(), " "+name) {
+			t.Errorf("expected a result for %q", name)
+		}
+	}
+
+	// Only the checks that can't have violating examples may be skipped.
+	var skipped []string
+	for _, line := range lines {
+		if name, ok := strings.CutPrefix(line, "skip "); ok {
+			name, _, _ = strings.Cut(name, " ")
+			skipped = append(skipped, name)
+		}
+	}
+	if want := slices.Sorted(maps.Keys(unexampledChecks)); !slices.Equal(skipped, want) {
+		t.Errorf("expected only %v to be skipped, got %v", want, skipped)
+	}
+}
+
+func TestRunExample(t *testing.T) {
+	check := checks.CheckFieldIDZero()
+
+	if err := runExample(check, "struct S {\n  0: optional string s\n}", true); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := runExample(check, "struct S {\n  1: optional string s\n}", true); err == nil {
+		t.Errorf("expected an error for a violating example without messages")
+	}
+	if err := runExample(check, "struct S {\n  0: optional string s\n}", false); err == nil {
+		t.Errorf("expected an error for a compliant example with messages")
+	}
+	if err := runExample(check, "struct S {", false); err == nil {
+		t.Errorf("expected an error for an example that fails to parse")
+	}
+
+	multi := "// a.thrift\ninclude \"b.thrift\"\ntypedef i32 Id\n\n// b.thrift\ntypedef i64 Id"
+	if err := runExample(checks.CheckTypedefConsistency(), multi, true); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,72 @@
+// Copyright 2026 Pinterest
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"strings"
+
+	"github.com/pinterest/thriftcheck"
+)
+
+// checkSeverity is the configured severity of a check's messages, which can
+// also be "ignore" to not run the check at all.
+type checkSeverity struct {
+	severity thriftcheck.Severity
+	ignore   bool
+}
+
+// UnmarshalString implements fig.StringUnmarshaler for automatic toml parsing.
+func (s *checkSeverity) UnmarshalString(v string) error {
+	if strings.EqualFold(v, "ignore") {
+		*s = checkSeverity{ignore: true}
+		return nil
+	}
+	*s = checkSeverity{}
+	return s.severity.UnmarshalString(v)
+}
+
+func (s checkSeverity) String() string {
+	if s.ignore {
+		return "ignore"
+	}
+	return s.severity.String()
+}
+
+// checkSeverities returns the severity overrides in cfg.Checks.Severity to
+// apply to messages. Ignored checks aren't included because selectChecks
+// doesn't run them.
+func checkSeverities(cfg *Config) map[string]thriftcheck.Severity {
+	severities := make(map[string]thriftcheck.Severity)
+	for name, s := range cfg.Checks.Severity {
+		if !s.ignore {
+			severities[name] = s.severity
+		}
+	}
+	return severities
+}
+
+// effectiveSeverity returns the severity of a check's messages after applying
+// cfg.Checks.Severity (where the longest matching name or prefix wins), which
+// may be "ignore". Path severities aren't included because they depend on the
+// file.
+func effectiveSeverity(cfg *Config, check thriftcheck.Check) string {
+	severity, longest := check.Info.Severity.String(), -1
+	for name, s := range cfg.Checks.Severity {
+		if len(name) > longest && (check.Name == name || strings.HasPrefix(check.Name, name+".")) {
+			severity, longest = s.String(), len(name)
+		}
+	}
+	return severity
+}
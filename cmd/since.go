@@ -0,0 +1,122 @@
+// Copyright 2026 Pinterest
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"maps"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/pinterest/thriftcheck"
+)
+
+// changedLines maps relative filenames to their changed line numbers. A nil
+// set of lines means that the entire file has changed.
+type changedLines map[string]map[int]bool
+
+var hunkRegexp = regexp.MustCompile(`^@@ -\d+(?:,\d+)? \+(\d+)(?:,(\d+))? @@`)
+
+// gitChangedLines returns the lines of the .thrift files in dir's working
+// tree that have changed since the given git ref. Untracked files are
+// considered to have changed entirely.
+func gitChangedLines(dir, ref string) (changedLines, error) {
+	if _, err := git(dir, "rev-parse", "--is-inside-work-tree"); err != nil {
+		return nil, err
+	}
+
+	diff, err := git(dir, "diff", "--unified=0", "--no-color", "--no-ext-diff", "--relative", ref, "--", "*.thrift")
+	if err != nil {
+		return nil, err
+	}
+
+	changed := changedLines{}
+	var filename string
+	scanner := bufio.NewScanner(bytes.NewReader(diff))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if after, ok := strings.CutPrefix(line, "+++ "); ok {
+			filename = ""
+			if after != "/dev/null" {
+				filename = filepath.Clean(strings.TrimPrefix(after, "b/"))
+				changed[filename] = map[int]bool{}
+			}
+			continue
+		}
+		if m := hunkRegexp.FindStringSubmatch(line); m != nil && filename != "" {
+			start, _ := strconv.Atoi(m[1])
+			count := 1
+			if m[2] != "" {
+				count, _ = strconv.Atoi(m[2])
+			}
+			for i := range count {
+				changed[filename][start+i] = true
+			}
+		}
+	}
+
+	// Untracked filenames are NUL-separated so that names containing spaces
+	// (or newlines) are kept intact and not quoted.
+	untracked, err := git(dir, "ls-files", "-z", "--others", "--exclude-standard", "--", "*.thrift")
+	if err != nil {
+		return nil, err
+	}
+	for _, filename := range strings.Split(string(untracked), "\x00") {
+		if filename != "" {
+			changed[filepath.Clean(filename)] = nil
+		}
+	}
+
+	return changed, nil
+}
+
+func git(dir string, args ...string) ([]byte, error) {
+	var stderr bytes.Buffer
+	cmd := exec.Command("git", append([]string{"-C", dir}, args...)...)
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("git %s: %s", args[0], msg)
+		}
+		return nil, fmt.Errorf("git %s: %w", args[0], err)
+	}
+	return out, nil
+}
+
+// files returns the sorted list of changed filenames.
+func (cl changedLines) files() []string {
+	return slices.Sorted(maps.Keys(cl))
+}
+
+// filter returns only those messages reported on changed lines. Messages
+// that aren't associated with a specific line are kept if their file has
+// changed.
+func (cl changedLines) filter(msgs thriftcheck.Messages) thriftcheck.Messages {
+	filtered := thriftcheck.Messages{}
+	for _, m := range msgs {
+		lines, ok := cl[filepath.Clean(m.Filename)]
+		if ok && (lines == nil || m.Pos.Line == 0 || lines[m.Pos.Line]) {
+			filtered = append(filtered, m)
+		}
+	}
+	return filtered
+}
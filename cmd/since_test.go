@@ -0,0 +1,82 @@
+// Copyright 2026 Pinterest
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/pinterest/thriftcheck"
+	"go.uber.org/thriftrw/ast"
+)
+
+func TestGitChangedLines(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	run := func(args ...string) {
+		t.Helper()
+		if _, err := git(dir, append([]string{"-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)...); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	write("a.thrift", "struct A {\n  1: string a\n}\n")
+	write("b.thrift", "struct B {}\n")
+	run("init", "-q")
+	run("add", ".")
+	run("commit", "-q", "-m", "baseline")
+
+	write("a.thrift", "struct A {\n  1: string a\n  2: string b\n}\n")
+	write("c.thrift", "struct C {}\n")
+	write("new file.thrift", "struct D {}\n")
+
+	changed, err := gitChangedLines(dir, "HEAD")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := changedLines{
+		"a.thrift":        {3: true},
+		"c.thrift":        nil,
+		"new file.thrift": nil,
+	}
+	if !reflect.DeepEqual(changed, expected) {
+		t.Errorf("expected %v, got %v", expected, changed)
+	}
+
+	msgs := thriftcheck.Messages{
+		{Filename: "a.thrift", Pos: ast.Position{Line: 2}},
+		{Filename: "a.thrift", Pos: ast.Position{Line: 3}},
+		{Filename: "./b.thrift", Pos: ast.Position{Line: 1}},
+		{Filename: "c.thrift", Pos: ast.Position{Line: 1}},
+	}
+	filtered := changed.filter(msgs)
+	if !reflect.DeepEqual(filtered, thriftcheck.Messages{msgs[1], msgs[3]}) {
+		t.Errorf("unexpected filtered messages: %v", filtered)
+	}
+}
+
+func TestGitChangedLinesNotRepository(t *testing.T) {
+	if _, err := gitChangedLines(t.TempDir(), "HEAD"); err == nil {
+		t.Errorf("expected an error outside of a git repository")
+	}
+}
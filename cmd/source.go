@@ -0,0 +1,69 @@
+// Copyright 2026 Pinterest
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"os"
+	"strings"
+
+	"github.com/pinterest/thriftcheck"
+)
+
+// sources caches the lines of linted files so that messages can be printed
+// along with the source code that they refer to.
+type sources map[string][]string
+
+// add records the content of a file that can't be read from disk (such as
+// standard input).
+func (s sources) add(filename string, data []byte) {
+	s[filename] = strings.Split(string(data), "\n")
+}
+
+func (s sources) lines(filename string) []string {
+	lines, ok := s[filename]
+	if !ok {
+		if data, err := os.ReadFile(filename); err == nil {
+			lines = strings.Split(string(data), "\n")
+		}
+		s[filename] = lines
+	}
+	return lines
+}
+
+// snippet returns the source line that a message refers to followed by a line
+// with a caret under the message's column. Tabs that precede the column are
+// preserved so that the caret lines up regardless of the tab width.
+func (s sources) snippet(m thriftcheck.Message) (string, bool) {
+	lines := s.lines(m.Filename)
+	if m.Pos.Line < 1 || m.Pos.Line > len(lines) {
+		return "", false
+	}
+
+	line := strings.TrimRight(lines[m.Pos.Line-1], "\r")
+	col := min(max(m.Pos.Column, 1), len(line)+1)
+
+	// Columns are byte offsets, so walk the runes that precede the column.
+	var caret strings.Builder
+	for _, r := range line[:col-1] {
+		if r == '\t' {
+			caret.WriteRune('\t')
+		} else {
+			caret.WriteRune(' ')
+		}
+	}
+	caret.WriteRune('^')
+
+	return line + "\n" + caret.String(), true
+}
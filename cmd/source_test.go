@@ -0,0 +1,72 @@
+// Copyright 2026 Pinterest
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pinterest/thriftcheck"
+	"github.com/pinterest/thriftcheck/checks"
+	"go.uber.org/thriftrw/ast"
+)
+
+func TestSourcesSnippet(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "a.thrift")
+	content := "struct S {\n\t1: optional map<i64, string> names\n}\n"
+	if err := os.WriteFile(filename, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	var i64Type thriftcheck.ThriftType
+	if err := i64Type.UnmarshalString("i64"); err != nil {
+		t.Fatal(err)
+	}
+	linter := thriftcheck.NewLinter(thriftcheck.Checks{
+		checks.CheckMapKeyType(nil, []thriftcheck.ThriftType{i64Type}),
+	})
+	msgs, err := linter.LintFiles([]string{filename})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(msgs) != 1 {
+		t.Fatalf("expected 1 message, got %v", msgs)
+	}
+
+	src := sources{}
+	snippet, ok := src.snippet(msgs[0])
+	if !ok {
+		t.Fatalf("expected a snippet for %v", msgs[0])
+	}
+	expected := "\t1: optional map<i64, string> names\n\t            ^"
+	if snippet != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, snippet)
+	}
+	if caret := strings.Split(snippet, "\n")[1]; len(caret) != msgs[0].Pos.Column {
+		t.Errorf("expected caret at column %d, got %d", msgs[0].Pos.Column, len(caret))
+	}
+
+	src.add("stdin", []byte("struct S {}"))
+	snippet, _ = src.snippet(thriftcheck.Message{Filename: "stdin", Pos: ast.Position{Line: 1, Column: 8}})
+	if expected := "struct S {}\n       ^"; snippet != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, snippet)
+	}
+
+	if _, ok := src.snippet(thriftcheck.Message{Filename: "stdin", Pos: ast.Position{Line: 0}}); ok {
+		t.Errorf("expected no snippet for line 0")
+	}
+}
@@ -0,0 +1,34 @@
+// Copyright 2026 Pinterest
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"text/template"
+)
+
+// messageTemplates parses cfg.Checks.MessageTemplates, naming each template
+// after the check (or check prefix) that it applies to.
+func messageTemplates(cfg *Config) (map[string]*template.Template, error) {
+	templates := make(map[string]*template.Template, len(cfg.Checks.MessageTemplates))
+	for name, text := range cfg.Checks.MessageTemplates {
+		tmpl, err := template.New(name).Option("missingkey=error").Parse(text)
+		if err != nil {
+			return nil, fmt.Errorf("invalid message template: %w", err)
+		}
+		templates[name] = tmpl
+	}
+	return templates, nil
+}
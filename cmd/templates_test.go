@@ -0,0 +1,42 @@
+// Copyright 2026 Pinterest
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMessageTemplates(t *testing.T) {
+	cfg := loadTestConfig(t, `
+[checks.messageTemplates]
+"set.value.type" = "{{.Message}} (owner: @team)"
+`)
+	templates, err := messageTemplates(&cfg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if tmpl := templates["set.value.type"]; tmpl == nil || tmpl.Name() != "set.value.type" {
+		t.Errorf("expected a set.value.type template, got %v", templates)
+	}
+
+	cfg = loadTestConfig(t, `
+[checks.messageTemplates]
+"set.value.type" = "{{.Message"
+`)
+	if _, err := messageTemplates(&cfg); err == nil || !strings.Contains(err.Error(), "set.value.type") {
+		t.Errorf("expected a template parse error, got %v", err)
+	}
+}
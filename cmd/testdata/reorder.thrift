@@ -0,0 +1,12 @@
+struct User {
+  2: optional string name
+  1: optional i64 id
+  3: optional string email
+  4: optional i64 created_at
+  5: optional i64 updated_at
+  6: optional i64 deleted_at
+  7: optional string locale
+  8: optional string timezone
+  10: optional bool admin
+  9: optional bool active
+}
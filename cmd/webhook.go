@@ -0,0 +1,80 @@
+// Copyright 2026 Pinterest
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/pinterest/thriftcheck"
+)
+
+const (
+	// webhookBatchSize is the largest number of messages sent in one request.
+	webhookBatchSize = 100
+
+	// webhookTimeout bounds each request, so a slow endpoint can't stall the
+	// run for long.
+	webhookTimeout = 10 * time.Second
+)
+
+// webhook POSTs messages to a URL as JSON arrays of up to webhookBatchSize
+// messages each. Delivery is best effort: the first failed request is
+// reported to warn and no further requests are made, and the run carries on.
+type webhook struct {
+	url    string
+	client *http.Client
+	warn   io.Writer
+	failed bool
+}
+
+func newWebhook(url string, warn io.Writer) *webhook {
+	return &webhook{url: url, client: &http.Client{Timeout: webhookTimeout}, warn: warn}
+}
+
+// send posts messages in batches as soon as they're reported.
+func (h *webhook) send(msgs thriftcheck.Messages) {
+	for batch := range slices.Chunk(msgs, webhookBatchSize) {
+		if h.failed {
+			return
+		}
+		if err := h.post(batch); err != nil {
+			fmt.Fprintf(h.warn, "--webhook: %v (no further findings will be sent)\n", err)
+			h.failed = true
+		}
+	}
+}
+
+func (h *webhook) post(msgs thriftcheck.Messages) error {
+	b, err := json.Marshal(msgs)
+	if err != nil {
+		return err
+	}
+	resp, err := h.client.Post(h.url, "application/json", bytes.NewReader(b))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("%s: %s", h.url, resp.Status)
+	}
+	return nil
+}
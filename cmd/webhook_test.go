@@ -0,0 +1,92 @@
+// Copyright 2026 Pinterest
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/pinterest/thriftcheck"
+)
+
+func TestWebhook(t *testing.T) {
+	var mu sync.Mutex
+	var batches [][]map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if ct := r.Header.Get("Content-Type"); ct != "application/json" {
+			t.Errorf("unexpected content type %q", ct)
+		}
+		var batch []map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&batch); err != nil {
+			t.Error(err)
+		}
+		mu.Lock()
+		batches = append(batches, batch)
+		mu.Unlock()
+	}))
+	defer srv.Close()
+
+	var msgs thriftcheck.Messages
+	for i := range webhookBatchSize + 1 {
+		msgs = append(msgs, thriftcheck.Message{Filename: "a.thrift", Check: "c", Message: fmt.Sprintf("m%d", i)})
+	}
+
+	var warn bytes.Buffer
+	h := newWebhook(srv.URL, &warn)
+	h.send(msgs[:1])
+	h.send(msgs[1:])
+	if warn.Len() != 0 {
+		t.Errorf("unexpected warnings: %s", warn.String())
+	}
+
+	var sizes []int
+	for _, b := range batches {
+		sizes = append(sizes, len(b))
+	}
+	if want := []int{1, webhookBatchSize}; fmt.Sprint(sizes) != fmt.Sprint(want) {
+		t.Fatalf("expected batches of %v, got %v", want, sizes)
+	}
+	if got := batches[1][webhookBatchSize-1]["message"]; got != fmt.Sprintf("m%d", webhookBatchSize) {
+		t.Errorf("unexpected last message %v", got)
+	}
+}
+
+func TestWebhookFailure(t *testing.T) {
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	var warn bytes.Buffer
+	h := newWebhook(srv.URL, &warn)
+	msgs := thriftcheck.Messages{{Filename: "a.thrift", Check: "c", Message: "m"}}
+	h.send(msgs)
+	h.send(msgs)
+
+	if requests != 1 {
+		t.Errorf("expected no requests after a failure, got %d", requests)
+	}
+	if !strings.Contains(warn.String(), "--webhook:") || !strings.Contains(warn.String(), "503") {
+		t.Errorf("expected a warning about the failed request, got %q", warn.String())
+	}
+}
@@ -0,0 +1,43 @@
+// Copyright 2026 Pinterest
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+Package thriftcheck lints Thrift IDL files.
+
+The thriftcheck command is built on this package, and other tools can embed
+the linter the same way. A [Linter] is created from a list of checks (such as
+those in the checks package, or custom ones created with [NewCheck] and
+[NewMultiFileCheck]) and any [Option] values:
+
+	linter := thriftcheck.NewLinter(thriftcheck.Checks{
+		checks.CheckFieldIDZero(),
+		checks.CheckCircularImport(),
+	}, thriftcheck.WithIncludes([]string{"idl"}))
+
+	msgs, err := linter.LintFiles([]string{"idl/users.thrift", "idl/common.thrift"})
+
+[Linter.LintFiles] runs every check over the given files and returns all of
+their [Messages], including those reported by the multi-file checks once all
+of the files have been linted. Each [Message] carries its filename, position,
+severity, check name, and text, and nothing is written to stdout. Use
+[Linter.LintFilesFunc] to receive each file's messages as soon as it has been
+linted, or [Linter.Lint] and [Linter.ParseAndLint] to lint a single buffer.
+
+To filter or enrich the messages before they're reported (for example, to
+attach severities from an external policy), pass [WithMessagePostProcessor].
+
+An error is only returned if a file can't be read. Syntax errors are reported
+as messages from the "parse" check.
+*/
+package thriftcheck
@@ -0,0 +1,41 @@
+// Copyright 2026 Pinterest
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package thriftcheck_test
+
+import (
+	"fmt"
+
+	"github.com/pinterest/thriftcheck"
+	"github.com/pinterest/thriftcheck/checks"
+)
+
+func ExampleLinter_LintFiles() {
+	linter := thriftcheck.NewLinter(thriftcheck.Checks{
+		checks.CheckFieldIDZero(),
+		checks.CheckCircularImport(),
+	})
+
+	msgs, err := linter.LintFiles([]string{"testdata/users.thrift", "testdata/common.thrift"})
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	for _, m := range msgs {
+		fmt.Println(m)
+	}
+	// Output:
+	// testdata/users.thrift:4:3: error: field ID for "name" is zero (field.id.zero)
+	// testdata/common.thrift:1:1: error: circular import: testdata/common.thrift -> testdata/users.thrift -> testdata/common.thrift (import.cycle.disallowed)
+}
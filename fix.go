@@ -0,0 +1,70 @@
+// Copyright 2026 Pinterest
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package thriftcheck
+
+import (
+	"bytes"
+	"cmp"
+	"slices"
+)
+
+// Edit is a suggested change to a file's source that replaces the bytes from
+// Start up to (but not including) End with Text. An edit with Start equal to
+// End inserts Text.
+type Edit struct {
+	Start int
+	End   int
+	Text  string
+}
+
+// ApplyFixes applies the suggested fixes of msgs to src, which is the source
+// of the file that the messages are about. Fixes are applied in order of
+// their positions, and a fix that overlaps one that was already applied is
+// skipped. It returns the fixed source along with the messages whose fixes
+// weren't applied (including those without fixes).
+func ApplyFixes(src []byte, msgs Messages) ([]byte, Messages) {
+	var fixes []int
+	for i, m := range msgs {
+		if m.Fix != nil && m.Fix.Start >= 0 && m.Fix.Start <= m.Fix.End && m.Fix.End <= len(src) {
+			fixes = append(fixes, i)
+		}
+	}
+	slices.SortStableFunc(fixes, func(a, b int) int {
+		return cmp.Compare(msgs[a].Fix.Start, msgs[b].Fix.Start)
+	})
+
+	var b bytes.Buffer
+	applied := make([]bool, len(msgs))
+	offset, last := 0, -1
+	for _, i := range fixes {
+		fix := msgs[i].Fix
+		if fix.Start < offset || fix.Start == last {
+			continue
+		}
+		b.Write(src[offset:fix.Start])
+		b.WriteString(fix.Text)
+		offset, last = fix.End, fix.Start
+		applied[i] = true
+	}
+	b.Write(src[offset:])
+
+	var remaining Messages
+	for i, m := range msgs {
+		if !applied[i] {
+			remaining = append(remaining, m)
+		}
+	}
+	return b.Bytes(), remaining
+}
@@ -0,0 +1,81 @@
+// Copyright 2026 Pinterest
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package thriftcheck
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestApplyFixes(t *testing.T) {
+	fix := func(message string, start, end int, text string) Message {
+		return Message{Check: "check", Message: message, Fix: &Edit{Start: start, End: end, Text: text}}
+	}
+	unfixable := Message{Check: "check", Message: "unfixable"}
+
+	tests := []struct {
+		name      string
+		msgs      Messages
+		want      string
+		remaining []string
+	}{
+		{
+			name:      "none",
+			msgs:      Messages{unfixable},
+			want:      "struct S {}",
+			remaining: []string{"unfixable"},
+		},
+		{
+			name:      "replace and insert",
+			msgs:      Messages{fix("insert", 11, 11, "\n"), unfixable, fix("replace", 7, 8, "User")},
+			want:      "struct User {}\n",
+			remaining: []string{"unfixable"},
+		},
+		{
+			name:      "overlapping",
+			msgs:      Messages{fix("second", 7, 10, "Y {"), fix("first", 0, 8, "union X")},
+			want:      "union X {}",
+			remaining: []string{"second"},
+		},
+		{
+			name:      "same insertion point",
+			msgs:      Messages{fix("first", 0, 0, "// a\n"), fix("second", 0, 0, "// b\n")},
+			want:      "// a\nstruct S {}",
+			remaining: []string{"second"},
+		},
+		{
+			name:      "out of range",
+			msgs:      Messages{fix("invalid", 5, 100, "")},
+			want:      "struct S {}",
+			remaining: []string{"invalid"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, remaining := ApplyFixes([]byte("struct S {}"), tt.msgs)
+			if string(got) != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+			var names []string
+			for _, m := range remaining {
+				names = append(names, m.Message)
+			}
+			if !slices.Equal(names, tt.remaining) {
+				t.Errorf("expected remaining %v, got %v", tt.remaining, names)
+			}
+		})
+	}
+}
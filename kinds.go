@@ -0,0 +1,135 @@
+// Copyright 2026 Pinterest
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package thriftcheck
+
+import (
+	"reflect"
+	"slices"
+
+	"go.uber.org/thriftrw/ast"
+)
+
+// NodeKind identifies a concrete type of AST node, such as *ast.Struct.
+type NodeKind struct {
+	t reflect.Type
+}
+
+// KindOf returns the NodeKind of the given node.
+func KindOf(n ast.Node) NodeKind {
+	return NodeKind{reflect.TypeOf(n)}
+}
+
+func (k NodeKind) String() string {
+	if k.t == nil {
+		return "<nil>"
+	}
+	return k.t.String()
+}
+
+func kindsOf(nodes ...ast.Node) []NodeKind {
+	kinds := make([]NodeKind, len(nodes))
+	for i, n := range nodes {
+		kinds[i] = KindOf(n)
+	}
+	return kinds
+}
+
+var (
+	annotationKinds = kindsOf(&ast.Annotation{})
+	headerKinds     = kindsOf(&ast.Include{}, &ast.CppInclude{}, &ast.Namespace{})
+	definitionKinds = kindsOf(&ast.Constant{}, &ast.Typedef{}, &ast.Enum{}, &ast.Struct{}, &ast.Service{})
+	typeKinds       = kindsOf(ast.BaseType{}, ast.ListType{}, ast.MapType{}, ast.SetType{}, ast.TypeReference{})
+	constantKinds   = kindsOf(
+		ast.ConstantBoolean(false), ast.ConstantDouble(0), ast.ConstantInteger(0), ast.ConstantString(""),
+		ast.ConstantList{}, ast.ConstantMap{}, ast.ConstantReference{})
+)
+
+// allKinds lists every concrete kind of node that ast.Walk can visit.
+var allKinds = slices.Concat(
+	kindsOf(&ast.Program{}, &ast.EnumItem{}, &ast.Function{}, &ast.Field{}, ast.ConstantMapItem{}),
+	annotationKinds, headerKinds, definitionKinds, typeKinds, constantKinds)
+
+// childKinds maps each NodeKind to the kinds of its direct children, as
+// visited by ast.Walk. Kinds without an entry are leaves.
+var childKinds = map[NodeKind][]NodeKind{
+	KindOf(&ast.Program{}):        slices.Concat(headerKinds, definitionKinds),
+	KindOf(&ast.Constant{}):       slices.Concat(typeKinds, constantKinds),
+	KindOf(&ast.Typedef{}):        slices.Concat(typeKinds, annotationKinds),
+	KindOf(&ast.Enum{}):           slices.Concat(kindsOf(&ast.EnumItem{}), annotationKinds),
+	KindOf(&ast.EnumItem{}):       annotationKinds,
+	KindOf(&ast.Struct{}):         slices.Concat(kindsOf(&ast.Field{}), annotationKinds),
+	KindOf(&ast.Service{}):        slices.Concat(kindsOf(&ast.Function{}), annotationKinds),
+	KindOf(&ast.Function{}):       slices.Concat(typeKinds, kindsOf(&ast.Field{}), annotationKinds),
+	KindOf(&ast.Field{}):          slices.Concat(typeKinds, constantKinds, annotationKinds),
+	KindOf(ast.BaseType{}):        annotationKinds,
+	KindOf(ast.ListType{}):        slices.Concat(typeKinds, annotationKinds),
+	KindOf(ast.MapType{}):         slices.Concat(typeKinds, annotationKinds),
+	KindOf(ast.SetType{}):         slices.Concat(typeKinds, annotationKinds),
+	KindOf(ast.ConstantList{}):    constantKinds,
+	KindOf(ast.ConstantMap{}):     kindsOf(ast.ConstantMapItem{}),
+	KindOf(ast.ConstantMapItem{}): constantKinds,
+}
+
+// descendantKinds maps each NodeKind to the set of kinds that can appear
+// anywhere beneath it in the tree.
+var descendantKinds = func() map[NodeKind]map[NodeKind]bool {
+	descendants := make(map[NodeKind]map[NodeKind]bool, len(allKinds))
+	for _, kind := range allKinds {
+		seen := make(map[NodeKind]bool)
+		queue := slices.Clone(childKinds[kind])
+		for len(queue) > 0 {
+			k := queue[0]
+			queue = queue[1:]
+			if !seen[k] {
+				seen[k] = true
+				queue = append(queue, childKinds[k]...)
+			}
+		}
+		descendants[kind] = seen
+	}
+	return descendants
+}()
+
+// kindsMatching returns the kinds of nodes that are assignable to t.
+func kindsMatching(t reflect.Type) []NodeKind {
+	if t.Kind() != reflect.Interface {
+		return []NodeKind{{t}}
+	}
+	var kinds []NodeKind
+	for _, kind := range allKinds {
+		if kind.t.Implements(t) {
+			kinds = append(kinds, kind)
+		}
+	}
+	return kinds
+}
+
+// mayContain reports whether any of the checks inspect a kind of node that
+// can appear beneath n. Unknown kinds of nodes are conservatively assumed to
+// contain anything.
+func (c Checks) mayContain(n ast.Node) bool {
+	descendants, ok := descendantKinds[KindOf(n)]
+	if !ok {
+		return true
+	}
+	for _, check := range c {
+		for _, kind := range check.kinds {
+			if descendants[kind] {
+				return true
+			}
+		}
+	}
+	return false
+}
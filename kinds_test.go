@@ -0,0 +1,73 @@
+// Copyright 2026 Pinterest
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package thriftcheck
+
+import (
+	"reflect"
+	"testing"
+
+	"go.uber.org/thriftrw/ast"
+)
+
+func TestNodeKinds(t *testing.T) {
+	tests := []struct {
+		desc  string
+		fn    any
+		kinds []NodeKind
+	}{
+		{"struct", func(c *C, s *ast.Struct) {}, kindsOf(&ast.Struct{})},
+		{"parent", func(c *C, s *ast.Struct, f *ast.Field) {}, kindsOf(&ast.Field{})},
+		{"interface", func(c *C, t ast.Type) {}, typeKinds},
+		{"node", func(c *C, n ast.Node) {}, allKinds},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			check := NewCheck(tt.desc, tt.fn)
+			if kinds := check.NodeKinds(); !reflect.DeepEqual(kinds, tt.kinds) {
+				t.Errorf("expected %v, got %v", tt.kinds, kinds)
+			}
+		})
+	}
+}
+
+func TestChecksMayContain(t *testing.T) {
+	fields := Checks{NewCheck("field", func(c *C, f *ast.Field) {})}
+	types := Checks{NewCheck("type", func(c *C, t ast.Type) {})}
+
+	tests := []struct {
+		checks Checks
+		node   ast.Node
+		want   bool
+	}{
+		{fields, &ast.Program{}, true},
+		{fields, &ast.Struct{}, true},
+		{fields, &ast.Service{}, true},
+		{fields, &ast.Field{}, false},
+		{fields, &ast.Constant{}, false},
+		{fields, &ast.Enum{}, false},
+		{types, &ast.Field{}, true},
+		{types, ast.ListType{}, true},
+		{types, &ast.Enum{}, false},
+		{types, ast.ConstantMap{}, false},
+		{Checks{}, &ast.Program{}, false},
+	}
+
+	for _, tt := range tests {
+		if got := tt.checks.mayContain(tt.node); got != tt.want {
+			t.Errorf("%s.mayContain(%s): expected %v, got %v", tt.checks, KindOf(tt.node), tt.want, got)
+		}
+	}
+}
@@ -15,23 +15,69 @@
 package thriftcheck
 
 import (
+	"bytes"
+	"cmp"
 	"errors"
 	"fmt"
 	"io"
 	"log"
 	"os"
 	"path/filepath"
+	"reflect"
+	"runtime"
+	"slices"
 	"strings"
+	"sync/atomic"
+	"text/template"
 
+	"github.com/danwakefield/fnmatch"
 	"go.uber.org/thriftrw/ast"
 	"go.uber.org/thriftrw/idl"
 )
 
 // Linter is a configured Thrift linter.
 type Linter struct {
-	checks   Checks
-	logger   *log.Logger
-	includes []string
+	checks         Checks
+	logger         *log.Logger
+	includes       []string
+	pathSeverities []PathSeverity
+	checkSeverity  map[string]Severity
+	templates      map[string]*template.Template
+	suppressions   []Suppression
+	skipUnresolved bool
+	failFast       bool
+	jobs           int
+	postProcessor  MessagePostProcessor
+	parseOptions   []ParseOption
+	programs       *programCache
+
+	// disabled holds each linted file's thriftcheck:disable ranges until the
+	// multi-file checks are finalized, so that they also apply to the
+	// messages that those checks report.
+	disabled map[string]disabledRanges
+	// ran records the multi-file checks that were run over any files since
+	// they were last finalized. Multi-file checks are never run concurrently.
+	ran map[string]bool
+	// skipped records the multi-file checks that were skipped for any files
+	// because of an unresolved include, so that a check that was skipped for
+	// all of them isn't reported as unused.
+	skipped map[string]bool
+	// visited counts the AST nodes that checks were run on, across all of the
+	// files that have been linted, so that tests can see which parts of the
+	// tree were skipped.
+	visited atomic.Int64
+}
+
+// MessagePostProcessor rewrites the set of messages produced by a run. It may
+// drop, reorder, or modify messages, and the messages it returns are the ones
+// reported.
+type MessagePostProcessor func(Messages) Messages
+
+// PathSeverity overrides the severity of all messages reported for files
+// whose paths match the Path glob pattern.
+type PathSeverity struct {
+	Path     string   `fig:"path" validate:"required"`
+	Severity Severity `fig:"severity"`
 }
 
 // Option represents a Linter option.
@@ -51,6 +97,92 @@ func WithIncludes(includes []string) Option {
 	}
 }
 
+// WithPathSeverities is an Option that overrides the severity of messages
+// based on their filenames. When multiple patterns match, the last one wins.
+func WithPathSeverities(severities []PathSeverity) Option {
+	return func(l *Linter) {
+		l.pathSeverities = severities
+	}
+}
+
+// WithCheckSeverities is an Option that overrides the severity of messages
+// based on the checks that reported them. Keys are check names or name
+// prefixes; when several match, the longest one wins. Path severities take
+// precedence over these.
+func WithCheckSeverities(severities map[string]Severity) Option {
+	return func(l *Linter) {
+		l.checkSeverity = severities
+	}
+}
+
+// WithMessageTemplates is an Option that rewrites the text of messages based
+// on the checks that reported them. Keys are matched like those given to
+// WithCheckSeverities, and each template is executed with a MessageData.
+func WithMessageTemplates(templates map[string]*template.Template) Option {
+	return func(l *Linter) {
+		l.templates = templates
+	}
+}
+
+// WithSuppressions is an Option that suppresses any messages matched by the
+// given suppression rules.
+func WithSuppressions(suppressions []Suppression) Option {
+	return func(l *Linter) {
+		l.suppressions = suppressions
+	}
+}
+
+// WithSkipMultiFileOnUnresolved is an Option that skips the multi-file checks
+// for any file whose includes (followed through the files that it includes)
+// can't all be found. A single warning is reported for the file instead, so
+// partial checkouts don't produce a cascade of misleading findings.
+func WithSkipMultiFileOnUnresolved(skip bool) Option {
+	return func(l *Linter) {
+		l.skipUnresolved = skip
+	}
+}
+
+// WithFailFast is an Option that stops linting at the first error. Messages
+// after the first error in its file are dropped, no further files are linted,
+// and the multi-file checks are reset without reporting anything.
+func WithFailFast(failFast bool) Option {
+	return func(l *Linter) {
+		l.failFast = failFast
+	}
+}
+
+// WithJobs is an Option that lints up to n files concurrently in LintFiles
+// and LintFilesFunc. Values below 1 use runtime.GOMAXPROCS. Multi-file checks
+// still see every file, in order, so the results are the same as those of a
+// sequential run.
+func WithJobs(n int) Option {
+	return func(l *Linter) {
+		if n < 1 {
+			n = runtime.GOMAXPROCS(0)
+		}
+		l.jobs = n
+	}
+}
+
+// WithParseOptions is an Option that sets the ParseOptions used to read and
+// parse included files, such as to customize how their reads are retried.
+func WithParseOptions(options ...ParseOption) Option {
+	return func(l *Linter) {
+		l.parseOptions = options
+	}
+}
+
+// WithMessagePostProcessor is an Option that passes the messages through p
+// once all of the checks have run and the other message-level options have
+// been applied. Lint, LintFiles, and ParseAndLint call p once with the full
+// set of messages; LintFilesFunc, which never holds the full set, calls it
+// with each batch before passing that batch to fn.
+func WithMessagePostProcessor(p MessagePostProcessor) Option {
+	return func(l *Linter) {
+		l.postProcessor = p
+	}
+}
+
 // NewLinter creates a new Linter configured with the given checks and options.
 func NewLinter(checks Checks, options ...Option) *Linter {
 	l := &Linter{
@@ -60,6 +192,7 @@ func NewLinter(checks Checks, options ...Option) *Linter {
 	for _, option := range options {
 		option(l)
 	}
+	l.programs = newProgramCache(l.parseOptions...)
 	l.logger.Printf("checks: %s\n", checks)
 	l.logger.Printf("includes: %s\n", strings.Join(l.includes, " "))
 	return l
@@ -67,7 +200,287 @@ func NewLinter(checks Checks, options ...Option) *Linter {
 
 // Lint lints a single input file.
 func (l *Linter) Lint(r io.Reader, filename string) (Messages, error) {
-	program, info, err := Parse(r)
+	_, msgs, err := l.parseAndLint(r, filename)
+	if err != nil {
+		l.finalize()
+		return nil, err
+	}
+	msgs, _ = l.stopAtError(l.postprocess(append(msgs, l.finalize()...)))
+	return l.applyPostProcessor(msgs), nil
+}
+
+// LintFiles lints multiple files. Each is opened, parsed, and linted in
+// order, and the aggregate result is returned. Multi-file checks are
+// finalized once all of the files have been linted.
+func (l *Linter) LintFiles(filenames []string) (Messages, error) {
+	msgs := Messages{}
+	err := l.lintFiles(filenames, func(m Messages) error {
+		msgs = append(msgs, m...)
+		return nil
+	})
+	if err != nil {
+		return msgs, err
+	}
+	return l.applyPostProcessor(msgs), nil
+}
+
+// LintFilesFunc lints multiple files like LintFiles, but rather than
+// returning the aggregate result, it calls fn with each file's messages
+// (sorted by position) as soon as that file has been linted. Messages from
+// multi-file checks are passed to fn in a final call once all of the files
+// have been linted. If fn returns an error, linting stops and that error is
+// returned.
+func (l *Linter) LintFilesFunc(filenames []string, fn func(Messages) error) error {
+	return l.lintFiles(filenames, func(m Messages) error {
+		return fn(l.applyPostProcessor(m))
+	})
+}
+
+func (l *Linter) lintFiles(filenames []string, fn func(Messages) error) error {
+	if l.jobs > 1 && len(filenames) > 1 {
+		return l.lintFilesConcurrently(filenames, fn)
+	}
+
+	for _, filename := range filenames {
+		f, err := os.Open(filename)
+		if err != nil {
+			l.finalize()
+			return fmt.Errorf("%s: %w", filename, err)
+		}
+
+		_, m, err := l.parseAndLint(f, filename)
+		f.Close()
+		if err != nil {
+			l.finalize()
+			return err
+		}
+
+		m, stop := l.stopAtError(l.postprocess(sortByPosition(m)))
+		if err := fn(m); err != nil || stop {
+			l.finalize()
+			return err
+		}
+	}
+
+	m, _ := l.stopAtError(l.postprocess(l.finalize()))
+	return fn(m)
+}
+
+// lintFilesConcurrently implements LintFilesFunc using a pool of workers that
+// parse the files and run the single-file checks. The multi-file checks,
+// which accumulate state across files, are run on the calling goroutine as
+// each file's results become available (in order), so they're never called
+// concurrently and their results match a sequential run.
+func (l *Linter) lintFilesConcurrently(filenames []string, fn func(Messages) error) error {
+	var single, multi Checks
+	for _, check := range l.checks {
+		if check.IsMultiFile() {
+			multi = append(multi, check)
+		} else {
+			single = append(single, check)
+		}
+	}
+
+	type result struct {
+		file *parsedFile
+		msgs Messages
+		err  error
+	}
+	results := make([]chan result, len(filenames))
+	for i := range results {
+		results[i] = make(chan result, 1)
+	}
+
+	work := make(chan int)
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		defer close(work)
+		for i := range filenames {
+			select {
+			case work <- i:
+			case <-done:
+				return
+			}
+		}
+	}()
+	for range min(l.jobs, len(filenames)) {
+		go func() {
+			for i := range work {
+				f, err := os.Open(filenames[i])
+				if err != nil {
+					results[i] <- result{err: fmt.Errorf("%s: %w", filenames[i], err)}
+					continue
+				}
+				file, msgs, err := l.parse(f, filenames[i])
+				f.Close()
+				if err != nil || msgs != nil {
+					results[i] <- result{msgs: msgs, err: err}
+					continue
+				}
+				results[i] <- result{file: file, msgs: append(file.warnings, l.lint(file, single)...)}
+			}
+		}()
+	}
+
+	for i := range filenames {
+		r := <-results[i]
+		if r.err != nil {
+			l.finalize()
+			return r.err
+		}
+		msgs := r.msgs
+		if r.file != nil {
+			l.recordDisabled(r.file)
+			if len(multi) > 0 {
+				msgs = append(msgs, l.lint(r.file, multi)...)
+			}
+		}
+		msgs, stop := l.stopAtError(l.postprocess(sortByPosition(msgs)))
+		if err := fn(msgs); err != nil || stop {
+			l.finalize()
+			return err
+		}
+	}
+
+	msgs, _ := l.stopAtError(l.postprocess(l.finalize()))
+	return fn(msgs)
+}
+
+// sortByPosition sorts a file's messages by their positions. Messages at the
+// same position keep the order in which they were reported.
+func sortByPosition(msgs Messages) Messages {
+	slices.SortStableFunc(msgs, func(a, b Message) int {
+		return cmp.Or(cmp.Compare(a.Pos.Line, b.Pos.Line), cmp.Compare(a.Pos.Column, b.Pos.Column))
+	})
+	return msgs
+}
+
+// ParseAndLint parses and lints Thrift source content, returning the parsed
+// program along with the messages. This is useful for tools that perform
+// additional analysis of the program. Parse errors are reported as messages
+// rather than as an error; in that case, the program is whatever partial
+// result the parser was able to produce, which may be nil.
+func (l *Linter) ParseAndLint(filename string, src []byte) (*ast.Program, Messages, error) {
+	program, msgs, err := l.parseAndLint(bytes.NewReader(src), filename)
+	if err != nil {
+		l.finalize()
+		return nil, nil, err
+	}
+	msgs, _ = l.stopAtError(l.postprocess(append(msgs, l.finalize()...)))
+	return program, l.applyPostProcessor(msgs), nil
+}
+
+// stopAtError drops the messages after the first error when the linter
+// fails fast, reporting whether there was an error.
+func (l *Linter) stopAtError(msgs Messages) (Messages, bool) {
+	if !l.failFast {
+		return msgs, false
+	}
+	if i := slices.IndexFunc(msgs, func(m Message) bool { return m.Severity == Error }); i >= 0 {
+		return msgs[:i+1], true
+	}
+	return msgs, false
+}
+
+// postprocess applies the linter's message-level options to the full set of
+// messages produced by a run.
+func (l *Linter) postprocess(msgs Messages) Messages {
+	if len(l.suppressions) > 0 {
+		msgs = slices.DeleteFunc(msgs, func(m Message) bool {
+			return slices.ContainsFunc(l.suppressions, func(s Suppression) bool { return s.Matches(m) })
+		})
+	}
+	for i := range msgs {
+		if severity, ok := lookupCheck(l.checkSeverity, msgs[i].Check); ok {
+			msgs[i].Severity = severity
+		}
+		for _, ps := range l.pathSeverities {
+			if fnmatch.Match(ps.Path, filepath.Clean(msgs[i].Filename), fnmatch.FNM_NOESCAPE) {
+				msgs[i].Severity = ps.Severity
+			}
+		}
+		if tmpl, ok := lookupCheck(l.templates, msgs[i].Check); ok {
+			var b strings.Builder
+			if err := tmpl.Execute(&b, msgs[i].data()); err != nil {
+				l.logger.Printf("message template for %s: %s\n", msgs[i].Check, err)
+			} else {
+				msgs[i].Message = b.String()
+			}
+		}
+	}
+
+	// Number identical messages so that their fingerprints are distinct.
+	occurrences := make(map[string]int)
+	for i := range msgs {
+		msgs[i].Occurrence = 0
+		fp := msgs[i].Fingerprint()
+		msgs[i].Occurrence = occurrences[fp]
+		occurrences[fp]++
+	}
+	return msgs
+}
+
+// applyPostProcessor passes msgs through the linter's MessagePostProcessor, if any.
+func (l *Linter) applyPostProcessor(msgs Messages) Messages {
+	if l.postProcessor == nil {
+		return msgs
+	}
+	return l.postProcessor(msgs)
+}
+
+// lookupCheck returns the value for the named check from a map keyed by check
+// names or name prefixes, preferring the longest matching key.
+func lookupCheck[V any](m map[string]V, check string) (value V, ok bool) {
+	longest := -1
+	for name, v := range m {
+		if len(name) > longest && (check == name || strings.HasPrefix(check, name+".")) {
+			value, ok, longest = v, true, len(name)
+		}
+	}
+	return value, ok
+}
+
+func (l *Linter) parseAndLint(r io.Reader, filename string) (*ast.Program, Messages, error) {
+	f, msgs, err := l.parse(r, filename)
+	if err != nil || msgs != nil {
+		return f.program, msgs, err
+	}
+	l.recordDisabled(f)
+	return f.program, append(f.warnings, l.lint(f, l.checks)...), nil
+}
+
+// recordDisabled keeps a file's disabled ranges for finalize.
+func (l *Linter) recordDisabled(f *parsedFile) {
+	if len(f.disabled) == 0 {
+		return
+	}
+	if l.disabled == nil {
+		l.disabled = make(map[string]disabledRanges)
+	}
+	l.disabled[filepath.Clean(f.filename)] = f.disabled
+}
+
+// parsedFile is a parsed input file that's ready to be linted.
+type parsedFile struct {
+	filename string
+	program  *ast.Program
+	info     *idl.Info
+	src      []byte
+	ignores  ignoreDirectives
+	disabled disabledRanges
+	warnings Messages
+}
+
+// parse reads and parses an input file. Parse errors are returned as
+// messages, along with whatever partial program the parser produced.
+func (l *Linter) parse(r io.Reader, filename string) (*parsedFile, Messages, error) {
+	src, err := io.ReadAll(r)
+	if err != nil {
+		return &parsedFile{filename: filename}, nil, fmt.Errorf("%s: %w", filename, err)
+	}
+	program, info, err := Parse(bytes.NewReader(src))
+	f := &parsedFile{filename: filename, program: program, info: info}
 	if err != nil {
 		var parseError *idl.ParseError
 		if errors.As(err, &parseError) {
@@ -81,55 +494,131 @@ func (l *Linter) Lint(r io.Reader, filename string) (Messages, error) {
 					Message:  err.Err.Error(),
 				}
 			}
-			return msgs, nil
+			return f, msgs, nil
 		}
-		return nil, fmt.Errorf("%s: %w", filename, err)
+		return &parsedFile{filename: filename}, nil, fmt.Errorf("%s: %w", filename, err)
+	}
+	f.src = src
+	f.ignores = parseIgnoreDirectives(src)
+
+	var unbalanced []unbalancedDirective
+	f.disabled, unbalanced = parseDisableDirectives(src)
+	for _, u := range unbalanced {
+		f.warnings = append(f.warnings, Message{
+			Filename: filename,
+			Pos:      ast.Position{Line: u.line},
+			Check:    "directive.unbalanced",
+			Severity: Warning,
+			Message:  u.String(),
+		})
 	}
-	return l.lint(program, filename, info), nil
+	return f, nil, nil
 }
 
-// LintFiles lints multiple files. Each is opened, parsed, and linted in
-// order, and the aggregate result is returned.
-func (l *Linter) LintFiles(filenames []string) (Messages, error) {
-	msgs := Messages{}
+// finalize runs all of the multi-file checks' finalize functions and returns
+// their aggregate messages, along with warnings for the included files that
+// couldn't be read, except for those in the linted files' disabled ranges.
+func (l *Linter) finalize() (messages Messages) {
+	defer clear(l.disabled)
+	defer clear(l.ran)
+	defer clear(l.skipped)
+	defer l.programs.reset()
 
-	for _, filename := range filenames {
-		f, err := os.Open(filename)
-		if err != nil {
-			return msgs, fmt.Errorf("%s: %w", filename, err)
+	for _, check := range l.checks {
+		ctx := &C{
+			Dirs:     l.includes,
+			logger:   l.logger,
+			includes: l.includes,
+			programs: l.programs,
 		}
-		defer f.Close()
-
-		m, err := l.Lint(f, filename)
-		if err != nil {
-			return msgs, err
+		if check.Finalize(ctx) {
+			switch {
+			case !l.ran[check.Name] && l.skipped[check.Name]:
+				l.logger.Printf("multi-file check %s was skipped for all of the files\n", check.Name)
+			case !l.ran[check.Name]:
+				ctx.Messages = append(ctx.Messages, Message{
+					Check:    "multifile.unused",
+					Severity: Warning,
+					Message:  fmt.Sprintf("multi-file check %s wasn't run on any files, so it found nothing to report", check.Name),
+				})
+			case !ctx.Received():
+				l.logger.Printf("multi-file check %s wasn't called for any nodes\n", check.Name)
+			}
+			messages = append(messages, ctx.Messages...)
 		}
-
-		msgs = append(msgs, m...)
 	}
-
-	return msgs, nil
+	messages = append(messages, l.programs.failures()...)
+	return slices.DeleteFunc(messages, func(m Message) bool {
+		return l.disabled[filepath.Clean(m.Filename)].suppresses(m)
+	})
 }
 
-func (l *Linter) lint(program *ast.Program, filename string, parseInfo *idl.Info) (messages Messages) {
-	l.logger.Printf("linting %s\n", filename)
+// lint runs the given checks over a parsed file.
+func (l *Linter) lint(f *parsedFile, checks Checks) (messages Messages) {
+	l.logger.Printf("linting %s\n", f.filename)
 
 	ctx := &C{
-		Filename:  filename,
-		Dirs:      append([]string{filepath.Dir(filename)}, l.includes...),
-		Program:   program,
+		Filename:  f.filename,
+		Dirs:      append([]string{filepath.Dir(f.filename)}, l.includes...),
+		Program:   f.program,
 		logger:    l.logger,
-		parseInfo: parseInfo,
+		parseInfo: f.info,
+		src:       f.src,
+		includes:  l.includes,
+		programs:  l.programs,
 	}
-	activeChecks := overridableChecks{root: &l.checks}
+	l.programs.add(f.filename, f.program)
+	rootChecks := checks
+	if l.skipUnresolved && slices.ContainsFunc(checks, func(c Check) bool { return c.IsMultiFile() }) {
+		if filename, include, ok := unresolvedInclude(f.program, ctx.Dirs[0], l.includes, l.programs); ok {
+			if l.skipped == nil {
+				l.skipped = make(map[string]bool)
+			}
+			rootChecks = slices.DeleteFunc(slices.Clone(rootChecks), func(c Check) bool {
+				if c.IsMultiFile() {
+					l.skipped[c.Name] = true
+				}
+				return c.IsMultiFile()
+			})
+			m := Message{
+				Filename: ctx.Filename,
+				Check:    "multifile.skipped",
+				Severity: Warning,
+				Message:  fmt.Sprintf("skipping multi-file checks because %q%s couldn't be found", include.Path, includedBy(filename)),
+			}
+			if filename == "" {
+				m.Pos, m.Node = ctx.pos(include), include
+			}
+			ctx.Messages = append(ctx.Messages, m)
+		}
+	}
+	for _, check := range rootChecks {
+		if check.IsMultiFile() {
+			if l.ran == nil {
+				l.ran = make(map[string]bool)
+			}
+			l.ran[check.Name] = true
+		}
+	}
+	activeChecks := overridableChecks{root: &rootChecks}
+	visited := 0
 
 	var visitor VisitorFunc
 	visitor = func(w ast.Walker, n ast.Node) VisitorFunc {
 		nodes := append([]ast.Node{n}, w.Ancestors()...)
 		checks := *activeChecks.lookup(nodes[1:])
 
-		// Handle 'nolint' directives.
-		if names, found := nolint(n); found {
+		// Handle 'nolint' directives and 'thriftcheck:ignore' comments.
+		names, found := nolint(n)
+		if ignored, ok := f.ignores[ctx.pos(n).Line]; ok {
+			if ignored == nil || (found && names == nil) {
+				names = nil
+			} else {
+				names = append(names, ignored...)
+			}
+			found = true
+		}
+		if found {
 			if names == nil {
 				return nil
 			}
@@ -138,15 +627,86 @@ func (l *Linter) lint(program *ast.Program, filename string, parseInfo *idl.Info
 		}
 
 		// Run all of the checks that match this part of the tree.
+		visited++
 		for _, check := range checks {
 			check.Call(ctx, nodes...)
 		}
 
+		// Skip this node's children if no check inspects anything beneath it.
+		if !checks.mayContain(n) {
+			return nil
+		}
 		return visitor
 	}
 
-	ast.Walk(visitor, program)
-	return ctx.Messages
+	ast.Walk(visitor, f.program)
+	l.visited.Add(int64(visited))
+	l.logger.Printf("visited %d nodes in %s\n", visited, f.filename)
+	return slices.DeleteFunc(ctx.Messages, f.disabled.suppresses)
+}
+
+// unresolvedInclude searches the includes of a program (in dir) and of the
+// files that it includes, returning the first include that can't be found
+// and the file that contains it. The filename is empty if the include is in
+// the program itself. Included files are parsed through programs, and those
+// that can't be parsed aren't searched.
+func unresolvedInclude(program *ast.Program, dir string, includes []string, programs *programCache) (string, *ast.Include, bool) {
+	type file struct {
+		filename string
+		program  *ast.Program
+	}
+	seen := make(map[string]bool)
+	queue := []file{{program: program}}
+	for len(queue) > 0 {
+		f := queue[0]
+		queue = queue[1:]
+
+		fdir := dir
+		if f.filename != "" {
+			fdir = filepath.Dir(f.filename)
+		}
+		dirs := append([]string{fdir}, includes...)
+		for _, h := range f.program.Headers {
+			include, ok := h.(*ast.Include)
+			if !ok {
+				continue
+			}
+			path, ok := FindFile(include.Path, dirs)
+			if !ok {
+				return f.filename, include, true
+			}
+			if seen[path] {
+				continue
+			}
+			seen[path] = true
+			if p := programs.get(path); p != nil {
+				queue = append(queue, file{filename: path, program: p})
+			}
+		}
+	}
+	return "", nil, false
+}
+
+// FindFile searches for a file, such as an included one, in each of the given
+// directories in order and returns its path. Absolute paths are used as is.
+func FindFile(path string, dirs []string) (string, bool) {
+	if filepath.IsAbs(path) {
+		dirs = []string{""}
+	}
+	for _, dir := range dirs {
+		path := filepath.Join(dir, path)
+		if info, err := os.Stat(path); err == nil && !info.IsDir() {
+			return path, true
+		}
+	}
+	return "", false
+}
+
+func includedBy(filename string) string {
+	if filename == "" {
+		return ""
+	}
+	return " (included by " + filename + ")"
 }
 
 // Stores Checks overrides that apply to a node and all of its children.
@@ -161,6 +721,12 @@ type override struct {
 }
 
 func (oc *overridableChecks) add(node ast.Node, checks *Checks) {
+	// Some nodes (such as ast.SetType) are values that can't be compared,
+	// so they can't be found among a later node's ancestors. Their overrides
+	// still apply to the node itself.
+	if !reflect.TypeOf(node).Comparable() {
+		return
+	}
 	oc.overrides = append(oc.overrides, override{node: node, checks: checks})
 }
 
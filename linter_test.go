@@ -15,11 +15,17 @@
 package thriftcheck
 
 import (
+	"errors"
+	"fmt"
 	"io"
 	"log"
+	"os"
+	"path/filepath"
 	"reflect"
+	"slices"
 	"strings"
 	"testing"
+	"text/template"
 
 	"go.uber.org/thriftrw/ast"
 )
@@ -40,6 +46,256 @@ func TestWithIncludes(t *testing.T) {
 	}
 }
 
+func TestWithPathSeverities(t *testing.T) {
+	dir := t.TempDir()
+	filenames := []string{filepath.Join(dir, "new", "a.thrift"), filepath.Join(dir, "legacy", "b.thrift")}
+	for _, filename := range filenames {
+		if err := os.MkdirAll(filepath.Dir(filename), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filename, []byte("struct S {}"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	linter := NewLinter(Checks{
+		NewCheck("struct", func(c *C, s *ast.Struct) { c.Errorf(s, "struct") }),
+	}, WithPathSeverities([]PathSeverity{
+		{Path: "*/legacy/*", Severity: Warning},
+	}))
+
+	msgs, err := linter.LintFiles(filenames)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []Severity{Error, Warning}
+	if len(msgs) != len(expected) {
+		t.Fatalf("expected %d messages, got %v", len(expected), msgs)
+	}
+	for i, m := range msgs {
+		if m.Severity != expected[i] {
+			t.Errorf("%s: expected %s, got %s", m.Filename, expected[i], m.Severity)
+		}
+	}
+}
+
+func TestWithCheckSeverities(t *testing.T) {
+	linter := NewLinter(Checks{
+		NewCheck("a.one", func(c *C, s *ast.Struct) { c.Errorf(s, "one") }),
+		NewCheck("a.two", func(c *C, s *ast.Struct) { c.Errorf(s, "two") }),
+		NewCheck("ab", func(c *C, s *ast.Struct) { c.Errorf(s, "ab") }),
+	}, WithCheckSeverities(map[string]Severity{
+		"a":     Warning,
+		"a.two": Error,
+	}))
+
+	msgs, err := linter.Lint(strings.NewReader("struct S {}"), "t.thrift")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := map[string]Severity{"a.one": Warning, "a.two": Error, "ab": Error}
+	if len(msgs) != len(expected) {
+		t.Fatalf("expected %d messages, got %v", len(expected), msgs)
+	}
+	for _, m := range msgs {
+		if m.Severity != expected[m.Check] {
+			t.Errorf("%s: expected %s, got %s", m.Check, expected[m.Check], m.Severity)
+		}
+	}
+}
+
+func TestWithMessageTemplates(t *testing.T) {
+	linter := NewLinter(Checks{
+		NewCheck("a.one", func(c *C, s *ast.Struct) { c.Errorf(s, "one") }),
+		NewCheck("b", func(c *C, s *ast.Struct) { c.Errorf(s, "b") }),
+	}, WithMessageTemplates(map[string]*template.Template{
+		"a": template.Must(template.New("a").Parse("{{.Message}} in {{.Name}} ({{.Check}} at {{.Filename}}:{{.Line}}, {{.Severity}})")),
+	}))
+
+	msgs, err := linter.Lint(strings.NewReader("struct S {}"), "t.thrift")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []string{
+		"t.thrift:1:1: error: one in S (a.one at t.thrift:1, error) (a.one)",
+		"t.thrift:1:1: error: b (b)",
+	}
+	if len(msgs) != len(expected) {
+		t.Fatalf("expected %d messages, got %v", len(expected), msgs)
+	}
+	for i, m := range msgs {
+		if m.String() != expected[i] {
+			t.Errorf("expected %q, got %q", expected[i], m.String())
+		}
+	}
+}
+
+func TestWithSuppressions(t *testing.T) {
+	dir := t.TempDir()
+	filenames := []string{filepath.Join(dir, "gen", "a.thrift"), filepath.Join(dir, "b.thrift")}
+	for _, filename := range filenames {
+		if err := os.MkdirAll(filepath.Dir(filename), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filename, []byte("struct S {}\nstruct T {}"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	linter := NewLinter(Checks{
+		NewCheck("check.warn", func(c *C, s *ast.Struct) { c.Warningf(s, "warn") }),
+		NewCheck("check.error", func(c *C, s *ast.Struct) { c.Errorf(s, "error") }),
+	}, WithSuppressions([]Suppression{
+		{File: "*/gen/*"},
+		{Check: "check.w*", StartLine: 2},
+	}))
+
+	msgs, err := linter.LintFiles(filenames)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var got []string
+	for _, m := range msgs {
+		got = append(got, fmt.Sprintf("%s:%d:%s", filepath.Base(m.Filename), m.Pos.Line, m.Check))
+	}
+	expected := []string{"b.thrift:1:check.warn", "b.thrift:1:check.error", "b.thrift:2:check.error"}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+}
+
+func TestWithMessagePostProcessor(t *testing.T) {
+	dir := t.TempDir()
+	filenames := []string{filepath.Join(dir, "a.thrift"), filepath.Join(dir, "b.thrift")}
+	for _, filename := range filenames {
+		if err := os.WriteFile(filename, []byte("struct S {}\nstruct T {}"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	calls := 0
+	linter := NewLinter(Checks{
+		NewCheck("check.warn", func(c *C, s *ast.Struct) { c.Warningf(s, "warn") }),
+		NewCheck("check.error", func(c *C, s *ast.Struct) { c.Errorf(s, "error") }),
+	}, WithMessagePostProcessor(func(msgs Messages) Messages {
+		calls++
+		msgs = slices.DeleteFunc(msgs, func(m Message) bool { return m.Check == "check.warn" })
+		slices.Reverse(msgs)
+		return msgs
+	}))
+
+	msgs, err := linter.LintFiles(filenames)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var got []string
+	for _, m := range msgs {
+		got = append(got, fmt.Sprintf("%s:%d:%s", filepath.Base(m.Filename), m.Pos.Line, m.Check))
+	}
+	expected := []string{"b.thrift:2:check.error", "b.thrift:1:check.error", "a.thrift:2:check.error", "a.thrift:1:check.error"}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+	if calls != 1 {
+		t.Errorf("expected the post-processor to be called once, got %d calls", calls)
+	}
+}
+
+func TestWithSkipMultiFileOnUnresolved(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"a.thrift":        "include \"missing.thrift\"\nstruct A {}",
+		"b.thrift":        "include \"nested/c.thrift\"\nstruct B {}",
+		"nested/c.thrift": "include \"missing.thrift\"",
+		"d.thrift":        "include \"nested/e.thrift\"\nstruct D {}",
+		"nested/e.thrift": "",
+	}
+	for name, content := range files {
+		filename := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(filename), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filename, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	filenames := []string{filepath.Join(dir, "a.thrift"), filepath.Join(dir, "b.thrift"), filepath.Join(dir, "d.thrift")}
+
+	tests := []struct {
+		skip  bool
+		files []string
+		want  []string
+	}{
+		{
+			skip: false,
+			want: []string{
+				"a.thrift:2:1: warning: single (single)",
+				"b.thrift:2:1: warning: single (single)",
+				"d.thrift:2:1: warning: single (single)",
+				"multi: A B D",
+			},
+		},
+		{
+			skip: true,
+			want: []string{
+				`a.thrift:1:1: warning: skipping multi-file checks because "missing.thrift" couldn't be found (multifile.skipped)`,
+				"a.thrift:2:1: warning: single (single)",
+				`b.thrift:0:1: warning: skipping multi-file checks because "missing.thrift" (included by nested/c.thrift) couldn't be found (multifile.skipped)`,
+				"b.thrift:2:1: warning: single (single)",
+				"d.thrift:2:1: warning: single (single)",
+				"multi: D",
+			},
+		},
+		{
+			skip:  true,
+			files: filenames[:1],
+			want: []string{
+				`a.thrift:1:1: warning: skipping multi-file checks because "missing.thrift" couldn't be found (multifile.skipped)`,
+				"a.thrift:2:1: warning: single (single)",
+				"multi: ",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		var structs []string
+		linter := NewLinter(Checks{
+			NewCheck("single", func(c *C, s *ast.Struct) { c.Warningf(s, "single") }),
+			NewMultiFileCheck("multi", func(c *C, s *ast.Struct) {
+				structs = append(structs, s.Name)
+			}, func(c *C) {
+				c.WarningfAt(Location{}, "%s", strings.Join(structs, " "))
+				structs = nil
+			}),
+		}, WithSkipMultiFileOnUnresolved(tt.skip))
+
+		files := filenames
+		if tt.files != nil {
+			files = tt.files
+		}
+		msgs, err := linter.LintFiles(files)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		var got []string
+		for _, m := range msgs {
+			if m.Check == "multi" {
+				got = append(got, "multi: "+m.Message)
+			} else {
+				got = append(got, strings.ReplaceAll(m.String(), dir+string(filepath.Separator), ""))
+			}
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("skip=%v:\n- %v\n+ %v", tt.skip, tt.want, got)
+		}
+	}
+}
+
 func TestLint(t *testing.T) {
 	linter := NewLinter(Checks{
 		NewCheck("node", func(c *C, n ast.Node) { c.Errorf(n, "node") }),
@@ -80,6 +336,295 @@ func TestLint(t *testing.T) {
 	}
 }
 
+const visitTestSource = `
+	const map<string, list<i32>> LIMITS = {"a": [1, 2, 3], "b": [4, 5, 6]}
+
+	struct TestStruct {
+		1: string field1
+		2: list<bool> field2 = [true, false]
+	}
+
+	enum TestEnum {
+		ONE = 1
+		TWO = 2
+	}
+`
+
+func visitedNodes(t testing.TB, checks Checks) int {
+	linter := NewLinter(checks)
+	if _, err := linter.Lint(strings.NewReader(visitTestSource), "t.thrift"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return int(linter.visited.Load())
+}
+
+func TestLintSkipsUninspectedNodes(t *testing.T) {
+	all := visitedNodes(t, Checks{NewCheck("node", func(c *C, n ast.Node) {})})
+	fields := visitedNodes(t, Checks{NewCheck("field", func(c *C, f *ast.Field) {})})
+	if fields >= all {
+		t.Errorf("expected fewer than %d visited nodes with only a field check, got %d", all, fields)
+	}
+
+	// Program, Constant, Struct, 2 Fields, and Enum.
+	if fields != 6 {
+		t.Errorf("expected 6 visited nodes with only a field check, got %d", fields)
+	}
+}
+
+func BenchmarkLintNodeKinds(b *testing.B) {
+	benchmarks := map[string]Checks{
+		"node":  {NewCheck("node", func(c *C, n ast.Node) {})},
+		"field": {NewCheck("field", func(c *C, f *ast.Field) {})},
+	}
+	for name, checks := range benchmarks {
+		b.Run(name, func(b *testing.B) {
+			linter := NewLinter(checks)
+			for range b.N {
+				if _, err := linter.Lint(strings.NewReader(visitTestSource), "t.thrift"); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func TestLintFilesMultiFile(t *testing.T) {
+	dir := t.TempDir()
+	filenames := []string{filepath.Join(dir, "a.thrift"), filepath.Join(dir, "b.thrift")}
+	for _, filename := range filenames {
+		if err := os.WriteFile(filename, []byte("struct S {}"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var structs []Location
+	linter := NewLinter(Checks{
+		NewMultiFileCheck("multi", func(c *C, s *ast.Struct) {
+			structs = append(structs, c.Locate(s))
+		}, func(c *C) {
+			for _, loc := range structs[1:] {
+				c.ErrorfAt(loc, "duplicate struct")
+			}
+			structs = nil
+		}),
+	})
+
+	for range 2 {
+		msgs, err := linter.LintFiles(filenames)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		want := []string{filenames[1] + ":1:1: error: duplicate struct (multi)"}
+		strings := make([]string, len(msgs))
+		for i, m := range msgs {
+			strings[i] = m.String()
+		}
+		if !reflect.DeepEqual(strings, want) {
+			t.Errorf("- %v\n+ %v", want, strings)
+		}
+	}
+}
+
+func TestWithJobs(t *testing.T) {
+	dir := t.TempDir()
+	var filenames []string
+	for i := range 20 {
+		filename := filepath.Join(dir, fmt.Sprintf("%02d.thrift", i))
+		if err := os.WriteFile(filename, []byte("struct S {\n1: i32 a\n2: i32 b\n}"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		filenames = append(filenames, filename)
+	}
+
+	lint := func(jobs int) []string {
+		var structs []Location
+		linter := NewLinter(Checks{
+			NewCheck("field", func(c *C, f *ast.Field) { c.Warningf(f, "field") }),
+			NewCheck("struct", func(c *C, s *ast.Struct) { c.Warningf(s, "struct") }),
+			NewMultiFileCheck("multi", func(c *C, s *ast.Struct) {
+				structs = append(structs, c.Locate(s))
+			}, func(c *C) {
+				for _, loc := range structs[1:] {
+					c.ErrorfAt(loc, "duplicate struct")
+				}
+				structs = nil
+			}),
+		}, WithJobs(jobs))
+
+		msgs, err := linter.LintFiles(filenames)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		strings := make([]string, len(msgs))
+		for i, m := range msgs {
+			strings[i] = m.String()
+		}
+		return strings
+	}
+
+	want := lint(1)
+	if len(want) != 20*3+19 {
+		t.Fatalf("expected %d messages, got %d", 20*3+19, len(want))
+	}
+	for range 5 {
+		if got := lint(8); !reflect.DeepEqual(got, want) {
+			t.Errorf("- %v\n+ %v", want, got)
+		}
+	}
+}
+
+func TestWithFailFast(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"a.thrift": "struct A {\n1: i32 a\n}",
+		"b.thrift": "struct B {\n1: i32 bad\n2: i32 b\n3: i32 alsoBad\n}",
+		"c.thrift": "struct C {\n1: i32 bad\n}",
+	}
+	var filenames []string
+	for _, name := range []string{"a.thrift", "b.thrift", "c.thrift"} {
+		filename := filepath.Join(dir, name)
+		if err := os.WriteFile(filename, []byte(files[name]), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		filenames = append(filenames, filename)
+	}
+
+	for _, jobs := range []int{1, 4} {
+		finalized := 0
+		linter := NewLinter(Checks{
+			NewCheck("field", func(c *C, f *ast.Field) {
+				if strings.HasSuffix(strings.ToLower(f.Name), "bad") {
+					c.Errorf(f, "bad field")
+				} else {
+					c.Warningf(f, "field")
+				}
+			}),
+			NewMultiFileCheck("multi", func(c *C, s *ast.Struct) {}, func(c *C) {
+				finalized++
+				c.ErrorfAt(Location{}, "multi")
+			}),
+		}, WithFailFast(true), WithJobs(jobs))
+
+		msgs, err := linter.LintFiles(filenames)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		want := []string{
+			"a.thrift:2:1: warning: field (field)",
+			"b.thrift:2:1: error: bad field (field)",
+		}
+		got := make([]string, len(msgs))
+		for i, m := range msgs {
+			got[i] = strings.ReplaceAll(m.String(), dir+string(filepath.Separator), "")
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("jobs=%d:\n- %v\n+ %v", jobs, want, got)
+		}
+		if finalized != 1 {
+			t.Errorf("jobs=%d: expected the multi-file check to be reset once, got %d", jobs, finalized)
+		}
+	}
+}
+
+func TestLintFilesFunc(t *testing.T) {
+	dir := t.TempDir()
+	filenames := []string{filepath.Join(dir, "a.thrift"), filepath.Join(dir, "b.thrift")}
+	for _, filename := range filenames {
+		if err := os.WriteFile(filename, []byte("struct S {}"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	count := 0
+	linter := NewLinter(Checks{
+		NewCheck("struct", func(c *C, s *ast.Struct) { c.Warningf(s, "struct") }),
+		NewMultiFileCheck("multi", func(c *C, s *ast.Struct) { count++ }, func(c *C) {
+			c.ErrorfAt(Location{Filename: filenames[0]}, "%d structs", count)
+			count = 0
+		}),
+	})
+
+	var batches [][]string
+	err := linter.LintFilesFunc(filenames, func(msgs Messages) error {
+		var batch []string
+		for _, m := range msgs {
+			batch = append(batch, m.Check)
+		}
+		batches = append(batches, batch)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := [][]string{{"struct"}, {"struct"}, {"multi"}}
+	if !reflect.DeepEqual(batches, want) {
+		t.Errorf("- %v\n+ %v", want, batches)
+	}
+
+	stop := errors.New("stop")
+	calls := 0
+	err = linter.LintFilesFunc(filenames, func(msgs Messages) error {
+		calls++
+		return stop
+	})
+	if err != stop || calls != 1 {
+		t.Errorf("expected to stop after 1 call with %v, got %d calls and %v", stop, calls, err)
+	}
+	if count != 0 {
+		t.Errorf("expected multi-file state to be reset, got %d", count)
+	}
+}
+
+func TestParseAndLint(t *testing.T) {
+	linter := NewLinter(Checks{
+		NewCheck("struct.empty", func(c *C, s *ast.Struct) {
+			if len(s.Fields) == 0 {
+				c.Warningf(s, "struct %q is empty", s.Name)
+			}
+		}),
+	})
+
+	tests := []struct {
+		src  string
+		defs int
+		want []string
+	}{
+		{
+			src:  "struct S {\n  1: string s\n}",
+			defs: 1,
+			want: []string{},
+		},
+		{
+			src:  "struct S {\n  1: string s\n}\nstruct Empty {}",
+			defs: 2,
+			want: []string{`t.thrift:4:1: warning: struct "Empty" is empty (struct.empty)`},
+		},
+		{
+			src:  "struct {}",
+			want: []string{`t.thrift:1:8: error: syntax error: unexpected '{', expecting IDENTIFIER (parse)`},
+		},
+	}
+
+	for _, tt := range tests {
+		program, msgs, err := linter.ParseAndLint("t.thrift", []byte(tt.src))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if tt.defs > 0 && (program == nil || len(program.Definitions) != tt.defs) {
+			t.Errorf("%q: expected a program with %d definitions, got %v", tt.src, tt.defs, program)
+		}
+		strings := make([]string, len(msgs))
+		for i, m := range msgs {
+			strings[i] = m.String()
+		}
+		if !reflect.DeepEqual(strings, tt.want) {
+			t.Errorf("%q:\n- %v\n+ %v", tt.src, tt.want, strings)
+		}
+	}
+}
+
 func TestParseError(t *testing.T) {
 	tests := []struct {
 		s    string
@@ -97,6 +642,23 @@ func TestParseError(t *testing.T) {
 				`t.thrift:1:12: error: syntax error: unexpected '}' (parse)`,
 			},
 		},
+		// void is only valid as a function's return type.
+		{
+			s: "struct S {\n  1: optional void a\n}",
+			want: []string{
+				`t.thrift:2:15: error: syntax error: unexpected VOID (parse)`,
+			},
+		},
+		{
+			s: "struct S {\n  1: optional list<void> a\n}",
+			want: []string{
+				`t.thrift:2:20: error: syntax error: unexpected VOID (parse)`,
+			},
+		},
+		{
+			s:    "service S {\n  void ping()\n}",
+			want: []string{},
+		},
 	}
 
 	linter := NewLinter(Checks{})
@@ -232,7 +794,7 @@ func TestNoLint(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.desc, func(t *testing.T) {
 			actual := map[ast.Node][]string{}
-			for _, m := range linter.lint(tt.node, "filename.thrift", nil) {
+			for _, m := range linter.lint(&parsedFile{filename: "filename.thrift", program: tt.node}, linter.checks) {
 				if _, ok := m.Node.(*ast.Program); !ok {
 					actual[m.Node] = append(actual[m.Node], m.Check)
 				}
@@ -244,6 +806,167 @@ func TestNoLint(t *testing.T) {
 	}
 }
 
+func TestIgnoreComments(t *testing.T) {
+	linter := NewLinter(Checks{
+		NewCheck("check.struct", func(c *C, s *ast.Struct) { c.Warningf(s, "") }),
+		NewCheck("check.field", func(c *C, f *ast.Field) { c.Warningf(f, "") }),
+	})
+
+	tests := []struct {
+		desc string
+		src  string
+		want []string
+	}{
+		{
+			desc: "none",
+			src:  "struct S {\n1: i32 a\n}",
+			want: []string{"check.struct", "check.field"},
+		},
+		{
+			desc: "line above",
+			src:  "// thriftcheck:ignore\nstruct S {\n1: i32 a\n}",
+			want: []string{},
+		},
+		{
+			desc: "line above with names",
+			src:  "# thriftcheck:ignore check.field, check.other\nstruct S {\n1: i32 a\n}",
+			want: []string{"check.struct"},
+		},
+		{
+			desc: "trailing",
+			src:  "struct S {\n1: i32 a // thriftcheck:ignore check.field\n2: i32 b\n}",
+			want: []string{"check.struct", "check.field"},
+		},
+		{
+			desc: "other check",
+			src:  "struct S {\n// thriftcheck:ignore check.struct\n1: i32 a\n}",
+			want: []string{"check.struct", "check.field"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			msgs, err := linter.Lint(strings.NewReader(tt.src), "t.thrift")
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			got := []string{}
+			for _, m := range msgs {
+				got = append(got, m.Check)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestDisableComments(t *testing.T) {
+	linter := NewLinter(Checks{
+		NewCheck("check.struct", func(c *C, s *ast.Struct) { c.Warningf(s, "") }),
+		NewCheck("check.field", func(c *C, f *ast.Field) { c.Warningf(f, "") }),
+	})
+
+	tests := []struct {
+		desc string
+		src  string
+		want []string
+	}{
+		{
+			desc: "balanced",
+			src:  "struct S {\n// thriftcheck:disable check.field\n1: i32 a\n2: i32 b\n// thriftcheck:enable check.field\n3: i32 c\n}",
+			want: []string{"1:check.struct", "6:check.field"},
+		},
+		{
+			desc: "all checks",
+			src:  "// thriftcheck:disable\nstruct S {\n1: i32 a\n}\n// thriftcheck:enable\nstruct T {}",
+			want: []string{"6:check.struct"},
+		},
+		{
+			desc: "unbalanced",
+			src:  "// thriftcheck:enable check.struct\nstruct S {\n// thriftcheck:disable check.field\n1: i32 a\n}",
+			want: []string{"1:directive.unbalanced", "3:directive.unbalanced", "2:check.struct"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			msgs, err := linter.Lint(strings.NewReader(tt.src), "t.thrift")
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			got := []string{}
+			for _, m := range msgs {
+				got = append(got, fmt.Sprintf("%d:%s", m.Pos.Line, m.Check))
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestDisableCommentsMultiFile(t *testing.T) {
+	dir := t.TempDir()
+	a := filepath.Join(dir, "a.thrift")
+	b := filepath.Join(dir, "b.thrift")
+	if err := os.WriteFile(a, []byte("// thriftcheck:disable multi\nstruct S {}\n// thriftcheck:enable multi\nstruct T {}"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(b, []byte("struct U {}"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	var locs []Location
+	check := NewMultiFileCheck("multi", func(c *C, s *ast.Struct) {
+		locs = append(locs, c.Locate(s))
+	}, func(c *C) {
+		for _, loc := range locs {
+			c.WarningfAt(loc, "")
+		}
+		locs = nil
+	})
+
+	for _, jobs := range []int{1, 2} {
+		msgs, err := NewLinter(Checks{check}, WithJobs(jobs)).LintFiles([]string{a, b})
+		if err != nil {
+			t.Fatal(err)
+		}
+		got := []string{}
+		for _, m := range msgs {
+			got = append(got, fmt.Sprintf("%s:%d", filepath.Base(m.Filename), m.Pos.Line))
+		}
+		if want := []string{"a.thrift:4", "b.thrift:1"}; !reflect.DeepEqual(got, want) {
+			t.Errorf("jobs=%d: expected %v, got %v", jobs, want, got)
+		}
+	}
+}
+
+func TestMessageOccurrences(t *testing.T) {
+	linter := NewLinter(Checks{
+		NewCheck("check", func(c *C, f *ast.Field) { c.Warningf(f, "same") }),
+	})
+	msgs, err := linter.Lint(strings.NewReader("struct S {\n1: i32 a\n2: i32 b\n}\nstruct T {\n1: i32 c\n}"), "t.thrift")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	seen := make(map[string]bool)
+	for i, m := range msgs {
+		if m.Occurrence != i {
+			t.Errorf("expected message %d to be occurrence %d, got %d", i, i, m.Occurrence)
+		}
+		if fp := m.Fingerprint(); seen[fp] {
+			t.Errorf("duplicate fingerprint %s for %v", fp, m)
+		} else {
+			seen[fp] = true
+		}
+	}
+	if len(seen) != 3 {
+		t.Errorf("expected 3 distinct fingerprints, got %d", len(seen))
+	}
+}
+
 func TestOverrideableChecksLookup(t *testing.T) {
 	root := &Checks{Check{Name: "root"}}
 	pnode := &ast.Program{}
@@ -296,3 +1019,28 @@ func TestOverrideableChecksOverrides(t *testing.T) {
 		t.Errorf("expected overrides to be cleared")
 	}
 }
+
+func TestFindFile(t *testing.T) {
+	common := filepath.Join("testdata", "common.thrift")
+	abs, err := filepath.Abs(common)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		path string
+		dirs []string
+		want string
+		ok   bool
+	}{
+		{"common.thrift", []string{"missing", "testdata"}, common, true},
+		{"common.thrift", []string{"."}, "", false},
+		{"testdata", []string{"."}, "", false},
+		{abs, []string{"missing"}, abs, true},
+	}
+	for _, tt := range tests {
+		if got, ok := FindFile(tt.path, tt.dirs); got != tt.want || ok != tt.ok {
+			t.Errorf("FindFile(%q, %q) = %q, %v; expected %q, %v", tt.path, tt.dirs, got, ok, tt.want, tt.ok)
+		}
+	}
+}
@@ -15,7 +15,13 @@
 package thriftcheck
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
 
 	"go.uber.org/thriftrw/ast"
 )
@@ -37,6 +43,32 @@ func (s Severity) String() string {
 	return "error"
 }
 
+// UnmarshalString implements fig.StringUnmarshaler for automatic toml parsing.
+func (s *Severity) UnmarshalString(v string) error {
+	switch strings.ToLower(v) {
+	case "warning":
+		*s = Warning
+	case "error":
+		*s = Error
+	default:
+		return fmt.Errorf("unknown severity: %s, valid severities are: [error warning]", v)
+	}
+	return nil
+}
+
+// MessageData is the data available to a message template.
+type MessageData struct {
+	Check    string
+	Filename string
+	Line     int
+	Column   int
+	Severity string
+	// Name is the name of the node the message is about, if it has one.
+	Name string
+	// Message is the check's default message text.
+	Message string
+}
+
 // Message is a message produced by a Check.
 type Message struct {
 	Filename string
@@ -45,14 +77,74 @@ type Message struct {
 	Check    string
 	Severity Severity
 	Message  string
+	// Fix is a suggested edit that resolves the message, if there is one.
+	Fix *Edit
+	// Owner identifies the owners of the message's file, if they're known.
+	Owner string
+	// Occurrence counts the earlier messages in the same run with the same
+	// file, check, and text, which tells otherwise identical messages apart
+	// in their fingerprints.
+	Occurrence int
 }
 
 func (m Message) String() string {
-	col := m.Pos.Column
-	if col == 0 {
-		col = 1
+	if m.Filename == "" {
+		return fmt.Sprintf("%s: %s (%s)", m.Severity, m.Message, m.Check)
+	}
+	return fmt.Sprintf("%s:%d:%d: %s: %s (%s)", m.Filename, m.Pos.Line, m.column(), m.Severity, m.Message, m.Check)
+}
+
+func (m Message) data() MessageData {
+	return MessageData{
+		Check:    m.Check,
+		Filename: m.Filename,
+		Line:     m.Pos.Line,
+		Column:   m.column(),
+		Severity: m.Severity.String(),
+		Name:     Name(m.Node),
+		Message:  m.Message,
+	}
+}
+
+// MarshalJSON encodes the message as a JSON object with the same fields as
+// its String representation, along with its fingerprint and its owner (if it
+// has one).
+func (m Message) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Filename    string `json:"filename"`
+		Line        int    `json:"line"`
+		Column      int    `json:"column"`
+		Severity    string `json:"severity"`
+		Check       string `json:"check"`
+		Message     string `json:"message"`
+		Fingerprint string `json:"fingerprint"`
+		Owner       string `json:"owner,omitempty"`
+	}{m.Filename, m.Pos.Line, m.column(), m.Severity.String(), m.Check, m.Message, m.Fingerprint(), m.Owner})
+}
+
+// column returns the message's column, which is 1 if it's unknown.
+func (m Message) column() int {
+	if m.Pos.Column == 0 {
+		return 1
+	}
+	return m.Pos.Column
+}
+
+// lineRefRegexp matches the line numbers that some messages refer to.
+var lineRefRegexp = regexp.MustCompile(`\bline \d+\b`)
+
+// Fingerprint returns a string that identifies the message across runs. It's
+// derived from the message's file, check, text, and occurrence, but not its
+// position (or any line numbers in its text), so it's unaffected by changes
+// elsewhere in the file.
+func (m Message) Fingerprint() string {
+	h := sha256.New()
+	text := lineRefRegexp.ReplaceAllString(m.Message, "line")
+	fmt.Fprintf(h, "%s\x00%s\x00%s", filepath.ToSlash(filepath.Clean(m.Filename)), m.Check, text)
+	if m.Occurrence > 0 {
+		fmt.Fprintf(h, "\x00%d", m.Occurrence)
 	}
-	return fmt.Sprintf("%s:%d:%d: %s: %s (%s)", m.Filename, m.Pos.Line, col, m.Severity, m.Message, m.Check)
+	return hex.EncodeToString(h.Sum(nil))
 }
 
 // Messages is a list of messages.
@@ -15,6 +15,7 @@
 package thriftcheck
 
 import (
+	"encoding/json"
 	"testing"
 
 	"go.uber.org/thriftrw/ast"
@@ -33,6 +34,10 @@ func TestMessageString(t *testing.T) {
 			&Message{Filename: "a.thrift", Pos: ast.Position{Line: 5}, Check: "check", Severity: Error, Message: "Error"},
 			"a.thrift:5:1: error: Error (check)",
 		},
+		{
+			&Message{Check: "check", Severity: Warning, Message: "Warning"},
+			"warning: Warning (check)",
+		},
 	}
 
 	for _, tt := range tests {
@@ -42,3 +47,71 @@ func TestMessageString(t *testing.T) {
 		}
 	}
 }
+
+func TestMessageFingerprint(t *testing.T) {
+	m := Message{Filename: "idl/a.thrift", Pos: ast.Position{Line: 5}, Check: "check", Severity: Warning, Message: "Warning"}
+
+	moved := m
+	moved.Filename = "./idl/a.thrift"
+	moved.Pos = ast.Position{Line: 10, Column: 3}
+	if m.Fingerprint() != moved.Fingerprint() {
+		t.Errorf("expected the same fingerprint after moving the message: %s != %s", m.Fingerprint(), moved.Fingerprint())
+	}
+
+	ref := Message{Filename: m.Filename, Check: m.Check, Message: `"a" conflicts with "b" (line 3)`}
+	movedRef := ref
+	movedRef.Message = `"a" conflicts with "b" (line 7)`
+	if ref.Fingerprint() != movedRef.Fingerprint() {
+		t.Errorf("expected the same fingerprint after moving the referenced line: %s != %s", ref.Fingerprint(), movedRef.Fingerprint())
+	}
+
+	for _, other := range []Message{
+		{Filename: "idl/b.thrift", Check: m.Check, Message: m.Message},
+		{Filename: m.Filename, Check: "other", Message: m.Message},
+		{Filename: m.Filename, Check: m.Check, Message: "Other"},
+	} {
+		if m.Fingerprint() == other.Fingerprint() {
+			t.Errorf("expected %v and %v to have different fingerprints", m, other)
+		}
+	}
+	second := m
+	second.Occurrence = 1
+	if m.Fingerprint() == second.Fingerprint() {
+		t.Errorf("expected the second occurrence of %v to have a different fingerprint", m)
+	}
+}
+
+func TestMessageMarshalJSON(t *testing.T) {
+	m := Message{Filename: "a.thrift", Pos: ast.Position{Line: 5}, Check: "check", Severity: Error, Message: `"quoted"`}
+	b, err := json.Marshal(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `{"filename":"a.thrift","line":5,"column":1,"severity":"error","check":"check","message":"\"quoted\"","fingerprint":"` + m.Fingerprint() + `"}`
+	if string(b) != expected {
+		t.Errorf("expected %s, got %s", expected, b)
+	}
+}
+
+func TestSeverityUnmarshalString(t *testing.T) {
+	tests := []struct {
+		s        string
+		severity Severity
+		err      bool
+	}{
+		{"warning", Warning, false},
+		{"error", Error, false},
+		{"Error", Error, false},
+		{"fatal", Warning, true},
+	}
+
+	for _, tt := range tests {
+		var severity Severity
+		err := severity.UnmarshalString(tt.s)
+		if (err != nil) != tt.err {
+			t.Errorf("%q: unexpected error: %v", tt.s, err)
+		} else if severity != tt.severity {
+			t.Errorf("%q: expected %s, got %s", tt.s, tt.severity, severity)
+		}
+	}
+}
@@ -15,9 +15,14 @@
 package thriftcheck
 
 import (
+	"bytes"
+	"cmp"
+	"path/filepath"
 	"regexp"
+	"slices"
 	"strings"
 
+	"github.com/danwakefield/fnmatch"
 	"go.uber.org/thriftrw/ast"
 )
 
@@ -56,6 +61,133 @@ func nolint(n ast.Node) ([]string, bool) {
 	return names, true
 }
 
+var ignoreRegexp = regexp.MustCompile(`^(.*?)(?://|#)\s*thriftcheck:ignore(?:\s+(.*?))?\s*$`)
+
+// ignoreDirectives maps line numbers to the checks that `thriftcheck:ignore`
+// comments disable for nodes starting on that line. A nil list disables all
+// checks.
+type ignoreDirectives map[int][]string
+
+// parseIgnoreDirectives finds the `thriftcheck:ignore` comments in a file's
+// source. A comment on a line of its own applies to the following line, and
+// a trailing comment applies to its own line.
+func parseIgnoreDirectives(src []byte) ignoreDirectives {
+	var ignores ignoreDirectives
+	for i, line := range bytes.Split(src, []byte("\n")) {
+		m := ignoreRegexp.FindSubmatch(line)
+		if m == nil {
+			continue
+		}
+		target := i + 1
+		if len(bytes.TrimSpace(m[1])) == 0 {
+			target++
+		}
+		names := strings.FieldsFunc(string(m[2]), func(r rune) bool { return r == ',' || r == ' ' || r == '\t' })
+		if ignores == nil {
+			ignores = make(ignoreDirectives)
+		}
+		if existing, ok := ignores[target]; len(names) == 0 || (ok && existing == nil) {
+			ignores[target] = nil
+		} else {
+			ignores[target] = append(existing, names...)
+		}
+	}
+	return ignores
+}
+
+var disableRegexp = regexp.MustCompile(`(?://|#)\s*thriftcheck:(disable|enable)(?:\s+(.*?))?\s*$`)
+
+// disabledRange is an inclusive range of lines in which a
+// `thriftcheck:disable` comment disabled a check (or, if the name is empty,
+// all checks). An end of 0 means that the range extends to the end of the
+// file.
+type disabledRange struct {
+	name       string
+	start, end int
+}
+
+// disabledRanges is the set of ranges disabled in a file.
+type disabledRanges []disabledRange
+
+// suppresses reports whether any of the ranges disable a message.
+func (r disabledRanges) suppresses(m Message) bool {
+	return slices.ContainsFunc(r, func(d disabledRange) bool {
+		if m.Pos.Line < d.start || (d.end > 0 && m.Pos.Line > d.end) {
+			return false
+		}
+		return d.name == "" || m.Check == d.name || strings.HasPrefix(m.Check, d.name+".")
+	})
+}
+
+// unbalancedDirective is a `thriftcheck:disable` comment without a matching
+// `thriftcheck:enable` comment, or vice versa.
+type unbalancedDirective struct {
+	line      int
+	directive string
+	name      string
+}
+
+func (u unbalancedDirective) String() string {
+	other := "enable"
+	if u.directive == "enable" {
+		other = "disable"
+	}
+	return strings.TrimSpace("thriftcheck:"+u.directive+" "+u.name) + " has no matching thriftcheck:" + other
+}
+
+// parseDisableDirectives finds the `thriftcheck:disable` and
+// `thriftcheck:enable` comments in a file's source. Each disabled check stays
+// disabled until a later `enable` comment names it; a bare `enable` comment
+// re-enables everything. Markers that don't pair up are returned separately,
+// and a `disable` comment without an `enable` lasts until the end of the file.
+func parseDisableDirectives(src []byte) (disabledRanges, []unbalancedDirective) {
+	var ranges disabledRanges
+	var unbalanced []unbalancedDirective
+	open := make(map[string]int)
+
+	for i, text := range bytes.Split(src, []byte("\n")) {
+		m := disableRegexp.FindSubmatch(text)
+		if m == nil {
+			continue
+		}
+		line, directive := i+1, string(m[1])
+		names := strings.FieldsFunc(string(m[2]), func(r rune) bool { return r == ',' || r == ' ' || r == '\t' })
+		if len(names) == 0 {
+			names = []string{""}
+		}
+
+		for _, name := range names {
+			if directive == "disable" {
+				if _, ok := open[name]; !ok {
+					open[name] = len(ranges)
+					ranges = append(ranges, disabledRange{name: name, start: line})
+				}
+				continue
+			}
+
+			if name == "" && len(open) > 0 {
+				for _, j := range open {
+					ranges[j].end = line
+				}
+				clear(open)
+			} else if j, ok := open[name]; ok {
+				ranges[j].end = line
+				delete(open, name)
+			} else {
+				unbalanced = append(unbalanced, unbalancedDirective{line: line, directive: directive, name: name})
+			}
+		}
+	}
+
+	for _, r := range ranges {
+		if r.end == 0 {
+			unbalanced = append(unbalanced, unbalancedDirective{line: r.start, directive: "disable", name: r.name})
+		}
+	}
+	slices.SortStableFunc(unbalanced, func(a, b unbalancedDirective) int { return cmp.Compare(a.line, b.line) })
+	return ranges, unbalanced
+}
+
 func splitTrim(s, sep string) []string {
 	values := strings.Split(s, sep)
 	for i := range values {
@@ -63,3 +195,32 @@ func splitTrim(s, sep string) []string {
 	}
 	return values
 }
+
+// Suppression suppresses messages without requiring inline `nolint`
+// directives. Messages are suppressed when their filename matches the File
+// glob pattern and their check name matches the Check glob pattern. An empty
+// pattern matches everything. If StartLine or EndLine are non-zero, only
+// messages within that (inclusive) line range are suppressed.
+type Suppression struct {
+	File      string `fig:"file"`
+	Check     string `fig:"check"`
+	StartLine int    `fig:"startLine"`
+	EndLine   int    `fig:"endLine"`
+}
+
+// Matches reports whether the Suppression applies to a message.
+func (s Suppression) Matches(m Message) bool {
+	if s.File != "" && !fnmatch.Match(s.File, filepath.Clean(m.Filename), fnmatch.FNM_NOESCAPE) {
+		return false
+	}
+	if s.Check != "" && !fnmatch.Match(s.Check, m.Check, fnmatch.FNM_NOESCAPE) {
+		return false
+	}
+	if s.StartLine > 0 && m.Pos.Line < s.StartLine {
+		return false
+	}
+	if s.EndLine > 0 && m.Pos.Line > s.EndLine {
+		return false
+	}
+	return true
+}
@@ -15,6 +15,7 @@
 package thriftcheck
 
 import (
+	"fmt"
 	"reflect"
 	"testing"
 
@@ -110,3 +111,108 @@ func TestNolint(t *testing.T) {
 		})
 	}
 }
+
+func TestParseIgnoreDirectives(t *testing.T) {
+	src := `// thriftcheck:ignore
+struct A {
+  1: i32 a # thriftcheck:ignore b, c d
+  2: string url = "http://example.com" // thriftcheck:ignore e
+  // thriftcheck:ignore f
+  3: i32 b // thriftcheck:ignore
+}
+// not a thriftcheck:ignore directive
+`
+	want := ignoreDirectives{
+		2: nil,
+		3: {"b", "c", "d"},
+		4: {"e"},
+		6: nil,
+	}
+	if got := parseIgnoreDirectives([]byte(src)); !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+}
+
+func TestParseDisableDirectives(t *testing.T) {
+	tests := []struct {
+		desc       string
+		src        string
+		ranges     disabledRanges
+		unbalanced []string
+	}{
+		{
+			desc: "balanced",
+			src: `// thriftcheck:disable a, b
+struct A {
+  1: i32 a
+  # thriftcheck:enable b
+}
+// thriftcheck:enable a
+// thriftcheck:disable
+// thriftcheck:enable
+`,
+			ranges: disabledRanges{
+				{name: "a", start: 1, end: 6},
+				{name: "b", start: 1, end: 4},
+				{name: "", start: 7, end: 8},
+			},
+		},
+		{
+			desc: "unbalanced",
+			src: `// thriftcheck:enable a
+// thriftcheck:disable b
+struct A {}
+`,
+			ranges: disabledRanges{
+				{name: "b", start: 2},
+			},
+			unbalanced: []string{
+				"1: thriftcheck:enable a has no matching thriftcheck:disable",
+				"2: thriftcheck:disable b has no matching thriftcheck:enable",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			ranges, unbalanced := parseDisableDirectives([]byte(tt.src))
+			if !reflect.DeepEqual(ranges, tt.ranges) {
+				t.Errorf("expected ranges %v, got %v", tt.ranges, ranges)
+			}
+			var got []string
+			for _, u := range unbalanced {
+				got = append(got, fmt.Sprintf("%d: %s", u.line, u))
+			}
+			if !reflect.DeepEqual(got, tt.unbalanced) {
+				t.Errorf("expected unbalanced %v, got %v", tt.unbalanced, got)
+			}
+		})
+	}
+}
+
+func TestSuppressionMatches(t *testing.T) {
+	m := Message{Filename: "./idl/gen/a.thrift", Pos: ast.Position{Line: 10}, Check: "field.id.zero"}
+
+	tests := []struct {
+		s        Suppression
+		expected bool
+	}{
+		{Suppression{}, true},
+		{Suppression{File: "idl/gen/*"}, true},
+		{Suppression{File: "idl/*.thrift"}, true},
+		{Suppression{File: "src/*"}, false},
+		{Suppression{Check: "field.id.zero"}, true},
+		{Suppression{Check: "field.*"}, true},
+		{Suppression{Check: "field.id.missing"}, false},
+		{Suppression{File: "idl/*", Check: "enum.*"}, false},
+		{Suppression{StartLine: 5, EndLine: 10}, true},
+		{Suppression{StartLine: 11}, false},
+		{Suppression{EndLine: 9}, false},
+	}
+
+	for _, tt := range tests {
+		if actual := tt.s.Matches(m); actual != tt.expected {
+			t.Errorf("%+v: expected %v, got %v", tt.s, tt.expected, actual)
+		}
+	}
+}
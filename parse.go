@@ -15,10 +15,15 @@
 package thriftcheck
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
 	"io"
+	"io/fs"
 	"os"
 	"path/filepath"
+	"syscall"
+	"time"
 
 	"go.uber.org/thriftrw/ast"
 	"go.uber.org/thriftrw/idl"
@@ -36,23 +41,111 @@ func Parse(r io.Reader) (*ast.Program, *idl.Info, error) {
 	return prog, cfg.Info, err
 }
 
+// A ParseOption configures how ParseFile reads files.
+type ParseOption func(*parseConfig)
+
+type parseConfig struct {
+	readFile     func(filename string) ([]byte, error)
+	readAttempts int
+	readBackoff  time.Duration
+}
+
+// WithReadFile is a ParseOption that reads files using readFile rather than
+// os.ReadFile, such as to read them from somewhere other than the local
+// filesystem.
+func WithReadFile(readFile func(filename string) ([]byte, error)) ParseOption {
+	return func(cfg *parseConfig) {
+		cfg.readFile = readFile
+	}
+}
+
+// WithReadRetries is a ParseOption that sets how many times a read that fails
+// transiently is attempted, and the delay before the first retry, which
+// doubles after each attempt. By default, reads are attempted 3 times,
+// starting with a 10ms delay.
+func WithReadRetries(attempts int, backoff time.Duration) ParseOption {
+	return func(cfg *parseConfig) {
+		cfg.readAttempts = attempts
+		cfg.readBackoff = backoff
+	}
+}
+
+// transientErrors are the read errors that are worth retrying, such as those
+// seen on network filesystems while a file is being replaced.
+var transientErrors = []error{
+	syscall.EAGAIN,
+	syscall.EBUSY,
+	syscall.EINTR,
+	syscall.EIO,
+	syscall.ESTALE,
+	syscall.ETIMEDOUT,
+}
+
+func isTransient(err error) bool {
+	for _, transient := range transientErrors {
+		if errors.Is(err, transient) {
+			return true
+		}
+	}
+	return false
+}
+
+func (cfg *parseConfig) readFileWithRetry(filename string) ([]byte, error) {
+	backoff := cfg.readBackoff
+	for attempt := 1; ; attempt++ {
+		b, err := cfg.readFile(filename)
+		if err == nil || !isTransient(err) || attempt >= cfg.readAttempts {
+			return b, err
+		}
+		time.Sleep(backoff)
+		backoff *= 2
+	}
+}
+
 // ParseFile parses a Thrift file. The filename must appear in one of the
-// given directories.
-func ParseFile(filename string, dirs []string) (*ast.Program, *idl.Info, error) {
+// given directories, which are searched in order. Reads that fail
+// transiently are retried (see WithReadRetries). If the file can't be read
+// from one directory for a reason other than it not existing there, the
+// remaining directories are still searched, and that error is returned only
+// if the file isn't found in any of them.
+func ParseFile(filename string, dirs []string, options ...ParseOption) (*ast.Program, *idl.Info, error) {
+	cfg := parseConfig{
+		readFile:     os.ReadFile,
+		readAttempts: 3,
+		readBackoff:  10 * time.Millisecond,
+	}
+	for _, option := range options {
+		option(&cfg)
+	}
+
 	if filepath.IsAbs(filename) {
-		if f, err := os.Open(filename); err == nil {
-			defer f.Close()
-			return Parse(f)
-		}
-		return nil, nil, fmt.Errorf("%s not found", filename)
+		dirs = []string{""}
 	}
 
+	var readErr error
 	for _, dir := range dirs {
-		if f, err := os.Open(filepath.Join(dir, filename)); err == nil {
-			defer f.Close()
-			return Parse(f)
+		b, err := cfg.readFileWithRetry(filepath.Join(dir, filename))
+		if err == nil {
+			return Parse(bytes.NewReader(b))
+		}
+		if !errors.Is(err, fs.ErrNotExist) && readErr == nil {
+			readErr = err
 		}
 	}
 
-	return nil, nil, fmt.Errorf("%s not found in %s", filename, dirs)
+	if readErr != nil {
+		return nil, nil, readErr
+	}
+	if filepath.IsAbs(filename) {
+		return nil, nil, notFoundError(fmt.Sprintf("%s not found", filename))
+	}
+	return nil, nil, notFoundError(fmt.Sprintf("%s not found in %s", filename, dirs))
 }
+
+// notFoundError is the error returned by ParseFile when a file doesn't exist
+// in any of the directories. It matches fs.ErrNotExist.
+type notFoundError string
+
+func (e notFoundError) Error() string { return string(e) }
+
+func (e notFoundError) Is(target error) bool { return target == fs.ErrNotExist }
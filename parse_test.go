@@ -0,0 +1,166 @@
+// Copyright 2026 Pinterest
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package thriftcheck
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"testing"
+
+	"go.uber.org/thriftrw/ast"
+)
+
+// fakeReadFile returns a ParseOption that reads files with a function that
+// fails the first n reads with err and then reads from the real filesystem,
+// along with a pointer to the number of reads attempted.
+func fakeReadFile(n int, err error) (ParseOption, *int) {
+	attempts := 0
+	return WithReadFile(func(name string) ([]byte, error) {
+		attempts++
+		if attempts <= n {
+			return nil, err
+		}
+		return os.ReadFile(name)
+	}), &attempts
+}
+
+func TestParseFileRetriesTransientFailures(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "a.thrift"), []byte("struct A {}"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	transient := &fs.PathError{Op: "open", Path: "a.thrift", Err: syscall.ESTALE}
+	noBackoff := WithReadRetries(3, 0)
+
+	read, attempts := fakeReadFile(2, transient)
+	program, _, err := ParseFile("a.thrift", []string{dir}, read, noBackoff)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(program.Definitions) != 1 {
+		t.Errorf("expected 1 definition, got %d", len(program.Definitions))
+	}
+	if *attempts != 3 {
+		t.Errorf("expected 3 read attempts, got %d", *attempts)
+	}
+
+	read, attempts = fakeReadFile(3, transient)
+	if _, _, err := ParseFile("a.thrift", []string{dir}, read, noBackoff); !errors.Is(err, syscall.ESTALE) {
+		t.Errorf("expected %v, got %v", transient, err)
+	}
+	if *attempts != 3 {
+		t.Errorf("expected 3 read attempts, got %d", *attempts)
+	}
+}
+
+func TestParseFileDoesNotRetryPermanentFailures(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "a.thrift"), []byte("struct A {}"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	denied := &fs.PathError{Op: "open", Path: "a.thrift", Err: fs.ErrPermission}
+
+	// The first directory's file can't be read, so the second one's is used.
+	read, attempts := fakeReadFile(1, denied)
+	program, _, err := ParseFile("a.thrift", []string{t.TempDir(), dir}, read)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(program.Definitions) != 1 {
+		t.Errorf("expected 1 definition, got %d", len(program.Definitions))
+	}
+	if *attempts != 2 {
+		t.Errorf("expected 2 read attempts, got %d", *attempts)
+	}
+
+	// When the file isn't found anywhere else, the read error is returned
+	// rather than a not found error.
+	read, attempts = fakeReadFile(1, denied)
+	if _, _, err := ParseFile("a.thrift", []string{dir, t.TempDir()}, read); !errors.Is(err, fs.ErrPermission) {
+		t.Errorf("expected %v, got %v", denied, err)
+	}
+	if *attempts != 2 {
+		t.Errorf("expected 2 read attempts, got %d", *attempts)
+	}
+}
+
+func TestParseFileDoesNotRetryMissingFiles(t *testing.T) {
+	read, attempts := fakeReadFile(0, nil)
+	dirs := []string{t.TempDir(), t.TempDir()}
+	_, _, err := ParseFile("missing.thrift", dirs, read)
+	if err == nil || !strings.Contains(err.Error(), "not found") || !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("expected a not found error, got %v", err)
+	}
+	if *attempts != len(dirs) {
+		t.Errorf("expected %d read attempts, got %d", len(dirs), *attempts)
+	}
+}
+
+func TestLinterParseOptions(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "b.thrift"), []byte("struct B {}"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	read, attempts := fakeReadFile(0, nil)
+	l := NewLinter(Checks{}, WithParseOptions(read))
+	if program := l.programs.get(filepath.Join(dir, "b.thrift")); program == nil {
+		t.Fatal("expected the included file to be parsed")
+	}
+	if *attempts != 1 {
+		t.Errorf("expected the included file to be read with the linter's ParseOptions")
+	}
+}
+
+func TestLinterReportsUnreadableIncludes(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"a.thrift": "include \"b.thrift\"\ninclude \"c.thrift\"\ninclude \"d.thrift\"\nstruct A {}",
+		"b.thrift": "struct B {}",
+		"c.thrift": "struct {",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	read := WithReadFile(func(name string) ([]byte, error) {
+		if filepath.Base(name) == "b.thrift" {
+			return nil, &fs.PathError{Op: "open", Path: name, Err: syscall.EIO}
+		}
+		return os.ReadFile(name)
+	})
+
+	l := NewLinter(Checks{
+		NewCheck("symbols", func(c *C, p *ast.Program) { c.Symbols() }),
+	}, WithParseOptions(read, WithReadRetries(2, 0)))
+	msgs, err := l.LintFiles([]string{filepath.Join(dir, "a.thrift")})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// c.thrift can't be parsed and d.thrift doesn't exist, so only b.thrift
+	// is reported.
+	if len(msgs) != 1 || msgs[0].Check != "include.unreadable" || msgs[0].Severity != Warning || msgs[0].Filename != filepath.Join(dir, "b.thrift") {
+		t.Fatalf("expected an include.unreadable warning for b.thrift, got %v", msgs)
+	}
+	if !strings.Contains(msgs[0].Message, syscall.EIO.Error()) {
+		t.Errorf("expected the read error in the message, got %q", msgs[0].Message)
+	}
+}
@@ -0,0 +1,263 @@
+// Copyright 2025 Pinterest
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package thriftcheck
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+	"path/filepath"
+	"slices"
+	"sync"
+
+	"go.uber.org/thriftrw/ast"
+	"go.uber.org/thriftrw/idl"
+)
+
+// Symbol is a named definition and the file that defines it.
+type Symbol struct {
+	Definition ast.Definition
+	Filename   string
+}
+
+// Symbols is a table of the definitions that are visible from a file: its own
+// definitions by name, and the definitions of the files that it includes by
+// qualified "include.Name" names. Names are resolved the same way as
+// [Resolve], but included files are only parsed once when the table is
+// built, rather than once per lookup.
+//
+// The linter builds a table for each file on demand (see [C.Symbols] and
+// [C.SymbolsFor]) and keeps it for the rest of the run, and those tables
+// share the included files that they parse.
+type Symbols struct {
+	symbols map[string]Symbol
+}
+
+// NewSymbols builds the symbol table for a program that was parsed from
+// filename. Included files must exist in one of the given search directories;
+// those that can't be found or parsed don't contribute any symbols.
+func NewSymbols(filename string, program *ast.Program, dirs []string) *Symbols {
+	return newSymbols(filename, program, dirs, nil)
+}
+
+func newSymbols(filename string, program *ast.Program, dirs []string, programs *programCache) *Symbols {
+	s := &Symbols{symbols: make(map[string]Symbol)}
+	s.add("", filename, program)
+	for _, header := range program.Headers {
+		include, ok := header.(*ast.Include)
+		if !ok {
+			continue
+		}
+		path, ok := FindFile(include.Path, dirs)
+		if !ok {
+			continue
+		}
+		if included := programs.get(path); included != nil {
+			s.add(includeName(include)+".", path, included)
+		}
+	}
+	return s
+}
+
+// add records a program's definitions under the given name prefix. Like
+// Resolve, the first definition of a name wins.
+func (s *Symbols) add(prefix, filename string, program *ast.Program) {
+	for _, def := range program.Definitions {
+		name := prefix + def.Info().Name
+		if _, ok := s.symbols[name]; !ok {
+			s.symbols[name] = Symbol{Definition: def, Filename: filename}
+		}
+	}
+}
+
+// Lookup returns the definition of a name, which is either unqualified
+// ("Name") or qualified by an include ("include.Name").
+func (s *Symbols) Lookup(name string) (Symbol, bool) {
+	if s == nil {
+		return Symbol{}, false
+	}
+	sym, ok := s.symbols[name]
+	return sym, ok
+}
+
+// LookupType resolves a type reference like [ResolveType]: references to
+// typedefs and constants resolve to their own types. It returns nil if the
+// reference can't be resolved.
+func (s *Symbols) LookupType(ref ast.TypeReference) ast.Node {
+	sym, ok := s.Lookup(ref.Name)
+	if !ok {
+		return nil
+	}
+	switch t := sym.Definition.(type) {
+	case *ast.Constant:
+		return t.Type
+	case *ast.Typedef:
+		return t.Type
+	default:
+		return t
+	}
+}
+
+// programCache holds the programs parsed from included files during a run,
+// keyed by path, so that they're shared between symbol tables, along with the
+// symbol table built for each file. Files are parsed using the cache's ParseOptions, and
+// those that couldn't be parsed are cached as nil along with their errors. A
+// nil *programCache parses files without caching them.
+type programCache struct {
+	mu       sync.Mutex
+	options  []ParseOption
+	programs map[string]*ast.Program
+	errs     map[string]error
+	symbols  map[string]*Symbols
+}
+
+func newProgramCache(options ...ParseOption) *programCache {
+	return &programCache{
+		options:  options,
+		programs: make(map[string]*ast.Program),
+		errs:     make(map[string]error),
+		symbols:  make(map[string]*Symbols),
+	}
+}
+
+func (pc *programCache) get(path string) *ast.Program {
+	program, _ := pc.load(path)
+	return program
+}
+
+// load is like get, but also returns the error that the file couldn't be
+// parsed with.
+func (pc *programCache) load(path string) (*ast.Program, error) {
+	if pc == nil {
+		return parseIncluded(path)
+	}
+
+	pc.mu.Lock()
+	defer pc.mu.Unlock()
+	path = filepath.Clean(path)
+	program, ok := pc.programs[path]
+	if !ok {
+		var err error
+		program, err = parseIncluded(path, pc.options...)
+		pc.programs[path], pc.errs[path] = program, err
+	}
+	return program, pc.errs[path]
+}
+
+// failures returns a warning for each file that couldn't be read (after
+// retrying transient failures), since the symbol tables and checks that
+// needed it would otherwise silently go without it. Files that don't exist
+// or can't be parsed aren't included: they're either expected to be missing,
+// like baseline files, or reported as parse errors when they're linted.
+func (pc *programCache) failures() (messages Messages) {
+	if pc == nil {
+		return nil
+	}
+
+	pc.mu.Lock()
+	defer pc.mu.Unlock()
+	for _, path := range slices.Sorted(maps.Keys(pc.errs)) {
+		err := pc.errs[path]
+		if err == nil || errors.Is(err, fs.ErrNotExist) || errors.As(err, new(*idl.ParseError)) {
+			continue
+		}
+		messages = append(messages, Message{
+			Filename: path,
+			Check:    "include.unreadable",
+			Severity: Warning,
+			Message:  fmt.Sprintf("included file couldn't be read: %v", err),
+		})
+	}
+	return messages
+}
+
+// add records the program of a linted file, so that files which include it
+// share it rather than parsing it again. The file's symbol table is dropped
+// if it was built from a different program.
+func (pc *programCache) add(path string, program *ast.Program) {
+	if pc == nil {
+		return
+	}
+
+	pc.mu.Lock()
+	defer pc.mu.Unlock()
+	key := filepath.Clean(path)
+	if pc.programs[key] != program {
+		delete(pc.symbols, key)
+	}
+	pc.programs[key] = program
+	delete(pc.errs, key)
+}
+
+// reset empties the cache, so that the next run parses files again and sees
+// any changes to them.
+func (pc *programCache) reset() {
+	if pc == nil {
+		return
+	}
+
+	pc.mu.Lock()
+	defer pc.mu.Unlock()
+	clear(pc.programs)
+	clear(pc.errs)
+	clear(pc.symbols)
+}
+
+// symbolsFor returns the symbol table of a file, building it the first time
+// it's needed. If program is nil, the file's cached program is used. It
+// returns nil if the file can't be parsed.
+func (pc *programCache) symbolsFor(filename string, program *ast.Program, dirs []string) *Symbols {
+	if pc == nil {
+		if program == nil {
+			program, _ = parseIncluded(filename)
+		}
+		if program == nil {
+			return nil
+		}
+		return newSymbols(filename, program, dirs, nil)
+	}
+
+	key := filepath.Clean(filename)
+	pc.mu.Lock()
+	s, ok := pc.symbols[key]
+	pc.mu.Unlock()
+	if ok {
+		return s
+	}
+
+	if program == nil {
+		program = pc.get(filename)
+	}
+	if program != nil {
+		s = newSymbols(filename, program, dirs, pc)
+	}
+
+	pc.mu.Lock()
+	defer pc.mu.Unlock()
+	if cached, ok := pc.symbols[key]; ok {
+		return cached
+	}
+	pc.symbols[key] = s
+	return s
+}
+
+func parseIncluded(path string, options ...ParseOption) (*ast.Program, error) {
+	program, _, err := ParseFile(path, []string{""}, options...)
+	if err != nil {
+		return nil, err
+	}
+	return program, nil
+}
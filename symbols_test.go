@@ -0,0 +1,140 @@
+// Copyright 2025 Pinterest
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package thriftcheck
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"go.uber.org/thriftrw/ast"
+)
+
+func TestSymbols(t *testing.T) {
+	filename := filepath.Join("testdata", "users.thrift")
+	program, _, err := ParseFile(filename, []string{"."})
+	if err != nil {
+		t.Fatal(err)
+	}
+	symbols := NewSymbols(filename, program, []string{"testdata"})
+
+	sym, ok := symbols.Lookup("common.Status")
+	if !ok {
+		t.Fatal("expected common.Status to resolve")
+	}
+	if e, ok := sym.Definition.(*ast.Enum); !ok || e.Name != "Status" {
+		t.Errorf("expected the Status enum, got %#v", sym.Definition)
+	}
+	if want := filepath.Join("testdata", "common.thrift"); sym.Filename != want {
+		t.Errorf("expected common.Status to be defined in %s, got %s", want, sym.Filename)
+	}
+
+	if sym, ok := symbols.Lookup("User"); !ok || sym.Filename != filename {
+		t.Errorf("expected User to be defined in %s, got %#v", filename, sym)
+	}
+	for _, name := range []string{"Status", "common.User", "missing.Status"} {
+		if sym, ok := symbols.Lookup(name); ok {
+			t.Errorf("expected %s not to resolve, got %#v", name, sym)
+		}
+	}
+
+	aliased := NewSymbols("t.thrift", &ast.Program{Headers: []ast.Header{
+		&ast.Include{Name: "c", Path: "common.thrift"},
+	}}, []string{"testdata"})
+	if _, ok := aliased.Lookup("c.Status"); !ok {
+		t.Error("expected c.Status to resolve through the aliased include")
+	}
+	if sym, ok := aliased.Lookup("common.Status"); ok {
+		t.Errorf("expected common.Status not to resolve, got %#v", sym)
+	}
+
+	var none *Symbols
+	if _, ok := none.Lookup("User"); ok {
+		t.Error("expected a nil table to resolve nothing")
+	}
+}
+
+func TestSymbolsLookupType(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "shared.thrift"), []byte("typedef i64 Id\nenum Kind { A }"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	program, _, err := Parse(strings.NewReader(`include "shared.thrift"`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	symbols := newSymbols("t.thrift", program, []string{dir}, newProgramCache())
+
+	if bt, ok := symbols.LookupType(ast.TypeReference{Name: "shared.Id"}).(ast.BaseType); !ok || bt.ID != ast.I64TypeID {
+		t.Errorf("expected shared.Id to resolve to i64, got %#v", bt)
+	}
+	if _, ok := symbols.LookupType(ast.TypeReference{Name: "shared.Kind"}).(*ast.Enum); !ok {
+		t.Error("expected shared.Kind to resolve to an enum")
+	}
+	if got := symbols.LookupType(ast.TypeReference{Name: "Kind"}); got != nil {
+		t.Errorf("expected Kind not to resolve, got %#v", got)
+	}
+}
+
+func TestProgramCache(t *testing.T) {
+	filename := filepath.Join("testdata", "common.thrift")
+	cache := newProgramCache()
+	first := cache.get(filename)
+	if first == nil {
+		t.Fatalf("expected %s to parse", filename)
+	}
+	if cache.get(filename) != first {
+		t.Error("expected the cached program to be reused")
+	}
+	if cache.get(filepath.Join("testdata", "missing.thrift")) != nil {
+		t.Error("expected a missing file to have no program")
+	}
+}
+
+func TestSymbolsForInFinalize(t *testing.T) {
+	dir := t.TempDir()
+	shared := filepath.Join(dir, "shared.thrift")
+	root := filepath.Join(dir, "root.thrift")
+	if err := os.WriteFile(shared, []byte("enum Kind { A }"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(root, []byte("include \"shared.thrift\"\nstruct S { 1: shared.Kind kind }"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	var walked, finalized *Symbols
+	check := NewMultiFileCheck("symbols", func(c *C, s *ast.Struct) {
+		walked = c.Symbols()
+	}, func(c *C) {
+		if c.Symbols() != nil {
+			t.Error("expected no current file's table in finalize")
+		}
+		finalized = c.SymbolsFor(root)
+		if sym, ok := c.SymbolsFor(shared).Lookup("Kind"); !ok || sym.Filename != shared {
+			t.Errorf("expected Kind to be defined in %s, got %#v", shared, sym)
+		}
+	})
+	if _, err := NewLinter(Checks{check}).LintFiles([]string{root}); err != nil {
+		t.Fatal(err)
+	}
+
+	if walked == nil || finalized != walked {
+		t.Fatal("expected finalize to share the table built while linting")
+	}
+	if sym, ok := finalized.Lookup("shared.Kind"); !ok || sym.Filename != shared {
+		t.Errorf("expected shared.Kind to be defined in %s, got %#v", shared, sym)
+	}
+}
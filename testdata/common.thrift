@@ -0,0 +1,5 @@
+include "users.thrift"
+
+enum Status {
+  ACTIVE = 1
+}
@@ -0,0 +1,6 @@
+include "common.thrift"
+
+struct User {
+  0: optional string name
+  1: optional common.Status status
+}
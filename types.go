@@ -80,7 +80,7 @@ var typeMatchers = map[string]typeMatcher{
 // Match a generic type, resolving any type references.
 func matchType[T ast.Node](c *C, n ast.Node) bool {
 	if ref, ok := n.(ast.TypeReference); ok {
-		if n = c.ResolveType(ref); n == nil {
+		if n = c.Symbols().LookupType(ref); n == nil {
 			return false
 		}
 	}
@@ -92,7 +92,7 @@ func matchType[T ast.Node](c *C, n ast.Node) bool {
 // Match a structure type, resolving any type references.
 func matchStructureType(c *C, n ast.Node, t ast.StructureType) bool {
 	if ref, ok := n.(ast.TypeReference); ok {
-		if n = c.ResolveType(ref); n == nil {
+		if n = c.Symbols().LookupType(ref); n == nil {
 			return false
 		}
 	}